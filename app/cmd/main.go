@@ -21,14 +21,28 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/moneyforward/auriga/app/internal/event"
 
+	"github.com/moneyforward/auriga/app/pkg/aws"
+	"github.com/moneyforward/auriga/app/pkg/cache"
+	"github.com/moneyforward/auriga/app/pkg/dedupe"
+	"github.com/moneyforward/auriga/app/pkg/queue"
 	"github.com/moneyforward/auriga/app/pkg/slack/listener"
+	"github.com/moneyforward/auriga/app/pkg/state"
 
 	"github.com/joho/godotenv"
 
 	"github.com/moneyforward/auriga/app/internal/handler"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/internal/repository"
+	"github.com/moneyforward/auriga/app/pkg/google/calendar"
 
 	"github.com/moneyforward/auriga/app/pkg/slack"
 )
@@ -38,14 +52,52 @@ const (
 	slackAppTokenKey = "SLACK_APP_TOKEN"
 
 	slackSigningSecretKey = "SLACK_SIGNING_SECRET"
+
+	googleCredentialsKey     = "GOOGLE_APPLICATION_CREDENTIALS"
+	googleCalendarIDKey      = "GOOGLE_CALENDAR_ID"
+	googleCalendarSubjectKey = "GOOGLE_CALENDAR_SUBJECT"
+	timeZoneKey              = "AURIGA_TIME_ZONE"
+	localeKey                = "AURIGA_LOCALE"
+	noGuestsKey              = "AURIGA_NO_GUESTS"
+	noExternalKey            = "AURIGA_NO_EXTERNAL"
+	domainsKey               = "AURIGA_DOMAINS"
+	remindMaxKey             = "AURIGA_REMIND_MAX"
+	remindOptOutKey          = "AURIGA_REMIND_OPT_OUT"
+	listenerKey              = "AURIGA_LISTENER"
+	httpAddrKey              = "AURIGA_HTTP_ADDR"
+	workersKey               = "AURIGA_WORKERS"
+	queueSizeKey             = "AURIGA_QUEUE_SIZE"
+	lambdaAsyncKey           = "AURIGA_LAMBDA_ASYNC"
+	jobTimeoutKey            = "AURIGA_JOB_TIMEOUT"
+	dedupeTableKey           = "AURIGA_DEDUPE_TABLE"
+	userCacheTTLKey          = "AURIGA_USER_CACHE_TTL"
+	userCacheSizeKey         = "AURIGA_USER_CACHE_SIZE"
+	userCacheTableKey        = "AURIGA_USER_CACHE_TABLE"
+	stateTableKey            = "AURIGA_STATE_TABLE"
+	stateFileKey             = "AURIGA_STATE_FILE"
+	dynamoDBEndpointKey      = "AURIGA_DYNAMODB_ENDPOINT"
+	awsRegionKey             = "AWS_REGION"
+	awsLambdaFunctionNameKey = "AWS_LAMBDA_FUNCTION_NAME"
+
+	defaultGoogleCalendarID = "primary"
+	defaultTimeZone         = "Asia/Tokyo"
+	defaultHTTPAddr         = ":8080"
+
+	listenerSocket = "socket"
+	listenerLambda = "lambda"
+	listenerHTTP   = "http"
 )
 
 var (
-	isDebug bool
+	isDebug      bool
+	listenerType string
+	httpAddr     string
 )
 
 func run(ctx context.Context) error {
 	flag.BoolVar(&isDebug, "debug", false, "debug mode")
+	flag.StringVar(&listenerType, "listener", "", "how to receive requests from Slack: socket, lambda or http (env: AURIGA_LISTENER, default: socket in debug mode, otherwise lambda)")
+	flag.StringVar(&httpAddr, "addr", "", "address the http listener serves on (env: AURIGA_HTTP_ADDR, default: :8080)")
 	flag.Parse()
 
 	var eventListener slack.Listener
@@ -55,7 +107,9 @@ func run(ctx context.Context) error {
 		if err := godotenv.Load(); err != nil {
 			return fmt.Errorf("load env file failed: %v", err)
 		}
-		slackClientOptions = append(slackClientOptions, slack.AppTokenOption(os.Getenv(slackAppTokenKey)))
+	}
+	if appToken := os.Getenv(slackAppTokenKey); appToken != "" {
+		slackClientOptions = append(slackClientOptions, slack.AppTokenOption(appToken))
 	}
 
 	slackClient, err := slack.NewClient(os.Getenv(slackBotTokenKey), slackClientOptions...)
@@ -63,24 +117,268 @@ func run(ctx context.Context) error {
 		return err
 	}
 
-	handlerFactory := handler.NewHandlerFactory(slackClient)
+	calendarClient, err := newCalendarClient()
+	if err != nil {
+		return err
+	}
+
+	location, err := time.LoadLocation(getEnv(timeZoneKey, defaultTimeZone))
+	if err != nil {
+		return fmt.Errorf("load time zone failed: %v", err)
+	}
+
+	defaultLocale, ok := i18n.ParseLocale(getEnv(localeKey, string(i18n.DefaultLocale)))
+	if !ok {
+		return fmt.Errorf("unsupported locale: %s", os.Getenv(localeKey))
+	}
+
+	userFilter, err := newUserFilter()
+	if err != nil {
+		return err
+	}
+
+	reminderPolicy, err := newReminderPolicy()
+	if err != nil {
+		return err
+	}
+
+	var repositoryOptions []repository.Option
+	// time.Local is named "Local", which is not an IANA time zone name
+	if location != time.Local {
+		repositoryOptions = append(repositoryOptions, repository.TimeZoneOption(location.String()))
+	}
+	userCache, err := newUserCache()
+	if err != nil {
+		return err
+	}
+	if userCache != nil {
+		repositoryOptions = append(repositoryOptions, repository.UserCacheOption(userCache))
+	}
+	stateStore, err := newStateStore()
+	if err != nil {
+		return err
+	}
+	if stateStore != nil {
+		repositoryOptions = append(repositoryOptions, repository.StateStoreOption(stateStore))
+	}
+	repositoryFactory := repository.NewFactory(slackClient, calendarClient, getEnv(googleCalendarIDKey, defaultGoogleCalendarID), repositoryOptions...)
+
+	handlerFactory := handler.NewHandlerFactory(repositoryFactory, location, defaultLocale, userFilter, reminderPolicy)
 	eventHandlerFactory := event.NewEventHandlerFactory(slackClient.GetAppUserID(), handlerFactory)
 
-	if isDebug {
-		socketClient := slack.NewSocketClient(slackClient, true)
-		eventListener = listener.NewSocketListener(socketClient, eventHandlerFactory.GetFunc())
-	} else {
+	if listenerType == "" {
+		listenerType = os.Getenv(listenerKey)
+	}
+	if listenerType == "" {
+		listenerType = listenerLambda
+		if isDebug {
+			listenerType = listenerSocket
+		}
+	}
+	q, err := newQueue(listenerType)
+	if err != nil {
+		return err
+	}
+	dedupeStore, err := newDedupeStore()
+	if err != nil {
+		return err
+	}
+	jobTimeout, err := time.ParseDuration(getEnv(jobTimeoutKey, listener.DefaultJobTimeout.String()))
+	if err != nil || jobTimeout <= 0 {
+		return fmt.Errorf("invalid %s: %s", jobTimeoutKey, os.Getenv(jobTimeoutKey))
+	}
+	listenerOptions := []listener.Option{listener.QueueOption(q), listener.DedupeOption(dedupeStore), listener.JobTimeoutOption(jobTimeout)}
+	switch listenerType {
+	case listenerSocket:
+		socketClient := slack.NewSocketClient(slackClient, isDebug)
+		eventListener = listener.NewSocketListener(socketClient, eventHandlerFactory.GetFunc(), eventHandlerFactory.GetSlashCommandFunc(), eventHandlerFactory.GetInteractionFunc(), listenerOptions...)
+	case listenerLambda:
 		fmt.Println("this is prod mode!!")
-		eventListener = listener.NewLambdaListener(eventHandlerFactory.GetFunc(), os.Getenv(slackSigningSecretKey))
+		eventListener = listener.NewLambdaListener(eventHandlerFactory.GetFunc(), eventHandlerFactory.GetSlashCommandFunc(), eventHandlerFactory.GetInteractionFunc(), os.Getenv(slackSigningSecretKey), listenerOptions...)
+	case listenerHTTP:
+		if httpAddr == "" {
+			httpAddr = getEnv(httpAddrKey, defaultHTTPAddr)
+		}
+		eventListener = listener.NewHTTPListener(httpAddr, eventHandlerFactory.GetFunc(), eventHandlerFactory.GetSlashCommandFunc(), eventHandlerFactory.GetInteractionFunc(), os.Getenv(slackSigningSecretKey), listenerOptions...)
+	default:
+		return fmt.Errorf("unknown listener: %s", listenerType)
 	}
 
 	eventListener.Listen(ctx)
 
-	return nil
+	// wait for the requests in progress
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return q.Shutdown(shutdownCtx)
+}
+
+// newDedupeStore builds the store of the handled events.
+// The events are remembered in a DynamoDB table if AURIGA_DEDUPE_TABLE is set, otherwise in memory.
+func newDedupeStore() (dedupe.Store, error) {
+	tableName := os.Getenv(dedupeTableKey)
+	if tableName == "" {
+		return dedupe.NewLRUStore(dedupe.DefaultSize, dedupe.DefaultTTL), nil
+	}
+	dynamoDBClient, err := newDynamoDBClient()
+	if err != nil {
+		return nil, err
+	}
+	return dedupe.NewDynamoDBStore(dynamoDBClient, tableName, dedupe.DefaultTTL), nil
+}
+
+// newUserCache builds the cache of the users looked up from Slack, or returns nil if AURIGA_USER_CACHE_TTL is 0.
+// The users are cached in a DynamoDB table if AURIGA_USER_CACHE_TABLE is set, otherwise in memory.
+func newUserCache() (cache.Store, error) {
+	ttl, err := time.ParseDuration(getEnv(userCacheTTLKey, cache.DefaultTTL.String()))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", userCacheTTLKey, err)
+	}
+	if ttl <= 0 {
+		return nil, nil
+	}
+	tableName := os.Getenv(userCacheTableKey)
+	if tableName == "" {
+		size, err := strconv.Atoi(getEnv(userCacheSizeKey, strconv.Itoa(cache.DefaultSize)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", userCacheSizeKey, err)
+		}
+		return cache.NewLRUStore(size, ttl), nil
+	}
+	dynamoDBClient, err := newDynamoDBClient()
+	if err != nil {
+		return nil, err
+	}
+	return cache.NewDynamoDBStore(dynamoDBClient, tableName, ttl), nil
+}
+
+// newStateStore builds the store of the state of the bot such as the events created from the messages.
+// The state is kept in a DynamoDB table if AURIGA_STATE_TABLE is set, in a local file if AURIGA_STATE_FILE is set,
+// or returns nil to keep it in memory.
+func newStateStore() (state.Store, error) {
+	if tableName := os.Getenv(stateTableKey); tableName != "" {
+		dynamoDBClient, err := newDynamoDBClient()
+		if err != nil {
+			return nil, err
+		}
+		return state.NewDynamoDBStore(dynamoDBClient, tableName), nil
+	}
+	if path := os.Getenv(stateFileKey); path != "" {
+		store, err := state.NewFileStore(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", stateFileKey, err)
+		}
+		return store, nil
+	}
+	return nil, nil
+}
+
+func newDynamoDBClient() (aws.DynamoDBClient, error) {
+	credentials, err := aws.CredentialsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	var options []aws.Option
+	if endpoint := os.Getenv(dynamoDBEndpointKey); endpoint != "" {
+		// e.g. DynamoDB Local
+		options = append(options, aws.EndpointOption(endpoint))
+	}
+	return aws.NewDynamoDBClient(credentials, os.Getenv(awsRegionKey), options...), nil
+}
+
+// newQueue builds the queue to process the requests in the background.
+// The Lambda listener invokes the function itself asynchronously unless AURIGA_LAMBDA_ASYNC is false,
+// since a Lambda function cannot run after returning the response. The others use a worker pool.
+func newQueue(listenerType string) (queue.Queue, error) {
+	if listenerType == listenerLambda {
+		async, err := strconv.ParseBool(getEnv(lambdaAsyncKey, "true"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", lambdaAsyncKey, err)
+		}
+		if !async {
+			// Slack retries the requests which take more than 3 seconds
+			return queue.NewInline(), nil
+		}
+		credentials, err := aws.CredentialsFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		lambdaClient := aws.NewLambdaClient(credentials, os.Getenv(awsRegionKey))
+		return queue.NewLambdaQueue(lambdaClient, os.Getenv(awsLambdaFunctionNameKey)), nil
+	}
+	workers, err := strconv.Atoi(getEnv(workersKey, strconv.Itoa(queue.DefaultConcurrency)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", workersKey, err)
+	}
+	size, err := strconv.Atoi(getEnv(queueSizeKey, strconv.Itoa(queue.DefaultCapacity)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", queueSizeKey, err)
+	}
+	return queue.NewWorkerPool(workers, size), nil
+}
+
+// newUserFilter builds the filter applied to the lists by default,
+// which the mention can tighten with "--no-guests", "--no-external" and "--domain=..."
+func newUserFilter() (*model.UserFilter, error) {
+	filter := &model.UserFilter{}
+	for key, flag := range map[string]*bool{noGuestsKey: &filter.NoGuests, noExternalKey: &filter.NoExternal} {
+		if v := os.Getenv(key); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %v", key, err)
+			}
+			*flag = b
+		}
+	}
+	if domains := os.Getenv(domainsKey); domains != "" {
+		filter.Domains = model.ParseDomains(domains)
+	}
+	return filter, nil
+}
+
+// newReminderPolicy builds the limits of the reminders sent by "pending --remind"
+func newReminderPolicy() (*model.ReminderPolicy, error) {
+	policy := &model.ReminderPolicy{}
+	if v := os.Getenv(remindMaxKey); v != "" {
+		maxMessages, err := strconv.Atoi(v)
+		if err != nil || maxMessages <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", remindMaxKey, v)
+		}
+		policy.MaxMessages = maxMessages
+	}
+	if optOut := os.Getenv(remindOptOutKey); optOut != "" {
+		for _, userID := range strings.Split(optOut, ",") {
+			if userID = strings.TrimSpace(userID); userID != "" {
+				policy.OptOutUserIDs = append(policy.OptOutUserIDs, userID)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// newCalendarClient builds a Google Calendar client if the credentials are set, otherwise returns nil
+func newCalendarClient() (calendar.Client, error) {
+	credentialsFile := os.Getenv(googleCredentialsKey)
+	if credentialsFile == "" {
+		return nil, nil
+	}
+	tokenSource, err := calendar.NewServiceAccountTokenSource(credentialsFile, os.Getenv(googleCalendarSubjectKey))
+	if err != nil {
+		return nil, err
+	}
+	return calendar.NewClient(tokenSource), nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
 }
 
 func main() {
-	ctx := context.Background()
+	// the http listener shuts down gracefully when the process is stopped
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	if err := run(ctx); err != nil {
 		panic(err)
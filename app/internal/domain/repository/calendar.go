@@ -0,0 +1,33 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+//go:generate mockgen -source=calendar.go -destination mock/calendar.go
+package repository
+
+import (
+	"context"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+type CalendarRepository interface {
+	// CreateEvent creates a calendar event and invites the attendees
+	CreateEvent(ctx context.Context, event *model.CalendarEvent) (*model.CalendarEvent, error)
+
+	// UpdateAttendees invites added and cancels the invitations of removed, keeping the other attendees.
+	// It returns the attendees actually changed, that is, without added already invited and removed not invited.
+	UpdateAttendees(ctx context.Context, eventID string, added, removed []string) (*model.AttendeeChange, error)
+}
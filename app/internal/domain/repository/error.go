@@ -20,4 +20,7 @@ package repository
 type ErrorRepository interface {
 	ErrThreadNotFound(err error) bool
 	ErrUserNotFound(err error) bool
+	ErrCalendarNotConfigured(err error) bool
+	// ErrMembersNotFound reports whether the members of the user group or the channel could not be listed
+	ErrMembersNotFound(err error) bool
 }
@@ -0,0 +1,36 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+//go:generate mockgen -source=event_binding.go -destination mock/event_binding.go
+package repository
+
+import (
+	"context"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+type EventBindingRepository interface {
+	// SaveEventBinding stores the binding of the message, replacing the previous one
+	SaveEventBinding(ctx context.Context, binding *model.EventBinding) error
+
+	// GetEventBinding gets the binding of the message, or nil if no event is created from it
+	GetEventBinding(ctx context.Context, channelID, ts string) (*model.EventBinding, error)
+
+	// LockEventBinding waits until the binding of the message is not updated by the others,
+	// including the other Lambda instances, and returns the func to let them update it
+	LockEventBinding(ctx context.Context, channelID, ts string) (func(), error)
+}
@@ -21,4 +21,7 @@ package repository
 type Factory interface {
 	SlackRepository() SlackRepository
 	ErrorRepository() ErrorRepository
+	CalendarRepository() CalendarRepository
+	EventBindingRepository() EventBindingRepository
+	StateRepository() StateRepository
 }
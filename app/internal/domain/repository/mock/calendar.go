@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: calendar.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/moneyforward/auriga/app/internal/model"
+)
+
+// MockCalendarRepository is a mock of CalendarRepository interface.
+type MockCalendarRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarRepositoryMockRecorder
+}
+
+// MockCalendarRepositoryMockRecorder is the mock recorder for MockCalendarRepository.
+type MockCalendarRepositoryMockRecorder struct {
+	mock *MockCalendarRepository
+}
+
+// NewMockCalendarRepository creates a new mock instance.
+func NewMockCalendarRepository(ctrl *gomock.Controller) *MockCalendarRepository {
+	mock := &MockCalendarRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarRepository) EXPECT() *MockCalendarRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEvent mocks base method.
+func (m *MockCalendarRepository) CreateEvent(ctx context.Context, event *model.CalendarEvent) (*model.CalendarEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", ctx, event)
+	ret0, _ := ret[0].(*model.CalendarEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockCalendarRepositoryMockRecorder) CreateEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockCalendarRepository)(nil).CreateEvent), ctx, event)
+}
+
+// UpdateAttendees mocks base method.
+func (m *MockCalendarRepository) UpdateAttendees(ctx context.Context, eventID string, added, removed []string) (*model.AttendeeChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAttendees", ctx, eventID, added, removed)
+	ret0, _ := ret[0].(*model.AttendeeChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAttendees indicates an expected call of UpdateAttendees.
+func (mr *MockCalendarRepositoryMockRecorder) UpdateAttendees(ctx, eventID, added, removed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttendees", reflect.TypeOf((*MockCalendarRepository)(nil).UpdateAttendees), ctx, eventID, added, removed)
+}
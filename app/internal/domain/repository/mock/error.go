@@ -33,6 +33,34 @@ func (m *MockErrorRepository) EXPECT() *MockErrorRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ErrCalendarNotConfigured mocks base method.
+func (m *MockErrorRepository) ErrCalendarNotConfigured(err error) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ErrCalendarNotConfigured", err)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ErrCalendarNotConfigured indicates an expected call of ErrCalendarNotConfigured.
+func (mr *MockErrorRepositoryMockRecorder) ErrCalendarNotConfigured(err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrCalendarNotConfigured", reflect.TypeOf((*MockErrorRepository)(nil).ErrCalendarNotConfigured), err)
+}
+
+// ErrMembersNotFound mocks base method.
+func (m *MockErrorRepository) ErrMembersNotFound(err error) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ErrMembersNotFound", err)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ErrMembersNotFound indicates an expected call of ErrMembersNotFound.
+func (mr *MockErrorRepositoryMockRecorder) ErrMembersNotFound(err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrMembersNotFound", reflect.TypeOf((*MockErrorRepository)(nil).ErrMembersNotFound), err)
+}
+
 // ErrThreadNotFound mocks base method.
 func (m *MockErrorRepository) ErrThreadNotFound(err error) bool {
 	m.ctrl.T.Helper()
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event_binding.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/moneyforward/auriga/app/internal/model"
+)
+
+// MockEventBindingRepository is a mock of EventBindingRepository interface.
+type MockEventBindingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventBindingRepositoryMockRecorder
+}
+
+// MockEventBindingRepositoryMockRecorder is the mock recorder for MockEventBindingRepository.
+type MockEventBindingRepositoryMockRecorder struct {
+	mock *MockEventBindingRepository
+}
+
+// NewMockEventBindingRepository creates a new mock instance.
+func NewMockEventBindingRepository(ctrl *gomock.Controller) *MockEventBindingRepository {
+	mock := &MockEventBindingRepository{ctrl: ctrl}
+	mock.recorder = &MockEventBindingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventBindingRepository) EXPECT() *MockEventBindingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetEventBinding mocks base method.
+func (m *MockEventBindingRepository) GetEventBinding(ctx context.Context, channelID, ts string) (*model.EventBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventBinding", ctx, channelID, ts)
+	ret0, _ := ret[0].(*model.EventBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventBinding indicates an expected call of GetEventBinding.
+func (mr *MockEventBindingRepositoryMockRecorder) GetEventBinding(ctx, channelID, ts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventBinding", reflect.TypeOf((*MockEventBindingRepository)(nil).GetEventBinding), ctx, channelID, ts)
+}
+
+// LockEventBinding mocks base method.
+func (m *MockEventBindingRepository) LockEventBinding(ctx context.Context, channelID, ts string) (func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockEventBinding", ctx, channelID, ts)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockEventBinding indicates an expected call of LockEventBinding.
+func (mr *MockEventBindingRepositoryMockRecorder) LockEventBinding(ctx, channelID, ts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockEventBinding", reflect.TypeOf((*MockEventBindingRepository)(nil).LockEventBinding), ctx, channelID, ts)
+}
+
+// SaveEventBinding mocks base method.
+func (m *MockEventBindingRepository) SaveEventBinding(ctx context.Context, binding *model.EventBinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEventBinding", ctx, binding)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEventBinding indicates an expected call of SaveEventBinding.
+func (mr *MockEventBindingRepositoryMockRecorder) SaveEventBinding(ctx, binding interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEventBinding", reflect.TypeOf((*MockEventBindingRepository)(nil).SaveEventBinding), ctx, binding)
+}
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/moneyforward/auriga/app/internal/model"
+	slack "github.com/slack-go/slack"
 )
 
 // MockSlackRepository is a mock of SlackRepository interface.
@@ -50,6 +51,110 @@ func (mr *MockSlackRepositoryMockRecorder) GetParentMessage(ctx, channelID, ts i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParentMessage", reflect.TypeOf((*MockSlackRepository)(nil).GetParentMessage), ctx, channelID, ts)
 }
 
+// GetPermalink mocks base method.
+func (m *MockSlackRepository) GetPermalink(ctx context.Context, channelID, ts string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermalink", ctx, channelID, ts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermalink indicates an expected call of GetPermalink.
+func (mr *MockSlackRepositoryMockRecorder) GetPermalink(ctx, channelID, ts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermalink", reflect.TypeOf((*MockSlackRepository)(nil).GetPermalink), ctx, channelID, ts)
+}
+
+// GetUserLocale mocks base method.
+func (m *MockSlackRepository) GetUserLocale(ctx context.Context, userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLocale", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLocale indicates an expected call of GetUserLocale.
+func (mr *MockSlackRepositoryMockRecorder) GetUserLocale(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLocale", reflect.TypeOf((*MockSlackRepository)(nil).GetUserLocale), ctx, userID)
+}
+
+// GetUserTimeZone mocks base method.
+func (m *MockSlackRepository) GetUserTimeZone(ctx context.Context, userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTimeZone", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTimeZone indicates an expected call of GetUserTimeZone.
+func (mr *MockSlackRepositoryMockRecorder) GetUserTimeZone(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimeZone", reflect.TypeOf((*MockSlackRepository)(nil).GetUserTimeZone), ctx, userID)
+}
+
+// InvalidateUser mocks base method.
+func (m *MockSlackRepository) InvalidateUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUser indicates an expected call of InvalidateUser.
+func (mr *MockSlackRepositoryMockRecorder) InvalidateUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUser", reflect.TypeOf((*MockSlackRepository)(nil).InvalidateUser), ctx, userID)
+}
+
+// ListChannelMembers mocks base method.
+func (m *MockSlackRepository) ListChannelMembers(ctx context.Context, channelID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelMembers", ctx, channelID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelMembers indicates an expected call of ListChannelMembers.
+func (mr *MockSlackRepositoryMockRecorder) ListChannelMembers(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelMembers", reflect.TypeOf((*MockSlackRepository)(nil).ListChannelMembers), ctx, channelID)
+}
+
+// ListThreadReplies mocks base method.
+func (m *MockSlackRepository) ListThreadReplies(ctx context.Context, channelID, ts string) ([]*model.SlackReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListThreadReplies", ctx, channelID, ts)
+	ret0, _ := ret[0].([]*model.SlackReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListThreadReplies indicates an expected call of ListThreadReplies.
+func (mr *MockSlackRepositoryMockRecorder) ListThreadReplies(ctx, channelID, ts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListThreadReplies", reflect.TypeOf((*MockSlackRepository)(nil).ListThreadReplies), ctx, channelID, ts)
+}
+
+// ListUserGroupMembers mocks base method.
+func (m *MockSlackRepository) ListUserGroupMembers(ctx context.Context, userGroupID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserGroupMembers", ctx, userGroupID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserGroupMembers indicates an expected call of ListUserGroupMembers.
+func (mr *MockSlackRepositoryMockRecorder) ListUserGroupMembers(ctx, userGroupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserGroupMembers", reflect.TypeOf((*MockSlackRepository)(nil).ListUserGroupMembers), ctx, userGroupID)
+}
+
 // ListUsersEmail mocks base method.
 func (m *MockSlackRepository) ListUsersEmail(ctx context.Context, userID []string) ([]*model.SlackUserEmail, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +170,34 @@ func (mr *MockSlackRepositoryMockRecorder) ListUsersEmail(ctx, userID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersEmail", reflect.TypeOf((*MockSlackRepository)(nil).ListUsersEmail), ctx, userID)
 }
 
+// OpenView mocks base method.
+func (m *MockSlackRepository) OpenView(ctx context.Context, triggerID string, view slack.ModalViewRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenView", ctx, triggerID, view)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenView indicates an expected call of OpenView.
+func (mr *MockSlackRepositoryMockRecorder) OpenView(ctx, triggerID, view interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenView", reflect.TypeOf((*MockSlackRepository)(nil).OpenView), ctx, triggerID, view)
+}
+
+// PostBlocks mocks base method.
+func (m *MockSlackRepository) PostBlocks(ctx context.Context, channelID, ts, text string, blocks []slack.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostBlocks", ctx, channelID, ts, text, blocks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostBlocks indicates an expected call of PostBlocks.
+func (mr *MockSlackRepositoryMockRecorder) PostBlocks(ctx, channelID, ts, text, blocks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostBlocks", reflect.TypeOf((*MockSlackRepository)(nil).PostBlocks), ctx, channelID, ts, text, blocks)
+}
+
 // PostEphemeral mocks base method.
 func (m *MockSlackRepository) PostEphemeral(ctx context.Context, channelID, message, ts, userID string) error {
 	m.ctrl.T.Helper()
@@ -92,3 +225,59 @@ func (mr *MockSlackRepositoryMockRecorder) PostMessage(ctx, channelID, message,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostMessage", reflect.TypeOf((*MockSlackRepository)(nil).PostMessage), ctx, channelID, message, ts)
 }
+
+// PostResponse mocks base method.
+func (m *MockSlackRepository) PostResponse(ctx context.Context, responseURL, message string, inChannel bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostResponse", ctx, responseURL, message, inChannel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostResponse indicates an expected call of PostResponse.
+func (mr *MockSlackRepositoryMockRecorder) PostResponse(ctx, responseURL, message, inChannel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostResponse", reflect.TypeOf((*MockSlackRepository)(nil).PostResponse), ctx, responseURL, message, inChannel)
+}
+
+// ReplaceResponse mocks base method.
+func (m *MockSlackRepository) ReplaceResponse(ctx context.Context, responseURL, text string, blocks []slack.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceResponse", ctx, responseURL, text, blocks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceResponse indicates an expected call of ReplaceResponse.
+func (mr *MockSlackRepositoryMockRecorder) ReplaceResponse(ctx, responseURL, text, blocks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceResponse", reflect.TypeOf((*MockSlackRepository)(nil).ReplaceResponse), ctx, responseURL, text, blocks)
+}
+
+// UpdateView mocks base method.
+func (m *MockSlackRepository) UpdateView(ctx context.Context, viewID, hash string, view slack.ModalViewRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateView", ctx, viewID, hash, view)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateView indicates an expected call of UpdateView.
+func (mr *MockSlackRepositoryMockRecorder) UpdateView(ctx, viewID, hash, view interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateView", reflect.TypeOf((*MockSlackRepository)(nil).UpdateView), ctx, viewID, hash, view)
+}
+
+// UploadFile mocks base method.
+func (m *MockSlackRepository) UploadFile(ctx context.Context, channelID, ts, filename, content, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFile", ctx, channelID, ts, filename, content, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadFile indicates an expected call of UploadFile.
+func (mr *MockSlackRepositoryMockRecorder) UploadFile(ctx, channelID, ts, filename, content, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockSlackRepository)(nil).UploadFile), ctx, channelID, ts, filename, content, comment)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: state.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStateRepository is a mock of StateRepository interface.
+type MockStateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStateRepositoryMockRecorder
+}
+
+// MockStateRepositoryMockRecorder is the mock recorder for MockStateRepository.
+type MockStateRepositoryMockRecorder struct {
+	mock *MockStateRepository
+}
+
+// NewMockStateRepository creates a new mock instance.
+func NewMockStateRepository(ctrl *gomock.Controller) *MockStateRepository {
+	mock := &MockStateRepository{ctrl: ctrl}
+	mock.recorder = &MockStateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStateRepository) EXPECT() *MockStateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteState mocks base method.
+func (m *MockStateRepository) DeleteState(ctx context.Context, kind, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteState", ctx, kind, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteState indicates an expected call of DeleteState.
+func (mr *MockStateRepositoryMockRecorder) DeleteState(ctx, kind, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteState", reflect.TypeOf((*MockStateRepository)(nil).DeleteState), ctx, kind, key)
+}
+
+// GetState mocks base method.
+func (m *MockStateRepository) GetState(ctx context.Context, kind, key string, value interface{}) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", ctx, kind, key, value)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockStateRepositoryMockRecorder) GetState(ctx, kind, key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockStateRepository)(nil).GetState), ctx, kind, key, value)
+}
+
+// LockState mocks base method.
+func (m *MockStateRepository) LockState(ctx context.Context, kind, key string, lease time.Duration) (func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockState", ctx, kind, key, lease)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockState indicates an expected call of LockState.
+func (mr *MockStateRepositoryMockRecorder) LockState(ctx, kind, key, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockState", reflect.TypeOf((*MockStateRepository)(nil).LockState), ctx, kind, key, lease)
+}
+
+// PutState mocks base method.
+func (m *MockStateRepository) PutState(ctx context.Context, kind, key string, value interface{}, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutState", ctx, kind, key, value, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutState indicates an expected call of PutState.
+func (mr *MockStateRepositoryMockRecorder) PutState(ctx, kind, key, value, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutState", reflect.TypeOf((*MockStateRepository)(nil).PutState), ctx, kind, key, value, expiresAt)
+}
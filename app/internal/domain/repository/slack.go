@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/slack-go/slack"
 )
 
 type SlackRepository interface {
@@ -30,9 +31,50 @@ type SlackRepository interface {
 	// PostEphemeral sends an ephemeral message to user in a channel
 	PostEphemeral(ctx context.Context, channelID, message, ts, userID string) error
 
+	// PostBlocks sends a Block Kit message to a channel. text is shown in the notifications.
+	PostBlocks(ctx context.Context, channelID, ts, text string, blocks []slack.Block) error
+
+	// PostResponse sends a message to the response_url of a slash command.
+	// The message is visible only to the user unless inChannel is true.
+	PostResponse(ctx context.Context, responseURL, message string, inChannel bool) error
+
+	// ReplaceResponse replaces the message the interaction was triggered on with a Block Kit message
+	ReplaceResponse(ctx context.Context, responseURL, text string, blocks []slack.Block) error
+
+	// OpenView opens a modal for the user who triggered the interaction
+	OpenView(ctx context.Context, triggerID string, view slack.ModalViewRequest) error
+
+	// UpdateView replaces the modal. hash is the one of the view the update is based on.
+	UpdateView(ctx context.Context, viewID, hash string, view slack.ModalViewRequest) error
+
+	// UploadFile shares a file in the thread of ts. channelID can be a user ID to send it in the direct message.
+	UploadFile(ctx context.Context, channelID, ts, filename, content, comment string) error
+
 	// GetParentMessage gets Slack message that started the thread
 	GetParentMessage(ctx context.Context, channelID, ts string) (*model.SlackMessage, error)
 
+	// ListThreadReplies gets the replies in the thread of ts by the users,
+	// excluding the parent message, the bots and the calls of Auriga
+	ListThreadReplies(ctx context.Context, channelID, ts string) ([]*model.SlackReply, error)
+
+	// ListUserGroupMembers gets the IDs of the users in the user group
+	ListUserGroupMembers(ctx context.Context, userGroupID string) ([]string, error)
+
+	// ListChannelMembers gets the IDs of the users in the channel, excluding Auriga
+	ListChannelMembers(ctx context.Context, channelID string) ([]string, error)
+
+	// GetPermalink gets the permalink URL of the message
+	GetPermalink(ctx context.Context, channelID, ts string) (string, error)
+
+	// GetUserTimeZone gets the IANA time zone name (e.g. Asia/Tokyo) of the user
+	GetUserTimeZone(ctx context.Context, userID string) (string, error)
+
+	// GetUserLocale gets the locale (e.g. ja-JP, en-US) of the user
+	GetUserLocale(ctx context.Context, userID string) (string, error)
+
 	// ListUsersEmail fetches users email
 	ListUsersEmail(ctx context.Context, userID []string) ([]*model.SlackUserEmail, error)
+
+	// InvalidateUser drops the user cached by ListUsersEmail, e.g. when the profile is changed
+	InvalidateUser(ctx context.Context, userID string) error
 }
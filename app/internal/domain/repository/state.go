@@ -0,0 +1,43 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+//go:generate mockgen -source=state.go -destination mock/state.go
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// StateKindEventBinding is the kind of the bindings of the messages and the calendar events
+const StateKindEventBinding = "event_binding"
+
+// StateRepository keeps the state of the bot across the requests.
+// The states are identified by the kind and the key, and stored in JSON.
+type StateRepository interface {
+	// GetState decodes the state into value, and reports false if it is not found or expired
+	GetState(ctx context.Context, kind, key string, value interface{}) (bool, error)
+
+	// PutState stores value as the state until expiresAt, or without expiry if expiresAt is zero
+	PutState(ctx context.Context, kind, key string, value interface{}, expiresAt time.Time) error
+
+	// DeleteState deletes the state, and does nothing if it is not found
+	DeleteState(ctx context.Context, kind, key string) error
+
+	// LockState waits until no one else holds the lock of the state, and returns the func to release it.
+	// The lock expires after lease in case the holder stops without releasing it.
+	LockState(ctx context.Context, kind, key string, lease time.Duration) (func(), error)
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/ics"
+)
+
+// calendarFileName is the name of the iCalendar file uploaded to Slack
+const calendarFileName = "invite.ics"
+
+type CalendarFileService interface {
+	// CreateCalendarFile writes the event on the schedule in an iCalendar file, organized by the user and inviting the users.
+	// The permalink of the thread is written in the event description.
+	CreateCalendarFile(ctx context.Context, channelID, ts, userID string, schedule *model.Schedule, emails []*model.SlackUserEmail) (*model.CalendarFile, error)
+}
+
+type calendarFileService struct {
+	slackRepository repository.SlackRepository
+	now             func() time.Time
+}
+
+func NewCalendarFileService(factory repository.Factory) *calendarFileService {
+	return &calendarFileService{
+		slackRepository: factory.SlackRepository(),
+		now:             time.Now,
+	}
+}
+
+func (s *calendarFileService) CreateCalendarFile(ctx context.Context, channelID, ts, userID string, schedule *model.Schedule, emails []*model.SlackUserEmail) (*model.CalendarFile, error) {
+	permalink, err := s.slackRepository.GetPermalink(ctx, channelID, ts)
+	if err != nil {
+		return nil, err
+	}
+	organizers, err := s.slackRepository.ListUsersEmail(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	event := &ics.Event{
+		// the same schedule from the same thread updates the event imported before
+		UID:         fmt.Sprintf("%s-%s-%d@auriga", ts, channelID, schedule.Start.Unix()),
+		Stamp:       s.now(),
+		Start:       schedule.Start,
+		End:         schedule.End,
+		Summary:     schedule.Title,
+		Description: permalink,
+		URL:         permalink,
+		Attendees:   calendarFileAttendees(emails),
+	}
+	for _, organizer := range organizers {
+		if organizer.Resolved() {
+			event.Organizer = &ics.Person{Email: organizer.Email, Name: organizer.Name()}
+		}
+	}
+	// REQUEST needs the organizer who the replies go to (RFC 5546), so the event is only published without it
+	method := "PUBLISH"
+	if event.Organizer != nil {
+		method = "REQUEST"
+	}
+	calendar := &ics.Calendar{Method: method, Events: []*ics.Event{event}}
+	return &model.CalendarFile{
+		Event: &model.CalendarEvent{
+			Title:          schedule.Title,
+			Description:    permalink,
+			Start:          schedule.Start,
+			End:            schedule.End,
+			AttendeeEmails: attendeeEmails(emails),
+		},
+		Name:    calendarFileName,
+		Content: calendar.String(),
+	}, nil
+}
+
+// calendarFileAttendees returns the unique attendees of the users who can be invited
+func calendarFileAttendees(emails []*model.SlackUserEmail) []*ics.Person {
+	attendees := make([]*ics.Person, 0, len(emails))
+	seen := map[string]bool{}
+	for _, email := range emails {
+		if !email.Resolved() || seen[email.Email] {
+			continue
+		}
+		seen[email.Email] = true
+		attendees = append(attendees, &ics.Person{Email: email.Email, Name: email.Name()})
+	}
+	return attendees
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+func Test_calendarFileService_CreateCalendarFile(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	schedule := &model.Schedule{
+		Start: time.Date(2026, 11, 5, 14, 0, 0, 0, jst),
+		End:   time.Date(2026, 11, 5, 15, 0, 0, 0, jst),
+		Title: "Sprint review",
+	}
+	emails := []*model.SlackUserEmail{
+		{ID: "user02", Email: "user02@example.com", Status: model.SlackUserStatusOK, DisplayName: "Yamada, Taro"},
+		{ID: "user03", Status: model.SlackUserStatusNoEmail},
+		{ID: "user04", Email: "user04@example.com", Status: model.SlackUserStatusOK},
+	}
+	permalink := "https://example.slack.com/archives/sampleCID/p1"
+	tests := []struct {
+		name        string
+		prepare     func(msr *mock_repository.MockSlackRepository)
+		want        *model.CalendarFile
+		wantContent []string
+		// wantNoContent is not in the content
+		wantNoContent []string
+		wantErr       error
+	}{
+		{
+			name: "OK",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetPermalink(gomock.Any(), "sampleCID", "sampleTs").Return(permalink, nil),
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user01"}).Return([]*model.SlackUserEmail{
+						{ID: "user01", Email: "user01@example.com", Status: model.SlackUserStatusOK, RealName: "Hanako Suzuki"},
+					}, nil),
+				)
+			},
+			want: &model.CalendarFile{
+				Event: &model.CalendarEvent{
+					Title:          "Sprint review",
+					Description:    permalink,
+					Start:          schedule.Start,
+					End:            schedule.End,
+					AttendeeEmails: []string{"user02@example.com", "user04@example.com"},
+				},
+				Name: "invite.ics",
+			},
+			wantContent: []string{
+				"METHOD:REQUEST\r\n",
+				"UID:sampleTs-sampleCID-1793854800@auriga\r\n",
+				"DTSTAMP:20261101T030000Z\r\n",
+				"DTSTART:20261105T050000Z\r\n",
+				"SUMMARY:Sprint review\r\n",
+				"ORGANIZER;CN=Hanako Suzuki:mailto:user01@example.com\r\n",
+				"ATTENDEE;CN=\"Yamada, Taro\";",
+				"ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:user04",
+			},
+		},
+		{
+			name: "OK: the organizer without email is omitted",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetPermalink(gomock.Any(), "sampleCID", "sampleTs").Return(permalink, nil),
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user01"}).Return([]*model.SlackUserEmail{
+						{ID: "user01", Status: model.SlackUserStatusGuest},
+					}, nil),
+				)
+			},
+			want: &model.CalendarFile{
+				Event: &model.CalendarEvent{
+					Title:          "Sprint review",
+					Description:    permalink,
+					Start:          schedule.Start,
+					End:            schedule.End,
+					AttendeeEmails: []string{"user02@example.com", "user04@example.com"},
+				},
+				Name: "invite.ics",
+			},
+			wantContent: []string{
+				"METHOD:PUBLISH\r\n",
+				"SUMMARY:Sprint review\r\n",
+				"URL:" + permalink + "\r\nATTENDEE;",
+			},
+			wantNoContent: []string{"METHOD:REQUEST", "ORGANIZER"},
+		},
+		{
+			name: "NG: error in SlackRepository.GetPermalink",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetPermalink(gomock.Any(), "sampleCID", "sampleTs").Return("", errSample)
+			},
+			wantErr: errSample,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(msr)
+			}
+			s := &calendarFileService{
+				slackRepository: msr,
+				now:             func() time.Time { return time.Date(2026, 11, 1, 12, 0, 0, 0, jst) },
+			}
+			got, err := s.CreateCalendarFile(context.Background(), "sampleCID", "sampleTs", "user01", schedule, emails)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateCalendarFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got == nil {
+				if tt.want != nil {
+					t.Errorf("CreateCalendarFile() got = nil, want %v", tt.want)
+				}
+				return
+			}
+			for _, want := range tt.wantContent {
+				if !strings.Contains(got.Content, want) {
+					t.Errorf("CreateCalendarFile() content = %q, want to contain %q", got.Content, want)
+				}
+			}
+			for _, want := range tt.wantNoContent {
+				if strings.Contains(got.Content, want) {
+					t.Errorf("CreateCalendarFile() content = %q, want not to contain %q", got.Content, want)
+				}
+			}
+			got.Content = ""
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateCalendarFile() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+const (
+	// emailListFileThreshold is the number of users above which the email list is uploaded as a CSV file
+	// instead of flooding the thread with the messages of lineSizeOfPostEmailList lines.
+	emailListFileThreshold = 2 * lineSizeOfPostEmailList
+)
+
+var emailListFileHeader = []string{"user_id", "display_name", "real_name", "email", "reactions", "reaction_order", "status"}
+
+// emailListFileFormat returns the format of the file to upload the email list as, or "" to post messages
+func emailListFileFormat(emails []*model.SlackUserEmail, format string) string {
+	if format == model.EmailListFormatCSV || format == model.EmailListFormatTSV {
+		return format
+	}
+	if len(emails) > emailListFileThreshold {
+		return model.EmailListFormatCSV
+	}
+	return ""
+}
+
+// emailListFile returns the name and the content of the file in format (model.EmailListFormatCSV or model.EmailListFormatTSV)
+func emailListFile(emails []*model.SlackUserEmail, format string) (string, string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if format == model.EmailListFormatTSV {
+		w.Comma = '\t'
+	}
+	if err := w.Write(emailListFileHeader); err != nil {
+		return "", "", err
+	}
+	for _, email := range emails {
+		var order string
+		if email.ReactionOrder > 0 {
+			order = strconv.Itoa(email.ReactionOrder)
+		}
+		record := []string{email.ID, email.DisplayName, email.RealName, email.Email, strings.Join(email.Reactions, " "), order, string(email.Status)}
+		for i := range record {
+			record[i] = escapeFormula(record[i])
+		}
+		if err := w.Write(record); err != nil {
+			return "", "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", "", err
+	}
+	return "attendees." + format, b.String(), nil
+}
+
+// escapeFormula prefixes the cell which spreadsheets read as a formula (e.g. a display name "=HYPERLINK(...)")
+// with a quote, so that opening the file does not run it.
+// A leading tab or carriage return is escaped too, since some spreadsheets skip it and read the formula after it.
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
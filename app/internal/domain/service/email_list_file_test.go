@@ -0,0 +1,132 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+func Test_emailListFile(t *testing.T) {
+	emails := []*model.SlackUserEmail{
+		{ID: "U01", DisplayName: "taro", RealName: "Yamada, Taro", Email: "taro@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"sanka", "onsite"}, ReactionOrder: 1},
+		{ID: "U02", DisplayName: "hanako", RealName: "Hanako \"Hana\" Sato", Email: "hanako@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"sanka"}, ReactionOrder: 2},
+		{ID: "U03", DisplayName: "guest", Status: model.SlackUserStatusGuest, Reactions: []string{"sanka"}, ReactionOrder: 3},
+		{ID: "U04", DisplayName: "=HYPERLINK(\"https://example.com\")", RealName: "@jiro", Email: "jiro@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"+1"}, ReactionOrder: 4},
+	}
+	tests := []struct {
+		name         string
+		format       string
+		wantFilename string
+		wantContent  string
+	}{
+		{
+			name:         "OK: csv",
+			format:       model.EmailListFormatCSV,
+			wantFilename: "attendees.csv",
+			wantContent: "user_id,display_name,real_name,email,reactions,reaction_order,status\n" +
+				"U01,taro,\"Yamada, Taro\",taro@example.com,sanka onsite,1,ok\n" +
+				"U02,hanako,\"Hanako \"\"Hana\"\" Sato\",hanako@example.com,sanka,2,ok\n" +
+				"U03,guest,,,sanka,3,guest\n" +
+				"U04,\"'=HYPERLINK(\"\"https://example.com\"\")\",'@jiro,jiro@example.com,'+1,4,ok\n",
+		},
+		{
+			name:         "OK: tsv",
+			format:       model.EmailListFormatTSV,
+			wantFilename: "attendees.tsv",
+			wantContent: "user_id\tdisplay_name\treal_name\temail\treactions\treaction_order\tstatus\n" +
+				"U01\ttaro\tYamada, Taro\ttaro@example.com\tsanka onsite\t1\tok\n" +
+				"U02\thanako\t\"Hanako \"\"Hana\"\" Sato\"\thanako@example.com\tsanka\t2\tok\n" +
+				"U03\tguest\t\t\tsanka\t3\tguest\n" +
+				"U04\t\"'=HYPERLINK(\"\"https://example.com\"\")\"\t'@jiro\tjiro@example.com\t'+1\t4\tok\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename, content, err := emailListFile(emails, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if filename != tt.wantFilename {
+				t.Errorf("emailListFile() filename = %v, want %v", filename, tt.wantFilename)
+			}
+			if content != tt.wantContent {
+				t.Errorf("emailListFile() content = %q, want %q", content, tt.wantContent)
+			}
+		})
+	}
+}
+
+func Test_escapeFormula(t *testing.T) {
+	tests := []struct {
+		name string
+		cell string
+		want string
+	}{
+		{name: "OK: equals sign", cell: "=1+2", want: "'=1+2"},
+		{name: "OK: plus sign", cell: "+1", want: "'+1"},
+		{name: "OK: minus sign", cell: "-1", want: "'-1"},
+		{name: "OK: at sign", cell: "@jiro", want: "'@jiro"},
+		{name: "OK: tab", cell: "\t=1+2", want: "'\t=1+2"},
+		{name: "OK: carriage return", cell: "\r=1+2", want: "'\r=1+2"},
+		{name: "OK: the sign in the middle", cell: "taro=1", want: "taro=1"},
+		{name: "OK: empty", cell: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeFormula(tt.cell); got != tt.want {
+				t.Errorf("escapeFormula() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_emailListFileFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		emails []*model.SlackUserEmail
+		format string
+		want   string
+	}{
+		{
+			name:   "OK: specified",
+			emails: createEmails(0, 1),
+			format: model.EmailListFormatTSV,
+			want:   model.EmailListFormatTSV,
+		},
+		{
+			name:   "OK: messages for a short list",
+			emails: createEmails(0, emailListFileThreshold),
+			format: model.EmailListFormatComma,
+			want:   "",
+		},
+		{
+			name:   "OK: csv for a long list",
+			emails: createEmails(0, emailListFileThreshold+1),
+			format: model.EmailListFormatComma,
+			want:   model.EmailListFormatCSV,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := emailListFileFormat(tt.emails, tt.format); got != tt.want {
+				t.Errorf("emailListFileFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
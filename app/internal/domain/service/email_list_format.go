@@ -0,0 +1,154 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/internal/renderer"
+	"github.com/moneyforward/auriga/app/pkg/slice"
+)
+
+// sortEmailList returns the users sorted as options.Sort without changing emails, and options defaulted if nil
+func sortEmailList(emails []*model.SlackUserEmail, options *model.EmailListOptions) ([]*model.SlackUserEmail, *model.EmailListOptions) {
+	if options == nil {
+		options = &model.EmailListOptions{}
+	}
+	var less func(a, b *model.SlackUserEmail) bool
+	switch options.Sort {
+	case model.EmailListSortName:
+		less = func(a, b *model.SlackUserEmail) bool {
+			return lessNonEmpty(strings.ToLower(a.Name()), strings.ToLower(b.Name()))
+		}
+	case model.EmailListSortEmail:
+		less = func(a, b *model.SlackUserEmail) bool {
+			return lessNonEmpty(strings.ToLower(a.Email), strings.ToLower(b.Email))
+		}
+	case model.EmailListSortReactionTime:
+		less = func(a, b *model.SlackUserEmail) bool {
+			// the users without the order come last
+			return a.ReactionOrder > 0 && (b.ReactionOrder == 0 || a.ReactionOrder < b.ReactionOrder)
+		}
+	default:
+		return emails, options
+	}
+	sorted := make([]*model.SlackUserEmail, len(emails))
+	copy(sorted, emails)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+	return sorted, options
+}
+
+// lessNonEmpty compares the strings, putting the empty ones last
+func lessNonEmpty(a, b string) bool {
+	if a == "" || b == "" {
+		return a != "" && b == ""
+	}
+	return a < b
+}
+
+// emailListSeparators are the separators of the formats joining the entries in a line
+var emailListSeparators = map[string]string{
+	model.EmailListFormatComma:     ", ",
+	model.EmailListFormatSemicolon: "; ",
+}
+
+// emailListMessages formats the email list into messages of lineSizeOfPostEmailList entries,
+// followed by the message mentioning the users whose emails are not listed
+func emailListMessages(ctx context.Context, emails []*model.SlackUserEmail, options *model.EmailListOptions) []string {
+	locale := i18n.FromContext(ctx)
+	title := locale.T(i18n.EmailListTitle)
+	if options.Format == model.EmailListFormatMention {
+		// mentions reach the users whose emails are not known as well
+		mentions := make([]string, 0, len(emails))
+		for _, email := range emails {
+			mentions = append(mentions, "<@"+email.ID+">")
+		}
+		return joinedEmailListMessages(title, mentions, " ")
+	}
+
+	resolved := make([]*model.SlackUserEmail, 0, len(emails))
+	for _, email := range emails {
+		if email.Resolved() {
+			resolved = append(resolved, email)
+		}
+	}
+	var msgs []string
+	if sep, ok := emailListSeparators[options.Format]; ok {
+		msgs = joinedEmailListMessages(title, emailListAddresses(resolved, options.WithNames), sep)
+	} else if options.Format == model.EmailListFormatMarkdownTable {
+		msgs = markdownTableMessages(locale, title, resolved)
+	} else {
+		lines := append([]string{title}, emailListAddresses(resolved, options.WithNames)...)
+		for _, chunk := range slice.SplitStringSliceInChunks(lines, lineSizeOfPostEmailList) {
+			msgs = append(msgs, strings.Join(chunk, "\n"))
+		}
+	}
+	if unresolved := renderer.UnresolvedUsersTexts(locale, emails); len(unresolved) > 0 {
+		msgs = append(msgs, strings.Join(unresolved, "\n"))
+	}
+	return msgs
+}
+
+func emailListAddresses(emails []*model.SlackUserEmail, withNames bool) []string {
+	addresses := make([]string, 0, len(emails))
+	for _, email := range emails {
+		addresses = append(addresses, renderer.EmailAddress(email, withNames))
+	}
+	return addresses
+}
+
+// joinedEmailListMessages joins the entries with sep in the messages of lineSizeOfPostEmailList entries, following the title
+func joinedEmailListMessages(title string, entries []string, sep string) []string {
+	var msgs []string
+	for _, chunk := range slice.SplitStringSliceInChunks(entries, lineSizeOfPostEmailList) {
+		msgs = append(msgs, strings.Join(chunk, sep))
+	}
+	msgs[0] = strings.TrimSpace(title + "\n" + msgs[0])
+	return msgs
+}
+
+// markdownTableMessages lists the users in the Markdown tables of lineSizeOfPostEmailList rows.
+// Slack does not render tables, so they are in code blocks to be copied as they are.
+func markdownTableMessages(locale i18n.Locale, title string, emails []*model.SlackUserEmail) []string {
+	header := "| " + locale.T(i18n.EmailListTableName) + " | " + locale.T(i18n.EmailListTableEmail) + " | " + locale.T(i18n.EmailListTableReactions) + " |\n" +
+		"| --- | --- | --- |"
+	rows := make([]string, 0, len(emails))
+	for _, email := range emails {
+		reactions := make([]string, 0, len(email.Reactions))
+		for _, reaction := range email.Reactions {
+			reactions = append(reactions, ":"+reaction+":")
+		}
+		rows = append(rows, "| "+markdownTableCell(email.Name())+" | "+markdownTableCell(email.Email)+" | "+strings.Join(reactions, " ")+" |")
+	}
+	var msgs []string
+	for _, chunk := range slice.SplitStringSliceInChunks(rows, lineSizeOfPostEmailList) {
+		msgs = append(msgs, "```\n"+strings.TrimSpace(header+"\n"+strings.Join(chunk, "\n"))+"\n```")
+	}
+	msgs[0] = title + "\n" + msgs[0]
+	return msgs
+}
+
+// markdownTableCell escapes the pipes, which would split the cell
+func markdownTableCell(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
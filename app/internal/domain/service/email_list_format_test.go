@@ -0,0 +1,129 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+func Test_sortEmailList(t *testing.T) {
+	emails := []*model.SlackUserEmail{
+		{ID: "U01", DisplayName: "taro", Email: "taro@example.com", ReactionOrder: 3},
+		{ID: "U02", RealName: "Hanako", Email: "hanako@example.com", ReactionOrder: 1},
+		{ID: "U03", Status: model.SlackUserStatusNoEmail},
+		{ID: "U04", DisplayName: "Aki", Email: "Aki@example.com", ReactionOrder: 2},
+	}
+	tests := []struct {
+		name    string
+		options *model.EmailListOptions
+		want    []string
+	}{
+		{
+			name: "OK: nil options",
+			want: []string{"U01", "U02", "U03", "U04"},
+		},
+		{
+			name:    "OK: name",
+			options: &model.EmailListOptions{Sort: model.EmailListSortName},
+			want:    []string{"U04", "U02", "U01", "U03"},
+		},
+		{
+			name:    "OK: email",
+			options: &model.EmailListOptions{Sort: model.EmailListSortEmail},
+			want:    []string{"U04", "U02", "U01", "U03"},
+		},
+		{
+			name:    "OK: reaction-time",
+			options: &model.EmailListOptions{Sort: model.EmailListSortReactionTime},
+			want:    []string{"U02", "U04", "U01", "U03"},
+		},
+		{
+			name:    "OK: unknown",
+			options: &model.EmailListOptions{Sort: "sample"},
+			want:    []string{"U01", "U02", "U03", "U04"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := sortEmailList(emails, tt.options)
+			var gotIDs []string
+			for _, email := range got {
+				gotIDs = append(gotIDs, email.ID)
+			}
+			if !reflect.DeepEqual(gotIDs, tt.want) {
+				t.Errorf("sortEmailList() = %v, want %v", gotIDs, tt.want)
+			}
+			if emails[0].ID != "U01" || emails[3].ID != "U04" {
+				t.Errorf("sortEmailList() changed emails")
+			}
+		})
+	}
+}
+
+func Test_emailListMessages(t *testing.T) {
+	emails := []*model.SlackUserEmail{
+		{ID: "U01", DisplayName: "taro", Email: "taro@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"sanka", "onsite"}},
+		{ID: "U02", RealName: "Hanako | HR", Email: "hanako@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"sanka"}},
+		{ID: "U03", Status: model.SlackUserStatusGuest},
+	}
+	tests := []struct {
+		name    string
+		options *model.EmailListOptions
+		want    []string
+	}{
+		{
+			name:    "OK: lines",
+			options: &model.EmailListOptions{Format: model.EmailListFormatLines},
+			want:    []string{"参加者一覧\ntaro@example.com\nhanako@example.com", "メールアドレスが非公開のゲスト: <@U03>"},
+		},
+		{
+			name:    "OK: lines with names",
+			options: &model.EmailListOptions{Format: model.EmailListFormatLines, WithNames: true},
+			want:    []string{"参加者一覧\ntaro <taro@example.com>\nHanako | HR <hanako@example.com>", "メールアドレスが非公開のゲスト: <@U03>"},
+		},
+		{
+			name:    "OK: semicolon",
+			options: &model.EmailListOptions{Format: model.EmailListFormatSemicolon},
+			want:    []string{"参加者一覧\ntaro@example.com; hanako@example.com", "メールアドレスが非公開のゲスト: <@U03>"},
+		},
+		{
+			name:    "OK: mention",
+			options: &model.EmailListOptions{Format: model.EmailListFormatMention},
+			want:    []string{"参加者一覧\n<@U01> <@U02> <@U03>"},
+		},
+		{
+			name:    "OK: markdown-table",
+			options: &model.EmailListOptions{Format: model.EmailListFormatMarkdownTable},
+			want: []string{
+				"参加者一覧\n```\n| 名前 | メールアドレス | リアクション |\n| --- | --- | --- |\n" +
+					"| taro | taro@example.com | :sanka: :onsite: |\n| Hanako \\| HR | hanako@example.com | :sanka: |\n```",
+				"メールアドレスが非公開のゲスト: <@U03>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := emailListMessages(context.Background(), emails, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("emailListMessages() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
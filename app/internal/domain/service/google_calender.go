@@ -16,5 +16,187 @@
 
 package service
 
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/google/calendar"
+)
+
 type GoogleCalenderService interface {
+	// CreateEvent creates an event on the schedule and invites the users.
+	// The permalink of the thread is written in the event description.
+	CreateEvent(ctx context.Context, channelID, ts string, schedule *model.Schedule, emails []*model.SlackUserEmail) (*model.CalendarEvent, error)
+	// CreateTemplate returns the links to create the event like CreateEvent by the user,
+	// which needs no credentials of Google Calendar
+	CreateTemplate(ctx context.Context, channelID, ts string, schedule *model.Schedule, emails []*model.SlackUserEmail) (*model.CalendarTemplate, error)
+	// BindEvent remembers the event created from the message and how its attendees were selected,
+	// so that SyncAttendees updates them when the reactions change
+	BindEvent(ctx context.Context, channelID, ts string, event *model.CalendarEvent, filter *model.ReactionFilter, userFilter *model.UserFilter) error
+	// FindEventBinding returns the binding of the message if the reaction may change its attendees,
+	// or nil if no event is created from the message, the event has ended or the reaction is not concerned
+	FindEventBinding(ctx context.Context, channelID, ts, reaction string) (*model.EventBinding, error)
+	// LockEventBinding waits until the attendees of the event created from the message are not synced by the others,
+	// and returns the func to let them sync, so that the events of the message sync them one at a time
+	LockEventBinding(ctx context.Context, channelID, ts string) (func(), error)
+	// SyncAttendees invites the users selected now and cancels the invitations of the ones no longer selected
+	// among the attendees invited by Auriga. The attendees added on the calendar by hand are kept.
+	SyncAttendees(ctx context.Context, binding *model.EventBinding, emails []*model.SlackUserEmail) (*model.AttendeeChange, error)
+}
+
+type googleCalenderService struct {
+	slackRepository        repository.SlackRepository
+	calendarRepository     repository.CalendarRepository
+	eventBindingRepository repository.EventBindingRepository
+	now                    func() time.Time
+}
+
+func NewGoogleCalenderService(factory repository.Factory) *googleCalenderService {
+	return &googleCalenderService{
+		slackRepository:        factory.SlackRepository(),
+		calendarRepository:     factory.CalendarRepository(),
+		eventBindingRepository: factory.EventBindingRepository(),
+		now:                    time.Now,
+	}
+}
+
+func (s *googleCalenderService) CreateEvent(ctx context.Context, channelID, ts string, schedule *model.Schedule, emails []*model.SlackUserEmail) (*model.CalendarEvent, error) {
+	permalink, err := s.slackRepository.GetPermalink(ctx, channelID, ts)
+	if err != nil {
+		return nil, err
+	}
+	return s.calendarRepository.CreateEvent(ctx, &model.CalendarEvent{
+		Title:          schedule.Title,
+		Description:    permalink,
+		Start:          schedule.Start,
+		End:            schedule.End,
+		AttendeeEmails: attendeeEmails(emails),
+	})
+}
+
+func (s *googleCalenderService) CreateTemplate(ctx context.Context, channelID, ts string, schedule *model.Schedule, emails []*model.SlackUserEmail) (*model.CalendarTemplate, error) {
+	permalink, err := s.slackRepository.GetPermalink(ctx, channelID, ts)
+	if err != nil {
+		return nil, err
+	}
+	event := &model.CalendarEvent{
+		Title:          schedule.Title,
+		Description:    permalink,
+		Start:          schedule.Start,
+		End:            schedule.End,
+		AttendeeEmails: attendeeEmails(emails),
+	}
+	urls := calendar.TemplateURLs(&calendar.Template{
+		Title:   event.Title,
+		Details: event.Description,
+		Start:   event.Start,
+		End:     event.End,
+		Guests:  event.AttendeeEmails,
+	}, calendar.DefaultMaxTemplateURLLength)
+	return &model.CalendarTemplate{Event: event, URLs: urls}, nil
+}
+
+func (s *googleCalenderService) BindEvent(ctx context.Context, channelID, ts string, event *model.CalendarEvent, filter *model.ReactionFilter, userFilter *model.UserFilter) error {
+	return s.eventBindingRepository.SaveEventBinding(ctx, &model.EventBinding{
+		ChannelID:      channelID,
+		TimeStamp:      ts,
+		EventID:        event.ID,
+		Title:          event.Title,
+		URL:            event.URL,
+		End:            event.End,
+		Reactions:      filter,
+		UserFilter:     userFilter,
+		AttendeeEmails: event.AttendeeEmails,
+	})
+}
+
+func (s *googleCalenderService) FindEventBinding(ctx context.Context, channelID, ts, reaction string) (*model.EventBinding, error) {
+	binding, err := s.eventBindingRepository.GetEventBinding(ctx, channelID, ts)
+	if err != nil || binding == nil {
+		return nil, err
+	}
+	if !binding.End.After(s.now()) || !concernsReaction(binding.Reactions, reaction) {
+		return nil, nil
+	}
+	return binding, nil
+}
+
+func (s *googleCalenderService) LockEventBinding(ctx context.Context, channelID, ts string) (func(), error) {
+	return s.eventBindingRepository.LockEventBinding(ctx, channelID, ts)
+}
+
+// concernsReaction returns true if the users who reacted with the reaction may be selected or excluded by filter
+func concernsReaction(filter *model.ReactionFilter, reaction string) bool {
+	if filter.IsEmpty() || filter.NoReactions || (len(filter.Any)+len(filter.All) == 0 && filter.SelectsReactedUsers()) {
+		return true
+	}
+	return containsReaction(filter.Any, reaction) || containsReaction(filter.All, reaction) || containsReaction(filter.Exclude, reaction)
+}
+
+func (s *googleCalenderService) SyncAttendees(ctx context.Context, binding *model.EventBinding, emails []*model.SlackUserEmail) (*model.AttendeeChange, error) {
+	selected := attendeeEmails(emails)
+	isSelected := make(map[string]bool, len(selected))
+	for _, email := range selected {
+		isSelected[strings.ToLower(email)] = true
+	}
+	wasInvited := make(map[string]bool, len(binding.AttendeeEmails))
+	var removed []string
+	for _, email := range binding.AttendeeEmails {
+		wasInvited[strings.ToLower(email)] = true
+		if !isSelected[strings.ToLower(email)] {
+			removed = append(removed, email)
+		}
+	}
+	var added []string
+	for _, email := range selected {
+		if !wasInvited[strings.ToLower(email)] {
+			added = append(added, email)
+		}
+	}
+	if len(added)+len(removed) == 0 {
+		return &model.AttendeeChange{}, nil
+	}
+	change, err := s.calendarRepository.UpdateAttendees(ctx, binding.EventID, added, removed)
+	if err != nil {
+		return nil, err
+	}
+	binding.AttendeeEmails = invitedEmails(binding.AttendeeEmails, removed, change.Added)
+	if err := s.eventBindingRepository.SaveEventBinding(ctx, binding); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// invitedEmails returns the attendees invited by Auriga after the sync.
+// Only the ones the sync actually added are recorded, so that the attendees added on the calendar by hand
+// are never removed even if they react and then cancel the reaction.
+func invitedEmails(invited, removed, added []string) []string {
+	isRemoved := make(map[string]bool, len(removed))
+	for _, email := range removed {
+		isRemoved[strings.ToLower(email)] = true
+	}
+	emails := make([]string, 0, len(invited)+len(added))
+	for _, email := range invited {
+		if !isRemoved[strings.ToLower(email)] {
+			emails = append(emails, email)
+		}
+	}
+	return append(emails, added...)
+}
+
+// attendeeEmails returns the unique email addresses of the users who can be invited
+func attendeeEmails(emails []*model.SlackUserEmail) []string {
+	addresses := make([]string, 0, len(emails))
+	seen := map[string]bool{}
+	for _, email := range emails {
+		if !email.Resolved() || seen[email.Email] {
+			continue
+		}
+		seen[email.Email] = true
+		addresses = append(addresses, email.Email)
+	}
+	return addresses
 }
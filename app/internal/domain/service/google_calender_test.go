@@ -0,0 +1,350 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+func Test_googleCalenderService_CreateEvent(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	schedule := &model.Schedule{
+		Start: time.Date(2026, 11, 2, 15, 0, 0, 0, jst),
+		End:   time.Date(2026, 11, 2, 16, 0, 0, 0, jst),
+		Title: "Sprint review",
+	}
+	type args struct {
+		emails []*model.SlackUserEmail
+	}
+	tests := []struct {
+		name    string
+		args    args
+		prepare func(msr *mock_repository.MockSlackRepository, mcr *mock_repository.MockCalendarRepository)
+		want    *model.CalendarEvent
+		wantErr error
+	}{
+		{
+			name: "OK",
+			args: args{
+				emails: []*model.SlackUserEmail{
+					{ID: "user01", Email: "user01@example.com", Status: model.SlackUserStatusOK},
+					{ID: "user02", Email: ""},
+					{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK},
+				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository, mcr *mock_repository.MockCalendarRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetPermalink(gomock.Any(), "sampleCID", "sampleTs").
+						Return("https://example.slack.com/archives/sampleCID/p1", nil),
+					mcr.EXPECT().CreateEvent(gomock.Any(), &model.CalendarEvent{
+						Title:          "Sprint review",
+						Description:    "https://example.slack.com/archives/sampleCID/p1",
+						Start:          time.Date(2026, 11, 2, 15, 0, 0, 0, jst),
+						End:            time.Date(2026, 11, 2, 16, 0, 0, 0, jst),
+						AttendeeEmails: []string{"user01@example.com", "user03@example.com"},
+					}).Return(&model.CalendarEvent{ID: "event01"}, nil),
+				)
+			},
+			want: &model.CalendarEvent{ID: "event01"},
+		},
+		{
+			name: "NG: error in CalendarRepository.CreateEvent",
+			prepare: func(msr *mock_repository.MockSlackRepository, mcr *mock_repository.MockCalendarRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetPermalink(gomock.Any(), "sampleCID", "sampleTs").Return("", nil),
+					mcr.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Return(nil, errSample),
+				)
+			},
+			wantErr: errSample,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			mcr := mock_repository.NewMockCalendarRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(msr, mcr)
+			}
+			s := &googleCalenderService{
+				slackRepository:    msr,
+				calendarRepository: mcr,
+			}
+			got, err := s.CreateEvent(context.Background(), "sampleCID", "sampleTs", schedule, tt.args.emails)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateEvent() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var errSample = errors.New("sample_error")
+
+func Test_googleCalenderService_CreateTemplate(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	schedule := &model.Schedule{
+		Start: time.Date(2026, 11, 2, 15, 0, 0, 0, jst),
+		End:   time.Date(2026, 11, 2, 16, 0, 0, 0, jst),
+		Title: "Sprint review",
+	}
+	emails := []*model.SlackUserEmail{
+		{ID: "user01", Email: "user01@example.com", Status: model.SlackUserStatusOK},
+		{ID: "user02", Email: ""},
+		{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK},
+	}
+	tests := []struct {
+		name    string
+		prepare func(msr *mock_repository.MockSlackRepository)
+		want    *model.CalendarTemplate
+		wantErr error
+	}{
+		{
+			name: "OK",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetPermalink(gomock.Any(), "sampleCID", "sampleTs").
+					Return("https://example.slack.com/archives/sampleCID/p1", nil)
+			},
+			want: &model.CalendarTemplate{
+				Event: &model.CalendarEvent{
+					Title:          "Sprint review",
+					Description:    "https://example.slack.com/archives/sampleCID/p1",
+					Start:          time.Date(2026, 11, 2, 15, 0, 0, 0, jst),
+					End:            time.Date(2026, 11, 2, 16, 0, 0, 0, jst),
+					AttendeeEmails: []string{"user01@example.com", "user03@example.com"},
+				},
+				URLs: []string{
+					"https://calendar.google.com/calendar/render?action=TEMPLATE&text=Sprint+review&dates=20261102T060000Z/20261102T070000Z" +
+						"&details=https%3A%2F%2Fexample.slack.com%2Farchives%2FsampleCID%2Fp1&add=user01%40example.com&add=user03%40example.com",
+				},
+			},
+		},
+		{
+			name: "NG: error in SlackRepository.GetPermalink",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetPermalink(gomock.Any(), "sampleCID", "sampleTs").Return("", errSample)
+			},
+			wantErr: errSample,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(msr)
+			}
+			s := &googleCalenderService{
+				slackRepository: msr,
+			}
+			got, err := s.CreateTemplate(context.Background(), "sampleCID", "sampleTs", schedule, emails)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateTemplate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateTemplate() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_googleCalenderService_FindEventBinding(t *testing.T) {
+	now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	binding := &model.EventBinding{
+		ChannelID: "sampleCID",
+		TimeStamp: "sampleTs",
+		EventID:   "event01",
+		End:       now.Add(time.Hour),
+		Reactions: &model.ReactionFilter{Any: []string{"sanka"}, Exclude: []string{"absent"}},
+	}
+	tests := []struct {
+		name     string
+		reaction string
+		binding  *model.EventBinding
+		err      error
+		want     *model.EventBinding
+		wantErr  bool
+	}{
+		{
+			name:     "OK",
+			reaction: "sanka::skin-tone-2",
+			binding:  binding,
+			want:     binding,
+		},
+		{
+			name:     "OK: reaction excluding the attendees",
+			reaction: "absent",
+			binding:  binding,
+			want:     binding,
+		},
+		{
+			name:     "OK: reaction not concerned",
+			reaction: "eyes",
+			binding:  binding,
+		},
+		{
+			name:     "OK: event has ended",
+			reaction: "sanka",
+			binding:  &model.EventBinding{EventID: "event01", End: now, Reactions: binding.Reactions},
+		},
+		{
+			name:     "OK: no event created from the message",
+			reaction: "sanka",
+		},
+		{
+			name:     "NG: error in eventBindingRepository.GetEventBinding",
+			reaction: "sanka",
+			err:      errors.New("sample error"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mbr := mock_repository.NewMockEventBindingRepository(ctrl)
+			mbr.EXPECT().GetEventBinding(gomock.Any(), "sampleCID", "sampleTs").Return(tt.binding, tt.err)
+			s := &googleCalenderService{
+				eventBindingRepository: mbr,
+				now:                    func() time.Time { return now },
+			}
+			got, err := s.FindEventBinding(context.Background(), "sampleCID", "sampleTs", tt.reaction)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindEventBinding() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindEventBinding() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_googleCalenderService_SyncAttendees(t *testing.T) {
+	emails := []*model.SlackUserEmail{
+		{ID: "user01", Email: "user01@example.com", Status: model.SlackUserStatusOK},
+		{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK},
+		{ID: "user04", Status: model.SlackUserStatusNoEmail},
+	}
+	tests := []struct {
+		name    string
+		invited []string
+		prepare func(mcr *mock_repository.MockCalendarRepository, mbr *mock_repository.MockEventBindingRepository)
+		want    *model.AttendeeChange
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			invited: []string{"user01@example.com", "user02@example.com"},
+			prepare: func(mcr *mock_repository.MockCalendarRepository, mbr *mock_repository.MockEventBindingRepository) {
+				gomock.InOrder(
+					mcr.EXPECT().UpdateAttendees(gomock.Any(), "event01", []string{"user03@example.com"}, []string{"user02@example.com"}).
+						Return(&model.AttendeeChange{Added: []string{"user03@example.com"}, Removed: []string{"user02@example.com"}}, nil),
+					mbr.EXPECT().SaveEventBinding(gomock.Any(), &model.EventBinding{
+						EventID:        "event01",
+						AttendeeEmails: []string{"user01@example.com", "user03@example.com"},
+					}).Return(nil),
+				)
+			},
+			want: &model.AttendeeChange{Added: []string{"user03@example.com"}, Removed: []string{"user02@example.com"}},
+		},
+		{
+			name:    "OK: the attendee added by hand is not recorded",
+			invited: []string{"user01@example.com"},
+			prepare: func(mcr *mock_repository.MockCalendarRepository, mbr *mock_repository.MockEventBindingRepository) {
+				gomock.InOrder(
+					// user03 is already on the event, added by hand
+					mcr.EXPECT().UpdateAttendees(gomock.Any(), "event01", []string{"user03@example.com"}, nil).
+						Return(&model.AttendeeChange{}, nil),
+					mbr.EXPECT().SaveEventBinding(gomock.Any(), &model.EventBinding{
+						EventID:        "event01",
+						AttendeeEmails: []string{"user01@example.com"},
+					}).Return(nil),
+				)
+			},
+			want: &model.AttendeeChange{},
+		},
+		{
+			name:    "OK: the attendee removed by hand is forgotten",
+			invited: []string{"user01@example.com", "User02@example.com", "user03@example.com"},
+			prepare: func(mcr *mock_repository.MockCalendarRepository, mbr *mock_repository.MockEventBindingRepository) {
+				gomock.InOrder(
+					mcr.EXPECT().UpdateAttendees(gomock.Any(), "event01", nil, []string{"User02@example.com"}).
+						Return(&model.AttendeeChange{}, nil),
+					mbr.EXPECT().SaveEventBinding(gomock.Any(), &model.EventBinding{
+						EventID:        "event01",
+						AttendeeEmails: []string{"user01@example.com", "user03@example.com"},
+					}).Return(nil),
+				)
+			},
+			want: &model.AttendeeChange{},
+		},
+		{
+			name:    "OK: no changes",
+			invited: []string{"USER01@example.com", "user03@example.com"},
+			want:    &model.AttendeeChange{},
+		},
+		{
+			name:    "NG: error in calendarRepository.UpdateAttendees",
+			invited: []string{"user01@example.com"},
+			prepare: func(mcr *mock_repository.MockCalendarRepository, mbr *mock_repository.MockEventBindingRepository) {
+				mcr.EXPECT().UpdateAttendees(gomock.Any(), "event01", []string{"user03@example.com"}, nil).
+					Return(nil, errors.New("sample error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mcr := mock_repository.NewMockCalendarRepository(ctrl)
+			mbr := mock_repository.NewMockEventBindingRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(mcr, mbr)
+			}
+			s := &googleCalenderService{
+				calendarRepository:     mcr,
+				eventBindingRepository: mbr,
+			}
+			binding := &model.EventBinding{EventID: "event01", AttendeeEmails: tt.invited}
+			got, err := s.SyncAttendees(context.Background(), binding, emails)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SyncAttendees() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SyncAttendees() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
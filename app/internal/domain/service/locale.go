@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+)
+
+type LocaleService interface {
+	// WithUserLocale returns the context carrying the locale of the user, which the messages are rendered in
+	WithUserLocale(ctx context.Context, userID string) context.Context
+}
+
+type localeService struct {
+	slackRepository repository.SlackRepository
+	defaultLocale   i18n.Locale
+}
+
+// NewLocaleService builds LocaleService.
+// defaultLocale is used when the locale of the user is unknown or not supported.
+func NewLocaleService(factory repository.Factory, defaultLocale i18n.Locale) *localeService {
+	return &localeService{
+		slackRepository: factory.SlackRepository(),
+		defaultLocale:   defaultLocale,
+	}
+}
+
+func (s *localeService) WithUserLocale(ctx context.Context, userID string) context.Context {
+	return i18n.WithLocale(ctx, s.userLocale(ctx, userID))
+}
+
+// userLocale returns the locale of the user, or the default one if it is unknown or not supported
+func (s *localeService) userLocale(ctx context.Context, userID string) i18n.Locale {
+	if userID == "" {
+		return s.defaultLocale
+	}
+	l, err := s.slackRepository.GetUserLocale(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get locale of %s: %v", userID, err)
+		return s.defaultLocale
+	}
+	locale, ok := i18n.ParseLocale(l)
+	if !ok {
+		return s.defaultLocale
+	}
+	return locale
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+)
+
+func Test_localeService_WithUserLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		prepare func(msr *mock_repository.MockSlackRepository)
+		want    i18n.Locale
+	}{
+		{
+			name:   "OK: locale of the user",
+			userID: "user01",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetUserLocale(gomock.Any(), "user01").Return("en-US", nil)
+			},
+			want: i18n.English,
+		},
+		{
+			name:   "OK: default locale when the locale of the user is not supported",
+			userID: "user01",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetUserLocale(gomock.Any(), "user01").Return("fr-FR", nil)
+			},
+			want: i18n.Japanese,
+		},
+		{
+			name:   "NG: default locale when the locale of the user is unknown",
+			userID: "user01",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetUserLocale(gomock.Any(), "user01").Return("", errors.New("sample_error"))
+			},
+			want: i18n.Japanese,
+		},
+		{
+			name: "NG: default locale without the user",
+			want: i18n.Japanese,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(msr)
+			}
+			s := &localeService{
+				slackRepository: msr,
+				defaultLocale:   i18n.Japanese,
+			}
+			if got := i18n.FromContext(s.WithUserLocale(context.Background(), tt.userID)); got != tt.want {
+				t.Errorf("WithUserLocale() locale = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
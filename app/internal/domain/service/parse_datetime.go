@@ -16,5 +16,66 @@
 
 package service
 
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/datetime"
+)
+
 type ParseDatetimeService interface {
+	// Parse reads the schedule of a meeting (e.g. "明日15時から1時間 定例", "tomorrow 3pm for 45m Review")
+	// in the time zone of the user. The text other than the date and time becomes the title.
+	// The error is a *datetime.ParseError if the text has no date and time or it is ambiguous.
+	Parse(ctx context.Context, text, userID string) (*model.Schedule, error)
+}
+
+type parseDatetimeService struct {
+	slackRepository repository.SlackRepository
+	parser          *datetime.Parser
+	location        *time.Location
+	now             func() time.Time
+}
+
+// NewParseDatetimeService builds ParseDatetimeService.
+// location is used when the time zone of the user is unknown.
+func NewParseDatetimeService(factory repository.Factory, location *time.Location) *parseDatetimeService {
+	return &parseDatetimeService{
+		slackRepository: factory.SlackRepository(),
+		parser:          datetime.NewParser(),
+		location:        location,
+		now:             time.Now,
+	}
+}
+
+func (s *parseDatetimeService) Parse(ctx context.Context, text, userID string) (*model.Schedule, error) {
+	result, err := s.parser.Parse(text, s.now().In(s.userLocation(ctx, userID)))
+	if err != nil {
+		return nil, err
+	}
+	return &model.Schedule{
+		Start: result.Start,
+		End:   result.End,
+		Title: result.Rest,
+	}, nil
+}
+
+// userLocation returns the time zone of the user, or the default one if it is unknown
+func (s *parseDatetimeService) userLocation(ctx context.Context, userID string) *time.Location {
+	if userID == "" {
+		return s.location
+	}
+	tz, err := s.slackRepository.GetUserTimeZone(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get time zone of %s: %v", userID, err)
+		return s.location
+	}
+	location, err := time.LoadLocation(tz)
+	if tz == "" || err != nil {
+		return s.location
+	}
+	return location
 }
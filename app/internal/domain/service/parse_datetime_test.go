@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/datetime"
+)
+
+func Test_parseDatetimeService_Parse(t *testing.T) {
+	jst, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)
+	type args struct {
+		text   string
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		prepare func(msr *mock_repository.MockSlackRepository)
+		want    *model.Schedule
+		wantErr error
+	}{
+		{
+			name: "OK: in the time zone of the user",
+			args: args{text: "明日15時から1時間 定例", userID: "user01"},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetUserTimeZone(gomock.Any(), "user01").Return("Asia/Tokyo", nil)
+			},
+			want: &model.Schedule{
+				Start: time.Date(2026, 10, 22, 15, 0, 0, 0, jst),
+				End:   time.Date(2026, 10, 22, 16, 0, 0, 0, jst),
+				Title: "定例",
+			},
+		},
+		{
+			name: "OK: in the default time zone when the time zone of the user is unknown",
+			args: args{text: "明日15時から1時間 定例", userID: "user01"},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetUserTimeZone(gomock.Any(), "user01").Return("", errors.New("sample_error"))
+			},
+			want: &model.Schedule{
+				Start: time.Date(2026, 10, 22, 15, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 10, 22, 16, 0, 0, 0, time.UTC),
+				Title: "定例",
+			},
+		},
+		{
+			name:    "NG: ambiguous text",
+			args:    args{text: "明日 定例"},
+			wantErr: datetime.ErrAmbiguous,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(msr)
+			}
+			s := &parseDatetimeService{
+				slackRepository: msr,
+				parser:          datetime.NewParser(),
+				location:        time.UTC,
+				now:             func() time.Time { return now },
+			}
+			got, err := s.Parse(context.Background(), tt.args.text, tt.args.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// ResponseTarget is where SlackResponseService sends the responses to a call of Auriga:
+// the thread where it was mentioned, the response_url of the slash command,
+// or the thread of the message which the shortcut or a button was used on
+type ResponseTarget struct {
+	// channelID and ts are of the thread where the responses are posted
+	channelID string
+	ts        string
+	// userID is the user who called Auriga
+	userID string
+	// responseURL is of the slash command, to which the responses are sent instead of the thread
+	responseURL string
+	// replaceURL is of the email list whose "Refresh" button was pushed, which the new list replaces
+	replaceURL string
+	// private is true if the others did not see the call, so that the results for the user are sent only to them
+	private bool
+	// help and notFound are the messages of the help and of the message which is not found
+	help     i18n.Key
+	notFound i18n.Key
+}
+
+// NewMentionTarget returns the target of the thread where Auriga was mentioned
+func NewMentionTarget(event *slackevents.AppMentionEvent) *ResponseTarget {
+	return &ResponseTarget{
+		channelID: event.Channel,
+		ts:        event.ThreadTimeStamp,
+		userID:    event.User,
+		help:      i18n.HelpMention,
+		notFound:  i18n.ErrorThreadNotFound,
+	}
+}
+
+// NewSlashCommandTarget returns the target of the response_url of the slash command.
+// The files are sent in the direct message, since the command may be called outside the thread.
+func NewSlashCommandTarget(command *slack.SlashCommand) *ResponseTarget {
+	return &ResponseTarget{
+		userID:      command.UserID,
+		responseURL: command.ResponseURL,
+		private:     true,
+		help:        i18n.HelpSlashCommand,
+		// the message of the permalink is not found, or Auriga has not joined the channel
+		notFound: i18n.ErrorPermalinkNotFound,
+	}
+}
+
+// NewMessageTarget returns the target of the thread of the message which the shortcut or a button was used on by the user
+func NewMessageTarget(channelID, ts, userID string) *ResponseTarget {
+	return &ResponseTarget{
+		channelID: channelID,
+		ts:        ts,
+		userID:    userID,
+		private:   true,
+		help:      i18n.ErrorNoReactionSelected,
+		notFound:  i18n.ErrorMessageNotFound,
+	}
+}
+
+// NewRefreshTarget returns the target like NewMessageTarget, whose email list replaces the one of responseURL
+func NewRefreshTarget(responseURL, channelID, ts, userID string) *ResponseTarget {
+	t := NewMessageTarget(channelID, ts, userID)
+	t.replaceURL = responseURL
+	return t
+}
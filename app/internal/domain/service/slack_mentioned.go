@@ -26,8 +26,21 @@ import (
 
 const (
 	CommandHelp = "help"
+	CommandPoll = "poll"
+	// CommandPending lists the members of the channel, or of the following user groups and channels, who have not reacted
+	CommandPending = "pending"
+	// SourceReplies selects the users who replied in the thread, followed by the reactions to combine optionally
+	SourceReplies = "replies"
 )
 
+// knownFlags are the names of the flags. The other words starting with "--" are left in the text (e.g. "振り返り --draft版").
+var knownFlags = map[string]bool{
+	"book": true, "ics": true, "link": true, "remind": true,
+	model.EmailListFormatCSV: true, model.EmailListFormatTSV: true, "format": true, "sort": true, "with-names": true,
+	"no-guests": true, "no-external": true, "domain": true,
+	"keyword": true, "regex": true, "exclude-me": true,
+}
+
 type SlackMentionedService interface {
 	Parse(message string) *model.MentionParseResult
 }
@@ -39,25 +52,152 @@ func NewSlackMentionedService() *slackMentionedService {
 	return &slackMentionedService{}
 }
 
+// Parse parses the mention like "@Auriga :sanka: :maybe: +:onsite: -:absent: text".
+// Reactions are combined as follows:
+//   - ":a: :b:" selects users who reacted with :a: or :b:
+//   - "+:c:" selects only users who also reacted with :c:
+//   - "-:d:" excludes users who reacted with :d:
+//
+// "replies" before the reactions selects the users who replied in the thread as well,
+// whose replies are filtered by "--keyword=..." and "--regex=...".
+// The mentions of user groups ("@design-team") and channels ("#project-x") before the reactions
+// select their members instead of the users who reacted, and the reactions filter them.
+//
+// The words following the reactions are stored in Text.
+//
+// "pending" followed by the user groups and the channels (the channel of the thread if omitted)
+// selects their members who have not reacted at all.
 func (s *slackMentionedService) Parse(message string) *model.MentionParseResult {
-	tmp := strings.Split(message, " ")
+	tmp := strings.Fields(message)
 	if len(tmp) < 2 {
 		// no arguments
 		return &model.MentionParseResult{
 			Message: message,
 		}
 	}
-	reaction := tmp[1]
-	if slack.IsReaction(reaction) {
+	words, flags := s.splitFlags(tmp[1:])
+	if len(words) > 0 && words[0] == CommandPoll {
 		return &model.MentionParseResult{
 			Message: message,
-			Reaction: slack.ExtractReactionName(
-				slack.RemoveSkinToneFromReaction(reaction)),
+			Command: CommandPoll,
+			Text:    strings.Join(words[1:], " "),
+			Flags:   flags,
+		}
+	}
+	if len(words) > 0 && words[0] == CommandPending {
+		filter := &model.ReactionFilter{NoReactions: true}
+		for _, word := range words[1:] {
+			if !s.parseMemberSource(word, filter) {
+				break
+			}
+		}
+		return &model.MentionParseResult{
+			Message:   message,
+			Command:   CommandPending,
+			Reactions: filter,
+			Flags:     flags,
+		}
+	}
+	filter := &model.ReactionFilter{}
+	i := 0
+	for ; i < len(words); i++ {
+		if !s.parseSource(words[i], filter, flags) {
+			break
+		}
+	}
+	for ; i < len(words); i++ {
+		if !s.parseReaction(words[i], filter) {
+			break
+		}
+	}
+	if filter.IsEmpty() {
+		// invalid argument (not emoji)
+		return &model.MentionParseResult{
+			Message: message,
+			Command: CommandHelp,
 		}
 	}
-	// invalid argument (not emoji)
 	return &model.MentionParseResult{
-		Message: message,
-		Command: CommandHelp,
+		Message:   message,
+		Reactions: filter,
+		Text:      strings.Join(words[i:], " "),
+		Flags:     flags,
+	}
+}
+
+// splitFlags separates the known flags ("--name" or "--name=value") from the other words.
+// The em dash is also accepted because some clients replace "--" with it.
+func (s *slackMentionedService) splitFlags(words []string) ([]string, map[string]string) {
+	var flags map[string]string
+	others := make([]string, 0, len(words))
+	for _, word := range words {
+		var flag string
+		switch {
+		case strings.HasPrefix(word, "--") && len(word) > 2:
+			flag = word[2:]
+		case strings.HasPrefix(word, "—") && len(word) > len("—"):
+			flag = word[len("—"):]
+		}
+		name, value := flag, ""
+		if i := strings.Index(flag, "="); i >= 0 {
+			name, value = flag[:i], flag[i+1:]
+		}
+		if !knownFlags[name] {
+			others = append(others, word)
+			continue
+		}
+		if flags == nil {
+			flags = map[string]string{}
+		}
+		flags[name] = value
+	}
+	return others, flags
+}
+
+// parseSource adds the source of the users other than the reactions to filter.
+// It returns false if the word is not a source.
+func (s *slackMentionedService) parseSource(word string, filter *model.ReactionFilter, flags map[string]string) bool {
+	if word == SourceReplies {
+		filter.Replies = &model.ReplyFilter{
+			Keyword: flags["keyword"],
+			Pattern: flags["regex"],
+		}
+		return true
+	}
+	return s.parseMemberSource(word, filter)
+}
+
+// parseMemberSource adds the user group or the channel to filter.Members.
+// It returns false if the word is not a mention of them.
+func (s *slackMentionedService) parseMemberSource(word string, filter *model.ReactionFilter) bool {
+	if userGroupID, handle, ok := slack.ParseUserGroupMention(word); ok {
+		filter.Members = append(filter.Members, &model.MemberSource{UserGroupID: userGroupID, Name: handle})
+		return true
+	}
+	if channelID, name, ok := slack.ParseChannelMention(word); ok {
+		filter.Members = append(filter.Members, &model.MemberSource{ChannelID: channelID, Name: name})
+		return true
+	}
+	return false
+}
+
+// parseReaction adds the reaction to filter according to its prefix ("+" or "-").
+// It returns false if the word is not a reaction.
+func (s *slackMentionedService) parseReaction(word string, filter *model.ReactionFilter) bool {
+	target := &filter.Any
+	if len(word) > 1 && slack.IsReaction(word[1:]) {
+		switch word[0] {
+		case '+':
+			target = &filter.All
+			word = word[1:]
+		case '-':
+			target = &filter.Exclude
+			word = word[1:]
+		}
+	}
+	if !slack.IsReaction(word) {
+		return false
 	}
+	*target = append(*target, slack.ExtractReactionName(slack.RemoveSkinToneFromReaction(word)))
+	return true
 }
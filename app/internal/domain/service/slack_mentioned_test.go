@@ -55,8 +55,98 @@ func Test_slackMentionedService_Parse(t *testing.T) {
 			name: "OK: when a reaction is specified",
 			args: args{message: "@auriga :join:"},
 			want: &model.MentionParseResult{
-				Message:  "@auriga :join:",
-				Reaction: "join",
+				Message:   "@auriga :join:",
+				Reactions: &model.ReactionFilter{Any: []string{"join"}},
+			},
+		},
+		{
+			name: "OK: when a reaction and a schedule are specified",
+			args: args{message: "@auriga :join: 2026-11-02 15:00-16:00 Sprint review"},
+			want: &model.MentionParseResult{
+				Message:   "@auriga :join: 2026-11-02 15:00-16:00 Sprint review",
+				Reactions: &model.ReactionFilter{Any: []string{"join"}},
+				Text:      "2026-11-02 15:00-16:00 Sprint review",
+			},
+		},
+		{
+			name: "OK: when multiple reactions are combined",
+			args: args{message: "@auriga :sanka: :maybe::skin-tone-3: +:onsite: -:absent: 明日15時 定例"},
+			want: &model.MentionParseResult{
+				Message: "@auriga :sanka: :maybe::skin-tone-3: +:onsite: -:absent: 明日15時 定例",
+				Reactions: &model.ReactionFilter{
+					Any:     []string{"sanka", "maybe"},
+					All:     []string{"onsite"},
+					Exclude: []string{"absent"},
+				},
+				Text: "明日15時 定例",
+			},
+		},
+		{
+			name: "OK: when only an exclusion is specified",
+			args: args{message: "@auriga -:absent:"},
+			want: &model.MentionParseResult{
+				Message:   "@auriga -:absent:",
+				Reactions: &model.ReactionFilter{Exclude: []string{"absent"}},
+			},
+		},
+		{
+			name: "OK: poll command with flags",
+			args: args{message: "@auriga poll --book 定例"},
+			want: &model.MentionParseResult{
+				Message: "@auriga poll --book 定例",
+				Command: CommandPoll,
+				Text:    "定例",
+				Flags:   map[string]string{"book": ""},
+			},
+		},
+		{
+			name: "OK: pending command",
+			args: args{message: "@auriga pending --remind"},
+			want: &model.MentionParseResult{
+				Message:   "@auriga pending --remind",
+				Command:   CommandPending,
+				Reactions: &model.ReactionFilter{NoReactions: true},
+				Flags:     map[string]string{"remind": ""},
+			},
+		},
+		{
+			name: "OK: pending command for a user group",
+			args: args{message: "@auriga pending <!subteam^S0123456789|@design-team>"},
+			want: &model.MentionParseResult{
+				Message: "@auriga pending <!subteam^S0123456789|@design-team>",
+				Command: CommandPending,
+				Reactions: &model.ReactionFilter{
+					Members:     []*model.MemberSource{{UserGroupID: "S0123456789", Name: "@design-team"}},
+					NoReactions: true,
+				},
+			},
+		},
+		{
+			name: "OK: flags with value between the text",
+			args: args{message: "@auriga :join: 明日15時 --format=comma 定例 —csv"},
+			want: &model.MentionParseResult{
+				Message:   "@auriga :join: 明日15時 --format=comma 定例 —csv",
+				Reactions: &model.ReactionFilter{Any: []string{"join"}},
+				Text:      "明日15時 定例",
+				Flags:     map[string]string{"format": "comma", "csv": ""},
+			},
+		},
+		{
+			name: "OK: unknown flags are left in the text",
+			args: args{message: "@auriga :join: 明日15時 振り返り --draft版 —memo --csv"},
+			want: &model.MentionParseResult{
+				Message:   "@auriga :join: 明日15時 振り返り --draft版 —memo --csv",
+				Reactions: &model.ReactionFilter{Any: []string{"join"}},
+				Text:      "明日15時 振り返り --draft版 —memo",
+				Flags:     map[string]string{"csv": ""},
+			},
+		},
+		{
+			name: "NG: only flags are specified",
+			args: args{message: "@auriga --csv"},
+			want: &model.MentionParseResult{
+				Message: "@auriga --csv",
+				Command: CommandHelp,
 			},
 		},
 		{
@@ -71,8 +161,43 @@ func Test_slackMentionedService_Parse(t *testing.T) {
 			name: "OK: when a reaction with skin-tone is specified",
 			args: args{message: "@auriga :+1::skin-tone-2:"},
 			want: &model.MentionParseResult{
-				Message:  "@auriga :+1::skin-tone-2:",
-				Reaction: "+1",
+				Message:   "@auriga :+1::skin-tone-2:",
+				Reactions: &model.ReactionFilter{Any: []string{"+1"}},
+			},
+		},
+		{
+			name: "OK: replies",
+			args: args{message: "@auriga replies"},
+			want: &model.MentionParseResult{
+				Message:   "@auriga replies",
+				Reactions: &model.ReactionFilter{Replies: &model.ReplyFilter{}},
+			},
+		},
+		{
+			name: "OK: replies with reactions and the filters of the replies",
+			args: args{message: "@auriga replies :sanka: -:absent: --keyword=参加 --regex=^(出|参) --exclude-me"},
+			want: &model.MentionParseResult{
+				Message: "@auriga replies :sanka: -:absent: --keyword=参加 --regex=^(出|参) --exclude-me",
+				Reactions: &model.ReactionFilter{
+					Any:     []string{"sanka"},
+					Exclude: []string{"absent"},
+					Replies: &model.ReplyFilter{Keyword: "参加", Pattern: "^(出|参)"},
+				},
+				Flags: map[string]string{"keyword": "参加", "regex": "^(出|参)", "exclude-me": ""},
+			},
+		},
+		{
+			name: "OK: members of a user group and a channel who did not react",
+			args: args{message: "@auriga <!subteam^S0123456789|@design-team> <#C0123456789|project-x> -:sanka:"},
+			want: &model.MentionParseResult{
+				Message: "@auriga <!subteam^S0123456789|@design-team> <#C0123456789|project-x> -:sanka:",
+				Reactions: &model.ReactionFilter{
+					Exclude: []string{"sanka"},
+					Members: []*model.MemberSource{
+						{UserGroupID: "S0123456789", Name: "@design-team"},
+						{ChannelID: "C0123456789", Name: "project-x"},
+					},
+				},
 			},
 		},
 		{
@@ -116,3 +241,45 @@ func Test_slackMentionedService_Parse(t *testing.T) {
 		})
 	}
 }
+
+func Test_slackMentionedService_Parse_UserFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    *model.UserFilter
+	}{
+		{
+			name:    "OK: domains",
+			message: "@auriga :join: --no-guests --domain=example.com,@example.org",
+			want:    &model.UserFilter{NoGuests: true, Domains: []string{"example.com", "example.org"}},
+		},
+		{
+			name:    "OK: domains linked by Slack",
+			message: "@auriga :join: --domain=<http://example.com|example.com>,<http://example.org|example.org>",
+			want:    &model.UserFilter{Domains: []string{"example.com", "example.org"}},
+		},
+		{
+			name:    "OK: email linked by Slack",
+			message: "@auriga :join: --domain=<mailto:taro@example.com|taro@example.com>",
+			want:    &model.UserFilter{Domains: []string{"example.com"}},
+		},
+		{
+			name:    "OK: link without the label",
+			message: "@auriga :join: --no-external --domain=<http://example.com>",
+			want:    &model.UserFilter{NoExternal: true, Domains: []string{"example.com"}},
+		},
+		{
+			name:    "OK: no filter",
+			message: "@auriga :join:",
+			want:    &model.UserFilter{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &slackMentionedService{}
+			if got := s.Parse(tt.message).UserFilter(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
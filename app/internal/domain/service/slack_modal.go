@@ -0,0 +1,181 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/errors"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// CallbackIDCollectAttendees is the callback ID of the message shortcut and its modal.
+	// The shortcut must be registered on the Slack app with this ID.
+	CallbackIDCollectAttendees = "collect_attendees"
+	// ActionIDRefreshReactions is the action ID of the button reloading the reactions of the modal
+	ActionIDRefreshReactions = "refresh_reactions"
+
+	blockIDReactions = "reactions"
+	blockIDFormat    = "format"
+	blockIDDatetime  = "datetime"
+	blockIDTitle     = "title"
+)
+
+type SlackModalService interface {
+	// OpenCollectModal opens the modal listing the reactions on the message
+	OpenCollectModal(ctx context.Context, triggerID, channelID, ts string) error
+	// RefreshCollectModal reloads the reactions on the message of the modal
+	RefreshCollectModal(ctx context.Context, view *slack.View) error
+	// ReadCollectModal reads the input of the submitted modal
+	ReadCollectModal(view *slack.View) (*model.CollectModalInput, error)
+}
+
+type slackModalService struct {
+	slackRepository repository.SlackRepository
+}
+
+func NewSlackModalService(factory repository.Factory) *slackModalService {
+	return &slackModalService{
+		slackRepository: factory.SlackRepository(),
+	}
+}
+
+// collectModalMetadata is stored in private_metadata of the modal to remember the message
+type collectModalMetadata struct {
+	ChannelID string `json:"channel_id"`
+	TimeStamp string `json:"ts"`
+}
+
+func (s *slackModalService) OpenCollectModal(ctx context.Context, triggerID, channelID, ts string) error {
+	view, err := s.collectModal(ctx, channelID, ts)
+	if err != nil {
+		return err
+	}
+	return s.slackRepository.OpenView(ctx, triggerID, view)
+}
+
+func (s *slackModalService) RefreshCollectModal(ctx context.Context, view *slack.View) error {
+	var metadata collectModalMetadata
+	if err := json.Unmarshal([]byte(view.PrivateMetadata), &metadata); err != nil {
+		return errors.Wrap(err, "failed to read private_metadata")
+	}
+	newView, err := s.collectModal(ctx, metadata.ChannelID, metadata.TimeStamp)
+	if err != nil {
+		return err
+	}
+	return s.slackRepository.UpdateView(ctx, view.ID, view.Hash, newView)
+}
+
+func (s *slackModalService) collectModal(ctx context.Context, channelID, ts string) (slack.ModalViewRequest, error) {
+	msg, err := s.slackRepository.GetParentMessage(ctx, channelID, ts)
+	if err != nil {
+		return slack.ModalViewRequest{}, err
+	}
+	metadata, err := json.Marshal(&collectModalMetadata{ChannelID: channelID, TimeStamp: ts})
+	if err != nil {
+		return slack.ModalViewRequest{}, errors.Wrap(err, "failed to write private_metadata")
+	}
+	return slack.ModalViewRequest{
+		Type:            slack.VTModal,
+		CallbackID:      CallbackIDCollectAttendees,
+		Title:           plainText(i18n.T(ctx, i18n.ModalTitle)),
+		Submit:          plainText(i18n.T(ctx, i18n.ModalSubmit)),
+		Close:           plainText(i18n.T(ctx, i18n.ModalClose)),
+		PrivateMetadata: string(metadata),
+		Blocks:          slack.Blocks{BlockSet: collectModalBlocks(i18n.FromContext(ctx), msg.Reactions)},
+	}, nil
+}
+
+func collectModalBlocks(locale i18n.Locale, reactions []*model.SlackReaction) []slack.Block {
+	var blocks []slack.Block
+	if len(reactions) == 0 {
+		blocks = append(blocks, slack.NewSectionBlock(plainText(locale.T(i18n.ModalNoReactions)), nil, nil))
+	} else {
+		// reactions with different skin-tones are shown as one
+		var names []string
+		users := map[string]map[string]bool{}
+		for _, reaction := range reactions {
+			name := normalizeReactionName(reaction.Name)
+			if users[name] == nil {
+				names = append(names, name)
+				users[name] = map[string]bool{}
+			}
+			for _, userID := range reaction.UserIDs {
+				users[name][userID] = true
+			}
+		}
+		options := make([]*slack.OptionBlockObject, 0, len(names))
+		for _, name := range names {
+			options = append(options, slack.NewOptionBlockObject(name,
+				slack.NewTextBlockObject(slack.MarkdownType, locale.T(i18n.ModalReactionOption, name, len(users[name])), false, false), nil))
+		}
+		blocks = append(blocks, slack.NewInputBlock(blockIDReactions, plainText(locale.T(i18n.ModalReactionsLabel)),
+			slack.NewCheckboxGroupsBlockElement(blockIDReactions, options...)))
+	}
+	blocks = append(blocks, slack.NewActionBlock("",
+		slack.NewButtonBlockElement(ActionIDRefreshReactions, "", plainText(locale.T(i18n.ModalRefreshReactions)))))
+
+	lines := slack.NewOptionBlockObject(model.EmailListFormatLines, plainText(locale.T(i18n.ModalFormatLines)), nil)
+	comma := slack.NewOptionBlockObject(model.EmailListFormatComma, plainText(locale.T(i18n.ModalFormatComma)), nil)
+	csv := slack.NewOptionBlockObject(model.EmailListFormatCSV, plainText(locale.T(i18n.ModalFormatCSV)), nil)
+	format := slack.NewRadioButtonsBlockElement(blockIDFormat, lines, comma, csv)
+	format.InitialOption = lines
+	blocks = append(blocks, slack.NewInputBlock(blockIDFormat, plainText(locale.T(i18n.ModalFormatLabel)), format))
+
+	datetime := slack.NewInputBlock(blockIDDatetime, plainText(locale.T(i18n.ModalDatetimeLabel)),
+		slack.NewPlainTextInputBlockElement(plainText(locale.T(i18n.ModalDatetimePlaceholder)), blockIDDatetime))
+	datetime.Optional = true
+	datetime.Hint = plainText(locale.T(i18n.ModalDatetimeHint))
+	title := slack.NewInputBlock(blockIDTitle, plainText(locale.T(i18n.ModalTitleLabel)),
+		slack.NewPlainTextInputBlockElement(nil, blockIDTitle))
+	title.Optional = true
+	return append(blocks, datetime, title)
+}
+
+func (s *slackModalService) ReadCollectModal(view *slack.View) (*model.CollectModalInput, error) {
+	var metadata collectModalMetadata
+	if err := json.Unmarshal([]byte(view.PrivateMetadata), &metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to read private_metadata")
+	}
+	input := &model.CollectModalInput{
+		ChannelID: metadata.ChannelID,
+		TimeStamp: metadata.TimeStamp,
+		Format:    model.EmailListFormatLines,
+	}
+	if view.State == nil {
+		return input, nil
+	}
+	for _, option := range view.State.Values[blockIDReactions][blockIDReactions].SelectedOptions {
+		input.Reactions = append(input.Reactions, option.Value)
+	}
+	if format := view.State.Values[blockIDFormat][blockIDFormat].SelectedOption.Value; format != "" {
+		input.Format = format
+	}
+	input.Datetime = strings.TrimSpace(view.State.Values[blockIDDatetime][blockIDDatetime].Value)
+	input.Title = strings.TrimSpace(view.State.Values[blockIDTitle][blockIDTitle].Value)
+	return input, nil
+}
+
+func plainText(text string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.PlainTextType, text, false, false)
+}
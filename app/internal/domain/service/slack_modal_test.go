@@ -0,0 +1,148 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/slack-go/slack"
+
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+func Test_slackModalService_OpenCollectModal(t *testing.T) {
+	tests := []struct {
+		name        string
+		prepare     func(msr *mock_repository.MockSlackRepository)
+		wantOptions []string
+		wantErr     bool
+	}{
+		{
+			name: "OK",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetParentMessage(gomock.Any(), "sampleCID", "sampleTs").Return(&model.SlackMessage{
+					Reactions: []*model.SlackReaction{
+						{Name: "sanka", UserIDs: []string{"user01", "user02"}},
+						{Name: "+1::skin-tone-2", UserIDs: []string{"user03"}},
+						{Name: "+1", UserIDs: []string{"user01"}},
+					},
+				}, nil)
+				msr.EXPECT().OpenView(gomock.Any(), "sampleTriggerID", gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, view slack.ModalViewRequest) error {
+						if view.CallbackID != CallbackIDCollectAttendees || view.PrivateMetadata != `{"channel_id":"sampleCID","ts":"sampleTs"}` {
+							t.Errorf("unexpected view: %+v", view)
+						}
+						input := view.Blocks.BlockSet[0].(*slack.InputBlock)
+						var got []string
+						for _, option := range input.Element.(*slack.CheckboxGroupsBlockElement).Options {
+							got = append(got, option.Value+" "+option.Text.Text)
+						}
+						want := []string{"sanka :sanka: (2名)", "+1 :+1: (2名)"}
+						if !reflect.DeepEqual(got, want) {
+							t.Errorf("options = %v, want %v", got, want)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "NG: error in slackRepository.GetParentMessage",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetParentMessage(gomock.Any(), "sampleCID", "sampleTs").Return(nil, errSample)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			tt.prepare(msr)
+			s := &slackModalService{
+				slackRepository: msr,
+			}
+			if err := s.OpenCollectModal(context.Background(), "sampleTriggerID", "sampleCID", "sampleTs"); (err != nil) != tt.wantErr {
+				t.Errorf("OpenCollectModal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_slackModalService_ReadCollectModal(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    *slack.View
+		want    *model.CollectModalInput
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			view: &slack.View{
+				PrivateMetadata: `{"channel_id":"sampleCID","ts":"sampleTs"}`,
+				State: &slack.ViewState{Values: map[string]map[string]slack.BlockAction{
+					"reactions": {"reactions": {SelectedOptions: []slack.OptionBlockObject{{Value: "sanka"}, {Value: "maybe"}}}},
+					"format":    {"format": {SelectedOption: slack.OptionBlockObject{Value: "comma"}}},
+					"datetime":  {"datetime": {Value: " 明日15時から1時間 "}},
+					"title":     {"title": {Value: "定例"}},
+				}},
+			},
+			want: &model.CollectModalInput{
+				ChannelID: "sampleCID",
+				TimeStamp: "sampleTs",
+				Reactions: []string{"sanka", "maybe"},
+				Format:    model.EmailListFormatComma,
+				Datetime:  "明日15時から1時間",
+				Title:     "定例",
+			},
+		},
+		{
+			name: "OK: nothing is filled in",
+			view: &slack.View{
+				PrivateMetadata: `{"channel_id":"sampleCID","ts":"sampleTs"}`,
+				State:           &slack.ViewState{},
+			},
+			want: &model.CollectModalInput{
+				ChannelID: "sampleCID",
+				TimeStamp: "sampleTs",
+				Format:    model.EmailListFormatLines,
+			},
+		},
+		{
+			name:    "NG: broken private_metadata",
+			view:    &slack.View{PrivateMetadata: "broken"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &slackModalService{}
+			got, err := s.ReadCollectModal(tt.view)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadCollectModal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadCollectModal() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
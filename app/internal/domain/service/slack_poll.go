@@ -0,0 +1,157 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/errors"
+	"github.com/moneyforward/auriga/app/pkg/slice"
+)
+
+var (
+	// ErrPollNotFound is returned when the parent message has no numbered candidates
+	ErrPollNotFound = errors.New("poll_not_found")
+	// ErrPollNoVotes is returned when booking a poll nobody voted for
+	ErrPollNoVotes = errors.New("poll_no_votes")
+	// ErrPollTied is returned when booking a poll whose top slots have the same number of votes
+	ErrPollTied = errors.New("poll_tied")
+)
+
+type SlackPollService interface {
+	// TallyPoll reads the candidate slots numbered like ":one:" or "1." from the parent message of the thread
+	// and ranks them by the number of users who reacted with the corresponding number.
+	TallyPoll(ctx context.Context, channelID, ts string) (*model.PollResult, error)
+	// PickWinner returns the slot with the most votes
+	PickWinner(result *model.PollResult) (*model.PollSlot, error)
+}
+
+type slackPollService struct {
+	slackRepository repository.SlackRepository
+}
+
+func NewSlackPollService(factory repository.Factory) *slackPollService {
+	return &slackPollService{
+		slackRepository: factory.SlackRepository(),
+	}
+}
+
+// numberReactions maps the reaction names of numbers to the numbers
+var numberReactions = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "keycap_ten": 10,
+}
+
+var numberReactionNames = []string{"", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "keycap_ten"}
+
+// regPollSlot matches a line of a candidate like ":one: 11/5 14:00", "1. 11/5 14:00" or "① 11/5 14:00"
+var regPollSlot = regexp.MustCompile(`^\s*(?:[-*•]\s*)?(?::(one|two|three|four|five|six|seven|eight|nine|keycap_ten):|(10|[1-9])[.)．）]|([①②③④⑤⑥⑦⑧⑨⑩]))\s*(.*)$`)
+
+const circledNumbers = "①②③④⑤⑥⑦⑧⑨⑩"
+
+func (s *slackPollService) TallyPoll(ctx context.Context, channelID, ts string) (*model.PollResult, error) {
+	msg, err := s.slackRepository.GetParentMessage(ctx, channelID, ts)
+	if err != nil {
+		return nil, err
+	}
+	return s.tally(msg)
+}
+
+func (s *slackPollService) tally(msg *model.SlackMessage) (*model.PollResult, error) {
+	slots := map[int]*model.PollSlot{}
+	for _, line := range strings.Split(msg.Text, "\n") {
+		number, text := parsePollSlot(line)
+		if number == 0 || slots[number] != nil {
+			continue
+		}
+		slots[number] = &model.PollSlot{Number: number, Reaction: numberReactionNames[number], Text: text}
+	}
+	var respondentIDs []string
+	for _, reaction := range msg.Reactions {
+		number, ok := numberReactions[normalizeReactionName(reaction.Name)]
+		if !ok {
+			continue
+		}
+		if slots[number] == nil {
+			// the candidate may be written in a way other than text (e.g. an image)
+			slots[number] = &model.PollSlot{Number: number, Reaction: numberReactionNames[number]}
+		}
+		slots[number].UserIDs = slice.ToStringSet(append(slots[number].UserIDs, reaction.UserIDs...))
+		respondentIDs = append(respondentIDs, reaction.UserIDs...)
+	}
+	if len(slots) == 0 {
+		return nil, ErrPollNotFound
+	}
+	result := &model.PollResult{
+		Slots:         make([]*model.PollSlot, 0, len(slots)),
+		RespondentIDs: slice.ToStringSet(respondentIDs),
+	}
+	for _, slot := range slots {
+		voted := map[string]bool{}
+		for _, userID := range slot.UserIDs {
+			voted[userID] = true
+		}
+		for _, userID := range result.RespondentIDs {
+			if !voted[userID] {
+				slot.UnavailableUserIDs = append(slot.UnavailableUserIDs, userID)
+			}
+		}
+		result.Slots = append(result.Slots, slot)
+	}
+	sort.Slice(result.Slots, func(i, j int) bool {
+		if len(result.Slots[i].UserIDs) != len(result.Slots[j].UserIDs) {
+			return len(result.Slots[i].UserIDs) > len(result.Slots[j].UserIDs)
+		}
+		return result.Slots[i].Number < result.Slots[j].Number
+	})
+	return result, nil
+}
+
+// parsePollSlot returns the number and the text of the candidate, or zero if the line is not a candidate
+func parsePollSlot(line string) (int, string) {
+	m := regPollSlot.FindStringSubmatch(line)
+	if m == nil {
+		return 0, ""
+	}
+	text := strings.TrimSpace(m[4])
+	switch {
+	case m[1] != "":
+		return numberReactions[m[1]], text
+	case m[2] != "":
+		if m[2] == "10" {
+			return 10, text
+		}
+		return int(m[2][0] - '0'), text
+	default:
+		return strings.Index(circledNumbers, m[3])/len("①") + 1, text
+	}
+}
+
+func (s *slackPollService) PickWinner(result *model.PollResult) (*model.PollSlot, error) {
+	if len(result.Slots) == 0 || len(result.Slots[0].UserIDs) == 0 {
+		return nil, ErrPollNoVotes
+	}
+	if len(result.Slots) > 1 && len(result.Slots[1].UserIDs) == len(result.Slots[0].UserIDs) {
+		return nil, ErrPollTied
+	}
+	return result.Slots[0], nil
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+func Test_slackPollService_TallyPoll(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     *model.SlackMessage
+		err     error
+		want    *model.PollResult
+		wantErr error
+	}{
+		{
+			name: "OK",
+			msg: &model.SlackMessage{
+				Text: "来週の定例の候補です\n:one: 11/5(木) 14:00-15:00\n:two: 11/6(金) 15時から1時間\n:three: 11/9(月) 10:00-11:00",
+				Reactions: []*model.SlackReaction{
+					{Name: "one", UserIDs: []string{"user01"}},
+					{Name: "two", UserIDs: []string{"user01", "user02"}},
+					{Name: "sanka", UserIDs: []string{"user03"}},
+				},
+			},
+			want: &model.PollResult{
+				Slots: []*model.PollSlot{
+					{Number: 2, Reaction: "two", Text: "11/6(金) 15時から1時間", UserIDs: []string{"user01", "user02"}},
+					{Number: 1, Reaction: "one", Text: "11/5(木) 14:00-15:00", UserIDs: []string{"user01"}, UnavailableUserIDs: []string{"user02"}},
+					{Number: 3, Reaction: "three", Text: "11/9(月) 10:00-11:00", UnavailableUserIDs: []string{"user01", "user02"}},
+				},
+				RespondentIDs: []string{"user01", "user02"},
+			},
+		},
+		{
+			name: "OK: numbered with digits and circled numbers",
+			msg: &model.SlackMessage{
+				Text: "1. 11/5 14:00\n② 11/6 15:00",
+				Reactions: []*model.SlackReaction{
+					{Name: "two", UserIDs: []string{"user01"}},
+				},
+			},
+			want: &model.PollResult{
+				Slots: []*model.PollSlot{
+					{Number: 2, Reaction: "two", Text: "11/6 15:00", UserIDs: []string{"user01"}},
+					{Number: 1, Reaction: "one", Text: "11/5 14:00", UnavailableUserIDs: []string{"user01"}},
+				},
+				RespondentIDs: []string{"user01"},
+			},
+		},
+		{
+			name:    "NG: no candidates",
+			msg:     &model.SlackMessage{Text: "参加する人は :sanka: をつけてね"},
+			wantErr: ErrPollNotFound,
+		},
+		{
+			name:    "NG: error in slackRepository.GetParentMessage",
+			err:     errSample,
+			wantErr: errSample,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			msr.EXPECT().GetParentMessage(gomock.Any(), "sampleCID", "sampleTs").Return(tt.msg, tt.err)
+			s := &slackPollService{
+				slackRepository: msr,
+			}
+			got, err := s.TallyPoll(context.Background(), "sampleCID", "sampleTs")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TallyPoll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TallyPoll() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_slackPollService_PickWinner(t *testing.T) {
+	first := &model.PollSlot{Number: 2, UserIDs: []string{"user01", "user02"}}
+	tests := []struct {
+		name    string
+		result  *model.PollResult
+		want    *model.PollSlot
+		wantErr error
+	}{
+		{
+			name: "OK",
+			result: &model.PollResult{Slots: []*model.PollSlot{
+				first,
+				{Number: 1, UserIDs: []string{"user01"}},
+			}},
+			want: first,
+		},
+		{
+			name: "NG: no votes",
+			result: &model.PollResult{Slots: []*model.PollSlot{
+				{Number: 1},
+			}},
+			wantErr: ErrPollNoVotes,
+		},
+		{
+			name: "NG: tied",
+			result: &model.PollResult{Slots: []*model.PollSlot{
+				first,
+				{Number: 3, UserIDs: []string{"user03", "user04"}},
+			}},
+			wantErr: ErrPollTied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &slackPollService{}
+			got, err := s.PickWinner(tt.result)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PickWinner() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PickWinner() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,10 @@ package service
 
 import (
 	"context"
+	"regexp"
+	"sync"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
 
 	"github.com/moneyforward/auriga/app/pkg/slack"
 
@@ -27,14 +31,26 @@ import (
 	"github.com/moneyforward/auriga/app/internal/model"
 )
 
+// ErrInvalidReplyPattern is returned when the regular expression of the replies ("--regex=...") is invalid
+var ErrInvalidReplyPattern = errors.New("invalid_reply_pattern")
+
 type SlackReactionUsersService interface {
 	// ListUsersEmailByReaction get the email address of the users
-	// who reacted to the parent message associated with the thread
-	ListUsersEmailByReaction(ctx context.Context, channelID, ts, reactionName string) ([]*model.SlackUserEmail, error)
+	// who reacted to the parent message associated with the thread,
+	// and who replied in the thread if filter.Replies is set
+	ListUsersEmailByReaction(ctx context.Context, channelID, ts string, filter *model.ReactionFilter) ([]*model.SlackUserEmail, error)
+	// ListUsersEmail get the email address of the users
+	ListUsersEmail(ctx context.Context, userIDs []string) ([]*model.SlackUserEmail, error)
+	// FilterUsers keeps the users matching filter
+	FilterUsers(emails []*model.SlackUserEmail, filter *model.UserFilter) []*model.SlackUserEmail
+	// InvalidateUser drops the cached user, so that the next lookup has the changes
+	InvalidateUser(ctx context.Context, userID string) error
 }
 
 type slackReactionUsersService struct {
 	slackRepository repository2.SlackRepository
+	// concurrency is the number of chunks looked up at the same time, ConcurrencyOfChunkedListUserEmail if 0
+	concurrency int
 }
 
 func NewSlackReactionUsersService(factory repository2.Factory) *slackReactionUsersService {
@@ -46,52 +62,292 @@ func NewSlackReactionUsersService(factory repository2.Factory) *slackReactionUse
 const (
 	// ChunkSizeOfChunkedListUserEmail chunk size of calling slackRepository.ListUsersEmail
 	ChunkSizeOfChunkedListUserEmail = 20
+	// ConcurrencyOfChunkedListUserEmail is the number of chunks looked up at the same time.
+	// The Slack client paces the calls within the rate limit, so it only has to be small enough not to burst.
+	ConcurrencyOfChunkedListUserEmail = 4
 )
 
 // chunkedListUsersEmail splits userID array into chunks,
 //　and calls slackRepository.ListUsersEmail for each chunk.
+// The chunks are looked up concurrently up to s.concurrency, and the first error cancels the rest.
+// The users are returned in the order of userIDs.
 func (s *slackReactionUsersService) chunkedListUsersEmail(ctx context.Context, userIDs []string) ([]*model.SlackUserEmail, error) {
 	chunkedUserIDsList := slice.SplitStringSliceInChunks(userIDs, ChunkSizeOfChunkedListUserEmail)
-	slackUserEmails := make([]*model.SlackUserEmail, 0, len(chunkedUserIDsList))
-	for _, chunkedUserIDs := range chunkedUserIDsList {
-		userEmails, err := s.slackRepository.ListUsersEmail(ctx, chunkedUserIDs)
-		if err != nil {
-			return nil, err
+	concurrency := s.concurrency
+	if concurrency <= 0 {
+		concurrency = ConcurrencyOfChunkedListUserEmail
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]*model.SlackUserEmail, len(chunkedUserIDsList))
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+	for i, chunkedUserIDs := range chunkedUserIDsList {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
 		}
+		if ctx.Err() != nil {
+			// the rest are not looked up after an error
+			break
+		}
+		wg.Add(1)
+		go func(i int, chunkedUserIDs []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			userEmails, err := s.slackRepository.ListUsersEmail(ctx, chunkedUserIDs)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = userEmails
+		}(i, chunkedUserIDs)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	slackUserEmails := make([]*model.SlackUserEmail, 0, len(userIDs))
+	for _, userEmails := range results {
 		slackUserEmails = append(slackUserEmails, userEmails...)
 	}
 	return slackUserEmails, nil
 }
 
-func (s *slackReactionUsersService) ListUsersEmailByReaction(ctx context.Context, channelID, ts, reactionName string) ([]*model.SlackUserEmail, error) {
+func (s *slackReactionUsersService) ListUsersEmailByReaction(ctx context.Context, channelID, ts string, filter *model.ReactionFilter) ([]*model.SlackUserEmail, error) {
+	var pattern *regexp.Regexp
+	if filter.Replies != nil && filter.Replies.Pattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(filter.Replies.Pattern); err != nil {
+			return nil, ErrInvalidReplyPattern
+		}
+	}
 	msg, err := s.slackRepository.GetParentMessage(ctx, channelID, ts)
 	if err != nil {
 		return nil, err
 	}
-	reactedUserIDs := s.getReactionUserIDs(ctx, msg.Reactions, reactionName)
+	var reactedUserIDs []string
+	if len(filter.Members) > 0 {
+		if reactedUserIDs, err = s.getMemberUserIDs(ctx, msg.Reactions, filter); err != nil {
+			return nil, err
+		}
+	} else if filter.SelectsReactedUsers() {
+		reactedUserIDs = s.getReactionUserIDs(ctx, msg.Reactions, filter)
+	}
+	if filter.Replies != nil {
+		repliedUserIDs, err := s.getReplyUserIDs(ctx, channelID, ts, msg.Reactions, filter, pattern)
+		if err != nil {
+			return nil, err
+		}
+		reactedUserIDs = slice.ToStringSet(append(reactedUserIDs, repliedUserIDs...))
+	}
 	reactedUserEmails, err := s.chunkedListUsersEmail(ctx, reactedUserIDs)
 	if err != nil {
 		return nil, err
 	}
+	s.setReactions(reactedUserEmails, reactedUserIDs, msg.Reactions)
 	return reactedUserEmails, nil
 }
 
-// getReactionUserIDs get reaction users by reactionName
-func (s *slackReactionUsersService) getReactionUserIDs(ctx context.Context, reactions []*model.SlackReaction, reactionName string) []string {
+// setReactions sets the reactions of each user and the position in reactedUserIDs
+func (s *slackReactionUsersService) setReactions(emails []*model.SlackUserEmail, reactedUserIDs []string, reactions []*model.SlackReaction) {
+	orders := make(map[string]int, len(reactedUserIDs))
+	for i, userID := range reactedUserIDs {
+		orders[userID] = i + 1
+	}
+	names := map[string][]string{}
+	for _, reaction := range reactions {
+		name := normalizeReactionName(reaction.Name)
+		for _, userID := range reaction.UserIDs {
+			if !containsReaction(names[userID], name) {
+				names[userID] = append(names[userID], name)
+			}
+		}
+	}
+	for _, email := range emails {
+		email.Reactions = names[email.ID]
+		email.ReactionOrder = orders[email.ID]
+	}
+}
+
+func (s *slackReactionUsersService) ListUsersEmail(ctx context.Context, userIDs []string) ([]*model.SlackUserEmail, error) {
+	return s.chunkedListUsersEmail(ctx, userIDs)
+}
+
+func (s *slackReactionUsersService) InvalidateUser(ctx context.Context, userID string) error {
+	return s.slackRepository.InvalidateUser(ctx, userID)
+}
+
+func (s *slackReactionUsersService) FilterUsers(emails []*model.SlackUserEmail, filter *model.UserFilter) []*model.SlackUserEmail {
+	if filter.IsEmpty() {
+		return emails
+	}
+	filtered := make([]*model.SlackUserEmail, 0, len(emails))
+	for _, email := range emails {
+		if filter.Match(email) {
+			filtered = append(filtered, email)
+		}
+	}
+	return filtered
+}
+
+// getReactionUserIDs get reaction users by filter.
+// The order of the users follows the order of the reactions on the message.
+func (s *slackReactionUsersService) getReactionUserIDs(ctx context.Context, reactions []*model.SlackReaction, filter *model.ReactionFilter) []string {
 	var userIDs []string
-	var targetReactions []*model.SlackReaction
-	// filtered reactions by reactionName
+	usersByReaction := s.groupUserIDsByReaction(reactions)
+	// candidates are the users who reacted with any of filter.Any,
+	// or with filter.All if filter.Any is empty, or with anything if both are empty.
+	names := filter.Any
+	if len(names) == 0 {
+		names = filter.All
+	}
 	for _, reaction := range reactions {
-		rn := slack.ExtractReactionName(reaction.Name)
-		if slack.RemoveSkinToneFromReaction(rn) == reactionName {
-			targetReactions = append(targetReactions, reaction)
+		if len(names) == 0 || containsReaction(names, reaction.Name) {
+			userIDs = append(userIDs, reaction.UserIDs...)
 		}
 	}
-	if len(targetReactions) == 0 {
+	if len(userIDs) == 0 {
 		return userIDs // no reaction members
 	}
-	for _, tr := range targetReactions {
-		userIDs = append(userIDs, tr.UserIDs...)
+	var filtered []string
+	for _, userID := range slice.ToStringSet(userIDs) {
+		if s.matchFilter(usersByReaction, userID, filter) {
+			filtered = append(filtered, userID)
+		}
+	}
+	return filtered
+}
+
+// getReplyUserIDs gets the users who replied in the thread as filter.Replies, in the order of their first replies.
+// The users who reacted with filter.Exclude are excluded as well.
+func (s *slackReactionUsersService) getReplyUserIDs(ctx context.Context, channelID, ts string, reactions []*model.SlackReaction, filter *model.ReactionFilter, pattern *regexp.Regexp) ([]string, error) {
+	replies, err := s.slackRepository.ListThreadReplies(ctx, channelID, ts)
+	if err != nil {
+		return nil, err
+	}
+	var userIDs []string
+	for _, reply := range replies {
+		if filter.Replies.Match(reply, pattern) {
+			userIDs = append(userIDs, reply.UserID)
+		}
+	}
+	usersByReaction := s.groupUserIDsByReaction(reactions)
+	exclude := &model.ReactionFilter{Exclude: filter.Exclude}
+	var filtered []string
+	for _, userID := range slice.ToStringSet(userIDs) {
+		if s.matchFilter(usersByReaction, userID, exclude) {
+			filtered = append(filtered, userID)
+		}
+	}
+	return filtered, nil
+}
+
+// getMemberUserIDs gets the members of filter.Members in the order of the sources,
+// who reacted with any of filter.Any if specified, all of filter.All and none of filter.Exclude,
+// or with nothing if filter.NoReactions
+func (s *slackReactionUsersService) getMemberUserIDs(ctx context.Context, reactions []*model.SlackReaction, filter *model.ReactionFilter) ([]string, error) {
+	var userIDs []string
+	for _, member := range filter.Members {
+		var members []string
+		var err error
+		if member.UserGroupID != "" {
+			members, err = s.slackRepository.ListUserGroupMembers(ctx, member.UserGroupID)
+		} else {
+			members, err = s.slackRepository.ListChannelMembers(ctx, member.ChannelID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, members...)
+	}
+	usersByReaction := s.groupUserIDsByReaction(reactions)
+	reacted := map[string]bool{}
+	if filter.NoReactions {
+		for _, reaction := range reactions {
+			for _, userID := range reaction.UserIDs {
+				reacted[userID] = true
+			}
+		}
+	}
+	var filtered []string
+	for _, userID := range slice.ToStringSet(userIDs) {
+		if reacted[userID] {
+			continue
+		}
+		if len(filter.Any) > 0 && !s.reactedWithAny(usersByReaction, userID, filter.Any) {
+			continue
+		}
+		if s.matchFilter(usersByReaction, userID, filter) {
+			filtered = append(filtered, userID)
+		}
+	}
+	return filtered, nil
+}
+
+// reactedWithAny returns true if the user reacted with any of names
+func (s *slackReactionUsersService) reactedWithAny(usersByReaction map[string]map[string]bool, userID string, names []string) bool {
+	for _, name := range names {
+		if usersByReaction[normalizeReactionName(name)][userID] {
+			return true
+		}
+	}
+	return false
+}
+
+// groupUserIDsByReaction returns the set of users for each reaction name (without skin-tone)
+func (s *slackReactionUsersService) groupUserIDsByReaction(reactions []*model.SlackReaction) map[string]map[string]bool {
+	usersByReaction := map[string]map[string]bool{}
+	for _, reaction := range reactions {
+		name := normalizeReactionName(reaction.Name)
+		if usersByReaction[name] == nil {
+			usersByReaction[name] = map[string]bool{}
+		}
+		for _, userID := range reaction.UserIDs {
+			usersByReaction[name][userID] = true
+		}
+	}
+	return usersByReaction
+}
+
+// matchFilter returns true if the user reacted with all of filter.All and none of filter.Exclude
+func (s *slackReactionUsersService) matchFilter(usersByReaction map[string]map[string]bool, userID string, filter *model.ReactionFilter) bool {
+	for _, name := range filter.All {
+		if !usersByReaction[name][userID] {
+			return false
+		}
+	}
+	for _, name := range filter.Exclude {
+		if usersByReaction[name][userID] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeReactionName removes colons and skin-tone from the reaction name
+func normalizeReactionName(name string) string {
+	return slack.RemoveSkinToneFromReaction(slack.ExtractReactionName(name))
+}
+
+func containsReaction(names []string, reactionName string) bool {
+	name := normalizeReactionName(reactionName)
+	for _, n := range names {
+		if n == name {
+			return true
+		}
 	}
-	return slice.ToStringSet(userIDs)
+	return false
 }
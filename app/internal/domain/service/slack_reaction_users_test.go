@@ -19,8 +19,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
@@ -31,9 +33,9 @@ import (
 
 func Test_slackReactionUsersService_ListUsersEmailByReaction(t *testing.T) {
 	type args struct {
-		channelID    string
-		ts           string
-		reactionName string
+		channelID string
+		ts        string
+		filter    *model.ReactionFilter
 	}
 	sampleMessage := &model.SlackMessage{
 		ChannelID: "sampleCID",
@@ -60,7 +62,7 @@ func Test_slackReactionUsersService_ListUsersEmailByReaction(t *testing.T) {
 		{
 			name: "OK",
 			args: args{
-				channelID: "sampleCID", ts: "sampleTs", reactionName: "reactionSample",
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{Any: []string{"reactionSample"}},
 			},
 			prepare: func(msr *mock_repository.MockSlackRepository) {
 				gomock.InOrder(
@@ -68,20 +70,178 @@ func Test_slackReactionUsersService_ListUsersEmailByReaction(t *testing.T) {
 						sampleMessage, nil),
 					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user02", "user03"}).Return(
 						[]*model.SlackUserEmail{
-							{ID: "user02", Email: "user02@example.com"},
-							{ID: "user03", Email: "user03@example.com"},
+							{ID: "user02", Email: "user02@example.com", Status: model.SlackUserStatusOK},
+							{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK},
 						}, nil),
 				)
 			},
 			want: []*model.SlackUserEmail{
-				{ID: "user02", Email: "user02@example.com"},
-				{ID: "user03", Email: "user03@example.com"},
+				{ID: "user02", Email: "user02@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"join", "reactionSample"}, ReactionOrder: 1},
+				{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"reactionSample"}, ReactionOrder: 2},
 			},
 		},
+		{
+			name: "OK: replies",
+			args: args{
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{
+					Replies: &model.ReplyFilter{Keyword: "参加", ExcludeUserIDs: []string{"user05"}},
+				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetParentMessage(gomock.Any(), "sampleCID", "sampleTs").Return(
+						sampleMessage, nil),
+					msr.EXPECT().ListThreadReplies(gomock.Any(), "sampleCID", "sampleTs").Return(
+						[]*model.SlackReply{
+							{UserID: "user04", Text: "参加します"},
+							{UserID: "user03", Text: "不参加です"},
+							{UserID: "user04", Text: "参加します！"},
+							{UserID: "user06", Text: "よろしくお願いします"},
+							{UserID: "user05", Text: "参加"},
+						}, nil),
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user04", "user03"}).Return(
+						[]*model.SlackUserEmail{
+							{ID: "user04", Email: "user04@example.com", Status: model.SlackUserStatusOK},
+							{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK},
+						}, nil),
+				)
+			},
+			want: []*model.SlackUserEmail{
+				{ID: "user04", Email: "user04@example.com", Status: model.SlackUserStatusOK, ReactionOrder: 1},
+				{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"reactionSample"}, ReactionOrder: 2},
+			},
+		},
+		{
+			name: "OK: replies combined with reactions",
+			args: args{
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{
+					Any: []string{"join"}, Exclude: []string{"reactionSample"}, Replies: &model.ReplyFilter{Pattern: "^参加"},
+				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetParentMessage(gomock.Any(), "sampleCID", "sampleTs").Return(
+						sampleMessage, nil),
+					msr.EXPECT().ListThreadReplies(gomock.Any(), "sampleCID", "sampleTs").Return(
+						[]*model.SlackReply{
+							{UserID: "user01", Text: "参加します"},
+							{UserID: "user03", Text: "参加します"},
+							{UserID: "user04", Text: "参加します"},
+							{UserID: "user05", Text: "不参加です"},
+						}, nil),
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user01", "user04"}).Return(
+						[]*model.SlackUserEmail{
+							{ID: "user01", Email: "user01@example.com", Status: model.SlackUserStatusOK},
+							{ID: "user04", Email: "user04@example.com", Status: model.SlackUserStatusOK},
+						}, nil),
+				)
+			},
+			want: []*model.SlackUserEmail{
+				{ID: "user01", Email: "user01@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"join"}, ReactionOrder: 1},
+				{ID: "user04", Email: "user04@example.com", Status: model.SlackUserStatusOK, ReactionOrder: 2},
+			},
+		},
+		{
+			name: "OK: members of a user group and a channel who did not react",
+			args: args{
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{
+					Exclude: []string{"join"},
+					Members: []*model.MemberSource{{UserGroupID: "S01", Name: "@design-team"}, {ChannelID: "C01", Name: "project-x"}},
+				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetParentMessage(gomock.Any(), "sampleCID", "sampleTs").Return(
+						sampleMessage, nil),
+					msr.EXPECT().ListUserGroupMembers(gomock.Any(), "S01").Return([]string{"user01", "user03"}, nil),
+					msr.EXPECT().ListChannelMembers(gomock.Any(), "C01").Return([]string{"user03", "user04"}, nil),
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user03", "user04"}).Return(
+						[]*model.SlackUserEmail{
+							{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK},
+							{ID: "user04", Email: "user04@example.com", Status: model.SlackUserStatusOK},
+						}, nil),
+				)
+			},
+			want: []*model.SlackUserEmail{
+				{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"reactionSample"}, ReactionOrder: 1},
+				{ID: "user04", Email: "user04@example.com", Status: model.SlackUserStatusOK, ReactionOrder: 2},
+			},
+		},
+		{
+			name: "OK: members of a user group who reacted",
+			args: args{
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{
+					Any:     []string{"join"},
+					Members: []*model.MemberSource{{UserGroupID: "S01", Name: "@design-team"}},
+				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetParentMessage(gomock.Any(), "sampleCID", "sampleTs").Return(
+						sampleMessage, nil),
+					msr.EXPECT().ListUserGroupMembers(gomock.Any(), "S01").Return([]string{"user02", "user03"}, nil),
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user02"}).Return(
+						[]*model.SlackUserEmail{
+							{ID: "user02", Email: "user02@example.com", Status: model.SlackUserStatusOK},
+						}, nil),
+				)
+			},
+			want: []*model.SlackUserEmail{
+				{ID: "user02", Email: "user02@example.com", Status: model.SlackUserStatusOK, Reactions: []string{"join", "reactionSample"}, ReactionOrder: 1},
+			},
+		},
+		{
+			name: "OK: members of a channel who have not reacted at all",
+			args: args{
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{
+					Members:     []*model.MemberSource{{ChannelID: "C01"}},
+					NoReactions: true,
+				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetParentMessage(gomock.Any(), "sampleCID", "sampleTs").Return(
+						sampleMessage, nil),
+					msr.EXPECT().ListChannelMembers(gomock.Any(), "C01").Return([]string{"user01", "user04", "user03", "user05"}, nil),
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user04", "user05"}).Return(
+						[]*model.SlackUserEmail{
+							{ID: "user04", Email: "user04@example.com", Status: model.SlackUserStatusOK},
+							{ID: "user05", Status: model.SlackUserStatusBot},
+						}, nil),
+				)
+			},
+			want: []*model.SlackUserEmail{
+				{ID: "user04", Email: "user04@example.com", Status: model.SlackUserStatusOK, ReactionOrder: 1},
+				{ID: "user05", Status: model.SlackUserStatusBot, ReactionOrder: 2},
+			},
+		},
+		{
+			name: "NG: error in ListChannelMembers",
+			args: args{
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{
+					Members: []*model.MemberSource{{ChannelID: "C01", Name: "project-x"}},
+				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetParentMessage(gomock.Any(), "sampleCID", "sampleTs").Return(
+						sampleMessage, nil),
+					msr.EXPECT().ListChannelMembers(gomock.Any(), "C01").Return(nil, errors.New("sample_error")),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: invalid regular expression of the replies",
+			args: args{
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{Replies: &model.ReplyFilter{Pattern: "(参加"}},
+			},
+			wantErr: true,
+		},
 		{
 			name: "NG: error in GetParentMessage",
 			args: args{
-				channelID: "sampleCID", ts: "sampleTs", reactionName: "reactionSample",
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{Any: []string{"reactionSample"}},
 			},
 			prepare: func(msr *mock_repository.MockSlackRepository) {
 				gomock.InOrder(
@@ -94,7 +254,7 @@ func Test_slackReactionUsersService_ListUsersEmailByReaction(t *testing.T) {
 		{
 			name: "NG: error in ListUserEmail",
 			args: args{
-				channelID: "sampleCID", ts: "sampleTs", reactionName: "reactionSample",
+				channelID: "sampleCID", ts: "sampleTs", filter: &model.ReactionFilter{Any: []string{"reactionSample"}},
 			},
 			prepare: func(msr *mock_repository.MockSlackRepository) {
 				gomock.InOrder(
@@ -118,7 +278,7 @@ func Test_slackReactionUsersService_ListUsersEmailByReaction(t *testing.T) {
 			s := &slackReactionUsersService{
 				slackRepository: msr,
 			}
-			got, err := s.ListUsersEmailByReaction(context.Background(), tt.args.channelID, tt.args.ts, tt.args.reactionName)
+			got, err := s.ListUsersEmailByReaction(context.Background(), tt.args.channelID, tt.args.ts, tt.args.filter)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ListUsersEmailByReaction() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -132,8 +292,8 @@ func Test_slackReactionUsersService_ListUsersEmailByReaction(t *testing.T) {
 
 func Test_slackReactionUsersService_getReactionUserIDs(t *testing.T) {
 	type args struct {
-		reactions    []*model.SlackReaction
-		reactionName string
+		reactions []*model.SlackReaction
+		filter    *model.ReactionFilter
 	}
 	slackReactions := []*model.SlackReaction{
 		{
@@ -144,6 +304,10 @@ func Test_slackReactionUsersService_getReactionUserIDs(t *testing.T) {
 			Name:    "reactionSample",
 			UserIDs: []string{"user02", "user03"},
 		},
+		{
+			Name:    "remote::skin-tone-2",
+			UserIDs: []string{"user03", "user04"},
+		},
 	}
 	var noUsers []string
 	tests := []struct {
@@ -154,16 +318,56 @@ func Test_slackReactionUsersService_getReactionUserIDs(t *testing.T) {
 		{
 			name: "OK",
 			args: args{
-				reactions:    slackReactions,
-				reactionName: "reactionSample",
+				reactions: slackReactions,
+				filter:    &model.ReactionFilter{Any: []string{"reactionSample"}},
 			},
 			want: []string{"user02", "user03"},
 		},
+		{
+			name: "OK: union",
+			args: args{
+				reactions: slackReactions,
+				filter:    &model.ReactionFilter{Any: []string{"join", "remote"}},
+			},
+			want: []string{"user01", "user02", "user03", "user04"},
+		},
+		{
+			name: "OK: intersection",
+			args: args{
+				reactions: slackReactions,
+				filter:    &model.ReactionFilter{Any: []string{"join"}, All: []string{"reactionSample"}},
+			},
+			want: []string{"user02"},
+		},
+		{
+			name: "OK: intersection without union",
+			args: args{
+				reactions: slackReactions,
+				filter:    &model.ReactionFilter{All: []string{"reactionSample", "remote"}},
+			},
+			want: []string{"user03"},
+		},
+		{
+			name: "OK: exclusion",
+			args: args{
+				reactions: slackReactions,
+				filter:    &model.ReactionFilter{Any: []string{"join", "reactionSample"}, Exclude: []string{"remote"}},
+			},
+			want: []string{"user01", "user02"},
+		},
+		{
+			name: "OK: exclusion from everyone who reacted",
+			args: args{
+				reactions: slackReactions,
+				filter:    &model.ReactionFilter{Exclude: []string{"join"}},
+			},
+			want: []string{"user03", "user04"},
+		},
 		{
 			name: "OK: no users to filter by reactionName",
 			args: args{
-				reactions:    slackReactions,
-				reactionName: "sample",
+				reactions: slackReactions,
+				filter:    &model.ReactionFilter{Any: []string{"sample"}},
 			},
 			want: noUsers,
 		},
@@ -176,9 +380,171 @@ func Test_slackReactionUsersService_getReactionUserIDs(t *testing.T) {
 			s := &slackReactionUsersService{
 				slackRepository: msr,
 			}
-			if got := s.getReactionUserIDs(context.Background(), tt.args.reactions, tt.args.reactionName); !reflect.DeepEqual(got, tt.want) {
+			if got := s.getReactionUserIDs(context.Background(), tt.args.reactions, tt.args.filter); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("getReactionUserIDs() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_slackReactionUsersService_FilterUsers(t *testing.T) {
+	member := &model.SlackUserEmail{ID: "user01", Email: "user01@example.com", Status: model.SlackUserStatusOK}
+	subsidiary := &model.SlackUserEmail{ID: "user02", Email: "user02@sub.example.com", Status: model.SlackUserStatusOK}
+	guest := &model.SlackUserEmail{ID: "user03", Email: "user03@example.com", Status: model.SlackUserStatusOK, IsGuest: true}
+	external := &model.SlackUserEmail{ID: "user04", Email: "user04@partner.example", Status: model.SlackUserStatusOK, IsExternal: true}
+	noEmail := &model.SlackUserEmail{ID: "user05", Status: model.SlackUserStatusNoEmail}
+	emails := []*model.SlackUserEmail{member, subsidiary, guest, external, noEmail}
+	tests := []struct {
+		name     string
+		filter   *model.UserFilter
+		defaults *model.UserFilter
+		want     []*model.SlackUserEmail
+	}{
+		{
+			name:   "OK: no filter",
+			filter: &model.UserFilter{},
+			want:   emails,
+		},
+		{
+			name:   "OK: no guests and no external users",
+			filter: &model.UserFilter{NoGuests: true, NoExternal: true},
+			want:   []*model.SlackUserEmail{member, subsidiary, noEmail},
+		},
+		{
+			name:   "OK: domains, keeping the users whose emails are unknown",
+			filter: &model.UserFilter{Domains: []string{"EXAMPLE.com", "@sub.example.com"}},
+			want:   []*model.SlackUserEmail{member, subsidiary, guest, noEmail},
+		},
+		{
+			name:   "OK: domains linked by Slack",
+			filter: &model.UserFilter{Domains: model.ParseDomains("<http://example.com|example.com>")},
+			want:   []*model.SlackUserEmail{member, guest, noEmail},
+		},
+		{
+			name:     "OK: combined with the default",
+			filter:   &model.UserFilter{Domains: []string{"example.com"}},
+			defaults: &model.UserFilter{NoGuests: true, Domains: []string{"partner.example"}},
+			want:     []*model.SlackUserEmail{member, noEmail},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &slackReactionUsersService{}
+			if got := s.FilterUsers(emails, tt.filter.With(tt.defaults)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// sampleUserIDs returns the user IDs of n users
+func sampleUserIDs(n int) []string {
+	userIDs := make([]string, n)
+	for i := range userIDs {
+		userIDs[i] = fmt.Sprintf("user%03d", i)
+	}
+	return userIDs
+}
+
+// listUsersEmailWithLatency looks up the users after latency, or fails if the context is done before
+func listUsersEmailWithLatency(latency func(userIDs []string) time.Duration) func(ctx context.Context, userIDs []string) ([]*model.SlackUserEmail, error) {
+	return func(ctx context.Context, userIDs []string) ([]*model.SlackUserEmail, error) {
+		select {
+		case <-time.After(latency(userIDs)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		emails := make([]*model.SlackUserEmail, len(userIDs))
+		for i, userID := range userIDs {
+			emails[i] = &model.SlackUserEmail{ID: userID, Email: userID + "@example.com", Status: model.SlackUserStatusOK}
+		}
+		return emails, nil
+	}
+}
+
+func Test_slackReactionUsersService_ListUsersEmail(t *testing.T) {
+	userIDs := sampleUserIDs(90)
+	tests := []struct {
+		name    string
+		prepare func(msr *mock_repository.MockSlackRepository)
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "OK: in the order of the users",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				// the later chunks are returned earlier
+				msr.EXPECT().ListUsersEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+					listUsersEmailWithLatency(func(chunk []string) time.Duration {
+						return time.Duration(len(userIDs)-indexOf(userIDs, chunk[0])) * 100 * time.Microsecond
+					})).Times(5)
+			},
+			want: userIDs,
+		},
+		{
+			name: "NG: the first error cancels the rest",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().ListUsersEmail(gomock.Any(), userIDs[:ChunkSizeOfChunkedListUserEmail]).Return(nil, errSample)
+				msr.EXPECT().ListUsersEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+					listUsersEmailWithLatency(func([]string) time.Duration { return time.Minute })).MaxTimes(3)
+			},
+			wantErr: errSample,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			tt.prepare(msr)
+			s := &slackReactionUsersService{
+				slackRepository: msr,
+			}
+			got, err := s.ListUsersEmail(context.Background(), userIDs)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ListUsersEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var gotIDs []string
+			for _, email := range got {
+				gotIDs = append(gotIDs, email.ID)
+			}
+			if !reflect.DeepEqual(gotIDs, tt.want) {
+				t.Errorf("ListUsersEmail() got = %v, want %v", gotIDs, tt.want)
+			}
+		})
+	}
+}
+
+func indexOf(userIDs []string, userID string) int {
+	for i, id := range userIDs {
+		if id == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Benchmark_slackReactionUsersService_ListUsersEmail looks up 300 users with the latency of users.info
+func Benchmark_slackReactionUsersService_ListUsersEmail(b *testing.B) {
+	userIDs := sampleUserIDs(300)
+	for _, concurrency := range []int{1, 2, ConcurrencyOfChunkedListUserEmail, 8} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			ctrl := gomock.NewController(b)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			msr.EXPECT().ListUsersEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+				listUsersEmailWithLatency(func([]string) time.Duration { return 5 * time.Millisecond })).AnyTimes()
+			s := &slackReactionUsersService{
+				slackRepository: msr,
+				concurrency:     concurrency,
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.ListUsersEmail(context.Background(), userIDs); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+// DefaultMaxReminders is the maximum number of the reminders sent at once unless the policy specifies it
+const DefaultMaxReminders = 30
+
+type SlackReminderService interface {
+	// Remind sends the direct message linking to the message of ts to each of userIDs on behalf of senderID,
+	// except the users who opted out, up to the limit of the policy.
+	// The message is written in the language of ctx, that is, of the sender.
+	Remind(ctx context.Context, channelID, ts, senderID string, userIDs []string, policy *model.ReminderPolicy) (*model.ReminderResult, error)
+}
+
+type slackReminderService struct {
+	slackRepository repository.SlackRepository
+}
+
+func NewSlackReminderService(factory repository.Factory) *slackReminderService {
+	return &slackReminderService{
+		slackRepository: factory.SlackRepository(),
+	}
+}
+
+func (s *slackReminderService) Remind(ctx context.Context, channelID, ts, senderID string, userIDs []string, policy *model.ReminderPolicy) (*model.ReminderResult, error) {
+	permalink, err := s.slackRepository.GetPermalink(ctx, channelID, ts)
+	if err != nil {
+		return nil, err
+	}
+	maxMessages := DefaultMaxReminders
+	optedOut := map[string]bool{}
+	if policy != nil {
+		if policy.MaxMessages > 0 {
+			maxMessages = policy.MaxMessages
+		}
+		for _, userID := range policy.OptOutUserIDs {
+			optedOut[userID] = true
+		}
+	}
+	msg := i18n.T(ctx, i18n.ReminderMessage, senderID, permalink)
+	result := &model.ReminderResult{}
+	for _, userID := range userIDs {
+		switch {
+		case optedOut[userID]:
+			result.OptedOutUserIDs = append(result.OptedOutUserIDs, userID)
+		case len(result.SentUserIDs)+len(result.FailedUserIDs) >= maxMessages:
+			result.OverLimitUserIDs = append(result.OverLimitUserIDs, userID)
+		default:
+			// the channel of a user ID is the direct message from Auriga
+			if err := s.slackRepository.PostMessage(ctx, userID, msg, ""); err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				log.Printf("Failed to remind %s: %v", userID, err)
+				result.FailedUserIDs = append(result.FailedUserIDs, userID)
+				continue
+			}
+			result.SentUserIDs = append(result.SentUserIDs, userID)
+		}
+	}
+	return result, nil
+}
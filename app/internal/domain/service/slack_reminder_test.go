@@ -0,0 +1,98 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+func Test_slackReminderService_Remind(t *testing.T) {
+	const permalink = "https://example.slack.com/archives/C01/p1667283600123456"
+	msg := "<@sender> さんが<" + permalink + "|このメッセージ>への回答を待っています:pray: リアクションしてください。"
+	tests := []struct {
+		name    string
+		userIDs []string
+		policy  *model.ReminderPolicy
+		prepare func(msr *mock_repository.MockSlackRepository)
+		want    *model.ReminderResult
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			userIDs: []string{"user01", "user02"},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetPermalink(gomock.Any(), "C01", "sampleTs").Return(permalink, nil),
+					msr.EXPECT().PostMessage(gomock.Any(), "user01", msg, "").Return(nil),
+					msr.EXPECT().PostMessage(gomock.Any(), "user02", msg, "").Return(nil),
+				)
+			},
+			want: &model.ReminderResult{SentUserIDs: []string{"user01", "user02"}},
+		},
+		{
+			name:    "OK: opted out and over the limit",
+			userIDs: []string{"user01", "user02", "user03", "user04"},
+			policy:  &model.ReminderPolicy{MaxMessages: 2, OptOutUserIDs: []string{"user02"}},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().GetPermalink(gomock.Any(), "C01", "sampleTs").Return(permalink, nil),
+					msr.EXPECT().PostMessage(gomock.Any(), "user01", msg, "").Return(errors.New("cannot_dm_bot")),
+					msr.EXPECT().PostMessage(gomock.Any(), "user03", msg, "").Return(nil),
+				)
+			},
+			want: &model.ReminderResult{
+				SentUserIDs:      []string{"user03"},
+				OptedOutUserIDs:  []string{"user02"},
+				OverLimitUserIDs: []string{"user04"},
+				FailedUserIDs:    []string{"user01"},
+			},
+		},
+		{
+			name:    "NG: error in GetPermalink",
+			userIDs: []string{"user01"},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().GetPermalink(gomock.Any(), "C01", "sampleTs").Return("", errors.New("message_not_found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			tt.prepare(msr)
+			s := &slackReminderService{slackRepository: msr}
+			got, err := s.Remind(context.Background(), "C01", "sampleTs", "sender", tt.userIDs, tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Remind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Remind() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,20 +18,34 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
-	"github.com/moneyforward/auriga/app/pkg/slice"
+	"github.com/moneyforward/auriga/app/pkg/datetime"
+	"github.com/moneyforward/auriga/app/pkg/errors"
 
 	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/i18n"
 	"github.com/moneyforward/auriga/app/internal/model"
-	"github.com/slack-go/slack/slackevents"
+	"github.com/moneyforward/auriga/app/internal/renderer"
 )
 
 type SlackResponseService interface {
-	ReplyEmailList(ctx context.Context, event *slackevents.AppMentionEvent, emails []*model.SlackUserEmail) error
-	ReplyError(ctx context.Context, event *slackevents.AppMentionEvent, err error) error
-	ReplyHelp(ctx context.Context, event *slackevents.AppMentionEvent) error
+	// ReplyEmailList replies the Block Kit message of the list unless the format of options is specified.
+	// The list is sent only to the user if the target is private, or replaces the list whose "Refresh" button was pushed.
+	ReplyEmailList(ctx context.Context, target *ResponseTarget, filter *model.ReactionFilter, emails []*model.SlackUserEmail, options *model.EmailListOptions) error
+	ReplyCalendarEvent(ctx context.Context, target *ResponseTarget, calendarEvent *model.CalendarEvent) error
+	// ReplyCalendarFile uploads the iCalendar file of the event to the thread, or to the user without the thread
+	ReplyCalendarFile(ctx context.Context, target *ResponseTarget, file *model.CalendarFile) error
+	// ReplyCalendarTemplate replies the links to create the event on Google Calendar, one message for each link
+	ReplyCalendarTemplate(ctx context.Context, target *ResponseTarget, template *model.CalendarTemplate) error
+	ReplyPollResult(ctx context.Context, target *ResponseTarget, result *model.PollResult) error
+	// ReplyPendingResult replies the members who have not reacted only to the user, not to notify them in the thread
+	ReplyPendingResult(ctx context.Context, target *ResponseTarget, result *model.PendingResult) error
+	ReplyError(ctx context.Context, target *ResponseTarget, err error) error
+	ReplyHelp(ctx context.Context, target *ResponseTarget) error
+
+	// NotifyAttendeeChange posts the attendees of the bound event changed by the reactions in the thread
+	NotifyAttendeeChange(ctx context.Context, binding *model.EventBinding, change *model.AttendeeChange) error
 }
 
 type slackResponseService struct {
@@ -54,17 +68,36 @@ const (
 	lineSizeOfPostEmailList = 50
 )
 
+// post sends the message to the thread of the target, or to the channel of the slash command
+func (s *slackResponseService) post(ctx context.Context, target *ResponseTarget, msg string) error {
+	if target.responseURL != "" {
+		return s.slackRepository.PostResponse(ctx, target.responseURL, msg, true)
+	}
+	return s.slackRepository.PostMessage(ctx, target.channelID, msg, target.ts)
+}
+
+// postPrivately sends the message only to the user of the target
+func (s *slackResponseService) postPrivately(ctx context.Context, target *ResponseTarget, msg string) error {
+	if target.responseURL != "" {
+		return s.slackRepository.PostResponse(ctx, target.responseURL, msg, false)
+	}
+	return s.slackRepository.PostEphemeral(ctx, target.channelID, msg, target.ts, target.userID)
+}
+
+// uploadPrivately uploads the file in the direct message to the user of the target, and tells the user about it with sent
+func (s *slackResponseService) uploadPrivately(ctx context.Context, target *ResponseTarget, filename, content, comment, sent string) error {
+	if err := s.slackRepository.UploadFile(ctx, target.userID, "", filename, content, comment); err != nil {
+		return err
+	}
+	return s.postPrivately(ctx, target, sent)
+}
+
 // postEmailList method posts emailList using slack postMessageAPI.
 // The chunkedLines are generated and requested for each chunk,
 // because of considering the limit the number of characters of slackAPI.
-func (s *slackResponseService) postEmailList(ctx context.Context, channelID string, emails []*model.SlackUserEmail, ts string) error {
-	lines := append(make([]string, 0, len(emails)+1), "参加者一覧")
-	for _, email := range emails {
-		lines = append(lines, email.Email)
-	}
-	chunkedLines := slice.SplitStringSliceInChunks(lines, lineSizeOfPostEmailList)
-	for _, chunkedLine := range chunkedLines {
-		err := s.slackRepository.PostMessage(ctx, channelID, strings.Join(chunkedLine, "\n"), ts)
+func (s *slackResponseService) postEmailList(ctx context.Context, channelID string, emails []*model.SlackUserEmail, ts string, options *model.EmailListOptions) error {
+	for _, msg := range emailListMessages(ctx, emails, options) {
+		err := s.slackRepository.PostMessage(ctx, channelID, msg, ts)
 		if err != nil {
 			return err
 		}
@@ -72,46 +105,242 @@ func (s *slackResponseService) postEmailList(ctx context.Context, channelID stri
 	return nil
 }
 
-func (s *slackResponseService) ReplyEmailList(ctx context.Context, event *slackevents.AppMentionEvent, emails []*model.SlackUserEmail) error {
-	if len(emails) <= lineSizeOfPostEmailList-1 {
-		var b strings.Builder
-		b.WriteString("参加者一覧")
-		for _, email := range emails {
-			b.WriteString("\n" + email.Email)
+func (s *slackResponseService) ReplyEmailList(ctx context.Context, target *ResponseTarget, filter *model.ReactionFilter, emails []*model.SlackUserEmail, options *model.EmailListOptions) error {
+	if target.replaceURL != "" {
+		return s.replaceEmailList(ctx, target, filter, emails)
+	}
+	emails, options = sortEmailList(emails, options)
+	fileFormat := emailListFileFormat(emails, options.Format)
+	if fileFormat != "" {
+		filename, content, err := emailListFile(emails, fileFormat)
+		if err != nil {
+			return err
+		}
+		title := i18n.T(ctx, i18n.EmailListTitleWithCount, len(emails))
+		if target.private {
+			return s.uploadPrivately(ctx, target, filename, content, title, i18n.T(ctx, i18n.EmailListFileSent))
 		}
-		return s.slackRepository.PostMessage(ctx, event.Channel, b.String(), event.ThreadTimeStamp)
+		return s.slackRepository.UploadFile(ctx, target.channelID, target.ts, filename, content, title)
+	}
+	if target.private {
+		for _, msg := range emailListMessages(ctx, emails, options) {
+			if err := s.postPrivately(ctx, target, msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if options.Format == "" {
+		list := &renderer.EmailList{
+			ChannelID: target.channelID, TimeStamp: target.ts, Reactions: filter, Emails: emails, Locale: i18n.FromContext(ctx),
+			WithNames: options.WithNames,
+		}
+		blocks, err := renderer.EmailListBlocks(list)
+		if err != nil {
+			return err
+		}
+		return s.slackRepository.PostBlocks(ctx, target.channelID, target.ts, renderer.EmailListText(list), blocks)
+	}
+	return s.postEmailList(ctx, target.channelID, emails, target.ts, options)
+}
+
+// replaceEmailList replaces the Block Kit message of the list whose "Refresh" button was pushed
+func (s *slackResponseService) replaceEmailList(ctx context.Context, target *ResponseTarget, filter *model.ReactionFilter, emails []*model.SlackUserEmail) error {
+	list := &renderer.EmailList{ChannelID: target.channelID, TimeStamp: target.ts, Reactions: filter, Emails: emails, Locale: i18n.FromContext(ctx)}
+	blocks, err := renderer.EmailListBlocks(list)
+	if err != nil {
+		return err
 	}
+	return s.slackRepository.ReplaceResponse(ctx, target.replaceURL, renderer.EmailListText(list), blocks)
+}
+
+func (s *slackResponseService) ReplyCalendarEvent(ctx context.Context, target *ResponseTarget, calendarEvent *model.CalendarEvent) error {
+	return s.post(ctx, target, calendarEventMessage(ctx, calendarEvent))
+}
+
+func calendarEventMessage(ctx context.Context, calendarEvent *model.CalendarEvent) string {
+	return i18n.T(ctx, i18n.CalendarEventCreated,
+		len(calendarEvent.AttendeeEmails),
+		calendarEvent.Start.Format("2006/01/02 15:04"),
+		calendarEvent.End.Format("15:04"),
+		calendarEvent.Title,
+		calendarEvent.URL,
+	)
+}
+
+func (s *slackResponseService) ReplyCalendarFile(ctx context.Context, target *ResponseTarget, file *model.CalendarFile) error {
+	if target.responseURL != "" {
+		return s.uploadPrivately(ctx, target, file.Name, file.Content, calendarFileMessage(ctx, file), i18n.T(ctx, i18n.CalendarFileSent))
+	}
+	return s.slackRepository.UploadFile(ctx, target.channelID, target.ts, file.Name, file.Content, calendarFileMessage(ctx, file))
+}
 
-	return s.postEmailList(ctx, event.Channel, emails, event.ThreadTimeStamp)
+func calendarFileMessage(ctx context.Context, file *model.CalendarFile) string {
+	return i18n.T(ctx, i18n.CalendarFileCreated,
+		len(file.Event.AttendeeEmails),
+		file.Event.Start.Format("2006/01/02 15:04"),
+		file.Event.End.Format("15:04"),
+		file.Event.Title,
+	)
 }
 
-func (s *slackResponseService) ReplyError(ctx context.Context, event *slackevents.AppMentionEvent, err error) error {
-	var msg string
+func (s *slackResponseService) ReplyCalendarTemplate(ctx context.Context, target *ResponseTarget, template *model.CalendarTemplate) error {
+	for _, msg := range calendarTemplateMessages(ctx, template) {
+		if err := s.post(ctx, target, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// calendarTemplateMessages returns a message for each link, since a link can be as long as half the limit of a message
+func calendarTemplateMessages(ctx context.Context, template *model.CalendarTemplate) []string {
+	header := i18n.T(ctx, i18n.CalendarTemplate,
+		len(template.Event.AttendeeEmails),
+		template.Event.Start.Format("2006/01/02 15:04"),
+		template.Event.End.Format("15:04"),
+		template.Event.Title,
+	)
+	if len(template.URLs) == 1 {
+		return []string{header + "\n" + i18n.T(ctx, i18n.CalendarTemplateLink, template.URLs[0])}
+	}
+	header += "\n" + i18n.T(ctx, i18n.CalendarTemplateSplit, len(template.URLs))
+	msgs := make([]string, 0, len(template.URLs))
+	for i, u := range template.URLs {
+		msg := i18n.T(ctx, i18n.CalendarTemplatePart, u, i+1, len(template.URLs))
+		if i == 0 {
+			msg = header + "\n" + msg
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+func (s *slackResponseService) ReplyPollResult(ctx context.Context, target *ResponseTarget, result *model.PollResult) error {
+	return s.post(ctx, target, pollResultMessage(ctx, result))
+}
+
+func pollResultMessage(ctx context.Context, result *model.PollResult) string {
+	var b strings.Builder
+	b.WriteString(i18n.T(ctx, i18n.PollResultTitle, len(result.RespondentIDs)))
+	rank := 0
+	for i, slot := range result.Slots {
+		if i == 0 || len(slot.UserIDs) != len(result.Slots[i-1].UserIDs) {
+			rank = i + 1
+		}
+		b.WriteString("\n" + i18n.T(ctx, i18n.PollResultSlot, rank, slot.Reaction, slot.Text, len(slot.UserIDs)))
+		if len(slot.UnavailableUserIDs) > 0 {
+			b.WriteString("\n" + i18n.T(ctx, i18n.PollResultUnavailable, mentions(slot.UnavailableUserIDs)))
+		}
+	}
+	return b.String()
+}
+
+func (s *slackResponseService) ReplyPendingResult(ctx context.Context, target *ResponseTarget, result *model.PendingResult) error {
+	return s.postPrivately(ctx, target, pendingResultMessage(ctx, result))
+}
+
+func pendingResultMessage(ctx context.Context, result *model.PendingResult) string {
+	sources := make([]string, 0, len(result.Members))
+	for _, member := range result.Members {
+		if member.ChannelID != "" {
+			// Slack shows the name of the channel
+			sources = append(sources, "<#"+member.ChannelID+">")
+			continue
+		}
+		sources = append(sources, member.String())
+	}
+	var b strings.Builder
+	if len(result.UserIDs) == 0 {
+		b.WriteString(i18n.T(ctx, i18n.PendingResultNone, strings.Join(sources, " ")))
+	} else {
+		b.WriteString(i18n.T(ctx, i18n.PendingResultTitle, strings.Join(sources, " "), len(result.UserIDs), mentions(result.UserIDs)))
+	}
+	if reminder := result.Reminder; reminder != nil {
+		b.WriteString("\n" + i18n.T(ctx, i18n.ReminderSent, len(reminder.SentUserIDs)))
+		if len(reminder.OptedOutUserIDs) > 0 {
+			b.WriteString("\n" + i18n.T(ctx, i18n.ReminderOptedOut, mentions(reminder.OptedOutUserIDs)))
+		}
+		if len(reminder.OverLimitUserIDs) > 0 {
+			b.WriteString("\n" + i18n.T(ctx, i18n.ReminderOverLimit, len(reminder.SentUserIDs)+len(reminder.FailedUserIDs), mentions(reminder.OverLimitUserIDs)))
+		}
+		if len(reminder.FailedUserIDs) > 0 {
+			b.WriteString("\n" + i18n.T(ctx, i18n.ReminderFailed, mentions(reminder.FailedUserIDs)))
+		}
+	}
+	return b.String()
+}
+
+// mentions formats the users as Slack mentions
+func mentions(userIDs []string) string {
+	ms := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		ms = append(ms, "<@"+userID+">")
+	}
+	return strings.Join(ms, " ")
+}
+
+// ReplyError replies the message telling the user about err, only to the user unless the others should know it
+func (s *slackResponseService) ReplyError(ctx context.Context, target *ResponseTarget, err error) error {
+	msg, ephemeral, ok := s.errorMessage(ctx, target, err)
+	if !ok {
+		return err
+	}
+	if ephemeral || target.private {
+		return s.postPrivately(ctx, target, msg)
+	}
+	return s.post(ctx, target, msg)
+}
+
+// errorMessage returns the message telling the user about err, and whether it should be shown only to the user.
+// ok is false if err is not expected.
+func (s *slackResponseService) errorMessage(ctx context.Context, target *ResponseTarget, err error) (msg string, ephemeral bool, ok bool) {
+	var parseErr *datetime.ParseError
+	if errors.As(err, &parseErr) {
+		return i18n.T(ctx, i18n.ErrorParseDatetime, parseErr.Reason), true, true
+	}
+	if errors.Is(err, ErrPollNotFound) {
+		return i18n.T(ctx, i18n.ErrorPollNotFound), true, true
+	}
+	if errors.Is(err, ErrPollNoVotes) {
+		return i18n.T(ctx, i18n.ErrorPollNoVotes), false, true
+	}
+	if errors.Is(err, ErrPollTied) {
+		return i18n.T(ctx, i18n.ErrorPollTied), false, true
+	}
+	if errors.Is(err, ErrInvalidReplyPattern) {
+		return i18n.T(ctx, i18n.ErrorInvalidReplyPattern), true, true
+	}
+	if errors.Is(err, ErrInvalidPermalink) {
+		return i18n.T(ctx, i18n.ErrorInvalidPermalink), true, true
+	}
 	if s.errorRepository.ErrThreadNotFound(err) {
-		msg += "スレッドで呼び出してね:neko_namida:"
-		return s.slackRepository.PostEphemeral(
-			ctx, event.Channel, msg, event.ThreadTimeStamp, event.User,
-		)
+		// the message to collect the users from is not found
+		return i18n.T(ctx, target.notFound), true, true
 	}
 	if s.errorRepository.ErrUserNotFound(err) {
-		msg += "参加者はいないようです:neko_namida:"
-		return s.slackRepository.PostMessage(
-			ctx, event.Channel, msg, event.ThreadTimeStamp,
-		)
-	}
-	return err
-}
-
-func (s *slackResponseService) ReplyHelp(ctx context.Context, event *slackevents.AppMentionEvent) error {
-	msg := "[使い方]\n" +
-		"1. スレッドで `@Auriga :sanka:` のようにAurigaを呼び出し、リアクションを指定してください。\n" +
-		"2. スレッドの開始メッセージに指定のリアクションをしたユーザのメールアドレス一覧を返します。\n" +
-		"3. 結果をGoogleCalenderに貼り付けると一括招待できます！"
-	return s.slackRepository.PostEphemeral(
-		ctx,
-		event.Channel,
-		fmt.Sprint(msg),
-		event.ThreadTimeStamp,
-		event.User,
-	)
+		return i18n.T(ctx, i18n.ErrorUserNotFound), false, true
+	}
+	if s.errorRepository.ErrCalendarNotConfigured(err) {
+		return i18n.T(ctx, i18n.ErrorCalendarNotConfigured), true, true
+	}
+	if s.errorRepository.ErrMembersNotFound(err) {
+		return i18n.T(ctx, i18n.ErrorMembersNotFound), true, true
+	}
+	return "", false, false
+}
+
+func (s *slackResponseService) ReplyHelp(ctx context.Context, target *ResponseTarget) error {
+	return s.postPrivately(ctx, target, i18n.T(ctx, target.help))
+}
+
+func (s *slackResponseService) NotifyAttendeeChange(ctx context.Context, binding *model.EventBinding, change *model.AttendeeChange) error {
+	lines := []string{i18n.T(ctx, i18n.AttendeesUpdated, binding.URL, binding.Title)}
+	if len(change.Added) > 0 {
+		lines = append(lines, i18n.T(ctx, i18n.AttendeesAdded, strings.Join(change.Added, ", ")))
+	}
+	if len(change.Removed) > 0 {
+		lines = append(lines, i18n.T(ctx, i18n.AttendeesRemoved, strings.Join(change.Removed, ", ")))
+	}
+	return s.slackRepository.PostMessage(ctx, binding.ChannelID, strings.Join(lines, "\n"), binding.TimeStamp)
 }
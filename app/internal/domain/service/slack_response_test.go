@@ -22,11 +22,15 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/i18n"
 
 	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/datetime"
+	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 )
 
@@ -34,7 +38,8 @@ func createEmails(suffixBase, n int) []*model.SlackUserEmail {
 	emails := make([]*model.SlackUserEmail, n)
 	for index := range emails {
 		emails[index] = &model.SlackUserEmail{
-			Email: fmt.Sprintf("user_%d@example.com", suffixBase+index),
+			Email:  fmt.Sprintf("user_%d@example.com", suffixBase+index),
+			Status: model.SlackUserStatusOK,
 		}
 	}
 	return emails
@@ -132,7 +137,7 @@ func Test_slackResponseService_postEmailList(t *testing.T) {
 				slackRepository: msr,
 				errorRepository: mer,
 			}
-			if err := s.postEmailList(ctx, tt.args.cid, tt.args.emails, tt.args.ts); (err != nil) != tt.wantErr {
+			if err := s.postEmailList(ctx, tt.args.cid, tt.args.emails, tt.args.ts, &model.EmailListOptions{Format: model.EmailListFormatLines}); (err != nil) != tt.wantErr {
 				t.Errorf("postEmailList() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -140,9 +145,12 @@ func Test_slackResponseService_postEmailList(t *testing.T) {
 }
 
 func Test_slackErrorResponseService_ReplyEmailList(t *testing.T) {
+	command := &slack.SlashCommand{ResponseURL: "https://hooks.slack.com/commands/sample", UserID: "sampleUser"}
 	type args struct {
-		event  *slackevents.AppMentionEvent
-		emails []*model.SlackUserEmail
+		target  *ResponseTarget
+		filter  *model.ReactionFilter
+		emails  []*model.SlackUserEmail
+		options *model.EmailListOptions
 	}
 	tests := []struct {
 		name    string
@@ -153,14 +161,32 @@ func Test_slackErrorResponseService_ReplyEmailList(t *testing.T) {
 		{
 			name: "OK",
 			args: args{
-				event: &slackevents.AppMentionEvent{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
 					Channel:         "sampleChannel",
 					ThreadTimeStamp: "sampleThreadTimeStamp",
+				}),
+				filter: &model.ReactionFilter{Any: []string{"sanka"}},
+				emails: []*model.SlackUserEmail{
+					{Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{Email: "sample02@example.com", Status: model.SlackUserStatusOK},
 				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostBlocks(gomock.Any(), "sampleChannel", "sampleThreadTimeStamp", "参加者一覧 (2名)", gomock.Len(3)).Return(nil)
+			},
+		},
+		{
+			name: "OK: lines",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+				}),
 				emails: []*model.SlackUserEmail{
-					{Email: "sample01@example.com"},
-					{Email: "sample02@example.com"},
+					{Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{Email: "sample02@example.com", Status: model.SlackUserStatusOK},
 				},
+				options: &model.EmailListOptions{Format: model.EmailListFormatLines},
 			},
 			prepare: func(msr *mock_repository.MockSlackRepository) {
 				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
@@ -171,14 +197,15 @@ func Test_slackErrorResponseService_ReplyEmailList(t *testing.T) {
 		{
 			name: "NG: error in slackRepository/PostMessage",
 			args: args{
-				event: &slackevents.AppMentionEvent{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
 					Channel:         "sampleChannel",
 					ThreadTimeStamp: "sampleThreadTimeStamp",
-				},
+				}),
 				emails: []*model.SlackUserEmail{
-					{Email: "sample01@example.com"},
-					{Email: "sample02@example.com"},
+					{Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{Email: "sample02@example.com", Status: model.SlackUserStatusOK},
 				},
+				options: &model.EmailListOptions{Format: model.EmailListFormatLines},
 			},
 			prepare: func(msr *mock_repository.MockSlackRepository) {
 				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
@@ -187,6 +214,210 @@ func Test_slackErrorResponseService_ReplyEmailList(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "OK: comma",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+				}),
+				emails: []*model.SlackUserEmail{
+					{Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{Email: "sample02@example.com", Status: model.SlackUserStatusOK},
+				},
+				options: &model.EmailListOptions{Format: model.EmailListFormatComma},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+					"参加者一覧\nsample01@example.com, sample02@example.com",
+					"sampleThreadTimeStamp").Return(nil)
+			},
+		},
+		{
+			name: "OK: semicolon with names sorted by email",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+				}),
+				emails: []*model.SlackUserEmail{
+					{Email: "sample02@example.com", Status: model.SlackUserStatusOK, DisplayName: "sample02"},
+					{Email: "sample01@example.com", Status: model.SlackUserStatusOK, RealName: "Sample 01"},
+				},
+				options: &model.EmailListOptions{Format: model.EmailListFormatSemicolon, Sort: model.EmailListSortEmail, WithNames: true},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+					"参加者一覧\nSample 01 <sample01@example.com>; sample02 <sample02@example.com>",
+					"sampleThreadTimeStamp").Return(nil)
+			},
+		},
+		{
+			name: "OK: lines with the users whose emails are not listed",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+				}),
+				emails: []*model.SlackUserEmail{
+					{ID: "U01", Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{ID: "U02", Status: model.SlackUserStatusNoEmail},
+					{ID: "U03", Email: "bot@example.com", Status: model.SlackUserStatusBot},
+					{ID: "U04", Status: model.SlackUserStatusNoEmail},
+				},
+				options: &model.EmailListOptions{Format: model.EmailListFormatLines},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+						"参加者一覧\nsample01@example.com",
+						"sampleThreadTimeStamp").Return(nil),
+					msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+						"メールアドレスが分からないユーザー: <@U02> <@U04>\nボット: <@U03>",
+						"sampleThreadTimeStamp").Return(nil),
+				)
+			},
+		},
+		{
+			name: "OK: csv",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+				}),
+				emails: []*model.SlackUserEmail{
+					{ID: "U01", Email: "sample01@example.com", Status: model.SlackUserStatusOK, DisplayName: "sample01", RealName: "Sample 01", Reactions: []string{"sanka"}, ReactionOrder: 1},
+				},
+				options: &model.EmailListOptions{Format: model.EmailListFormatCSV},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().UploadFile(gomock.Any(), "sampleChannel", "sampleThreadTimeStamp", "attendees.csv",
+					"user_id,display_name,real_name,email,reactions,reaction_order,status\nU01,sample01,Sample 01,sample01@example.com,sanka,1,ok\n",
+					"参加者一覧 (1名)").Return(nil)
+			},
+		},
+		{
+			name: "OK: csv when the list is long",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+				}),
+				emails: createEmails(0, emailListFileThreshold+1),
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().UploadFile(gomock.Any(), "sampleChannel", "sampleThreadTimeStamp", "attendees.csv",
+					gomock.Any(), fmt.Sprintf("参加者一覧 (%d名)", emailListFileThreshold+1)).Return(nil)
+			},
+		},
+		{
+			name: "OK: only to the user of the slash command",
+			args: args{
+				target: NewSlashCommandTarget(command),
+				emails: []*model.SlackUserEmail{
+					{ID: "sample01", Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{ID: "sample02", Email: "sample02@example.com", Status: model.SlackUserStatusOK},
+				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostResponse(gomock.Any(), "https://hooks.slack.com/commands/sample",
+					"参加者一覧\nsample01@example.com\nsample02@example.com", false).Return(nil)
+			},
+		},
+		{
+			name: "NG: error in slackRepository.PostResponse",
+			args: args{
+				target: NewSlashCommandTarget(command),
+				emails: []*model.SlackUserEmail{{ID: "sample01", Email: "sample01@example.com", Status: model.SlackUserStatusOK}},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostResponse(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(errors.New("sample error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK: tsv is sent in the direct message to the user of the slash command",
+			args: args{
+				target:  NewSlashCommandTarget(command),
+				emails:  []*model.SlackUserEmail{{ID: "sample01", Email: "sample01@example.com", Status: model.SlackUserStatusOK}},
+				options: &model.EmailListOptions{Format: model.EmailListFormatTSV},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().UploadFile(gomock.Any(), "sampleUser", "", "attendees.tsv",
+						"user_id\tdisplay_name\treal_name\temail\treactions\treaction_order\tstatus\nsample01\t\t\tsample01@example.com\t\t\tok\n",
+						"参加者一覧 (1名)").Return(nil),
+					msr.EXPECT().PostResponse(gomock.Any(), "https://hooks.slack.com/commands/sample",
+						"参加者一覧のファイルをDMで送りました:envelope_with_arrow:", false).Return(nil),
+				)
+			},
+		},
+		{
+			name: "NG: error in slackRepository.UploadFile",
+			args: args{
+				target:  NewSlashCommandTarget(command),
+				emails:  []*model.SlackUserEmail{{ID: "sample01", Email: "sample01@example.com", Status: model.SlackUserStatusOK}},
+				options: &model.EmailListOptions{Format: model.EmailListFormatCSV},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().UploadFile(gomock.Any(), "sampleUser", "", "attendees.csv", gomock.Any(), gomock.Any()).Return(errors.New("sample error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK: comma only to the user of the shortcut",
+			args: args{
+				target: NewMessageTarget("sampleChannel", "sampleTs", "sampleUser"),
+				emails: []*model.SlackUserEmail{
+					{ID: "sample01", Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{ID: "sample02", Email: "sample02@example.com", Status: model.SlackUserStatusOK},
+				},
+				options: &model.EmailListOptions{Format: model.EmailListFormatComma},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel", "参加者一覧\nsample01@example.com, sample02@example.com", "sampleTs", "sampleUser").Return(nil)
+			},
+		},
+		{
+			name: "OK: csv is sent in the direct message to the user of the shortcut",
+			args: args{
+				target: NewMessageTarget("sampleChannel", "sampleTs", "sampleUser"),
+				emails: []*model.SlackUserEmail{
+					{ID: "sample01", Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{ID: "sample02", Email: "sample02@example.com", Status: model.SlackUserStatusOK},
+				},
+				options: &model.EmailListOptions{Format: model.EmailListFormatCSV},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().UploadFile(gomock.Any(), "sampleUser", "", "attendees.csv", gomock.Any(), "参加者一覧 (2名)").Return(nil),
+					msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel", "参加者一覧のファイルをDMで送りました:envelope_with_arrow:", "sampleTs", "sampleUser").Return(nil),
+				)
+			},
+		},
+		{
+			name: "OK: replaces the list whose Refresh button was pushed",
+			args: args{
+				target: NewRefreshTarget("https://hooks.slack.com/actions/sample", "sampleChannel", "sampleTs", "sampleUser"),
+				filter: &model.ReactionFilter{Any: []string{"sanka"}},
+				emails: []*model.SlackUserEmail{{ID: "sample01", Email: "sample01@example.com", Status: model.SlackUserStatusOK}},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().ReplaceResponse(gomock.Any(), "https://hooks.slack.com/actions/sample", "参加者一覧 (1名)", gomock.Len(3)).Return(nil)
+			},
+		},
+		{
+			name: "NG: error in slackRepository.ReplaceResponse",
+			args: args{
+				target: NewRefreshTarget("https://hooks.slack.com/actions/sample", "sampleChannel", "sampleTs", "sampleUser"),
+				filter: &model.ReactionFilter{Any: []string{"sanka"}},
+				emails: []*model.SlackUserEmail{{ID: "sample01", Email: "sample01@example.com", Status: model.SlackUserStatusOK}},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().ReplaceResponse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("sample error"))
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -201,20 +432,350 @@ func Test_slackErrorResponseService_ReplyEmailList(t *testing.T) {
 				slackRepository: msr,
 				errorRepository: mer,
 			}
-			if err := s.ReplyEmailList(context.Background(), tt.args.event, tt.args.emails); (err != nil) != tt.wantErr {
+			if err := s.ReplyEmailList(context.Background(), tt.args.target, tt.args.filter, tt.args.emails, tt.args.options); (err != nil) != tt.wantErr {
 				t.Errorf("ReplyEmailList() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func Test_slackResponseService_ReplyCalendarEvent(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	target := NewMentionTarget(&slackevents.AppMentionEvent{
+		Channel:         "sampleChannel",
+		ThreadTimeStamp: "sampleThreadTimeStamp",
+	})
+	calendarEvent := &model.CalendarEvent{
+		Title:          "Sprint review",
+		Start:          time.Date(2026, 11, 2, 15, 0, 0, 0, jst),
+		End:            time.Date(2026, 11, 2, 16, 0, 0, 0, jst),
+		AttendeeEmails: []string{"sample01@example.com", "sample02@example.com"},
+		URL:            "https://calendar.google.com/event?eid=sample",
+	}
+	tests := []struct {
+		name    string
+		prepare func(msr *mock_repository.MockSlackRepository)
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+					"予定を作成して2名を招待しました:spiral_calendar_pad:\n"+
+						"2026/11/02 15:00 - 16:00 Sprint review\n"+
+						"https://calendar.google.com/event?eid=sample",
+					"sampleThreadTimeStamp").Return(nil)
+			},
+		},
+		{
+			name: "NG: error in slackRepository.PostMessage",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel", gomock.Any(), "sampleThreadTimeStamp").
+					Return(errors.New("sample error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(msr)
+			}
+			s := &slackResponseService{
+				slackRepository: msr,
+				errorRepository: mock_repository.NewMockErrorRepository(ctrl),
+			}
+			if err := s.ReplyCalendarEvent(context.Background(), target, calendarEvent); (err != nil) != tt.wantErr {
+				t.Errorf("ReplyCalendarEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_slackResponseService_ReplyCalendarFile(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	target := NewMentionTarget(&slackevents.AppMentionEvent{
+		Channel:         "sampleChannel",
+		ThreadTimeStamp: "sampleThreadTimeStamp",
+	})
+	file := &model.CalendarFile{
+		Event: &model.CalendarEvent{
+			Title:          "Sprint review",
+			Start:          time.Date(2026, 11, 2, 15, 0, 0, 0, jst),
+			End:            time.Date(2026, 11, 2, 16, 0, 0, 0, jst),
+			AttendeeEmails: []string{"sample01@example.com", "sample02@example.com"},
+		},
+		Name:    "invite.ics",
+		Content: "BEGIN:VCALENDAR\r\n",
+	}
+	tests := []struct {
+		name    string
+		prepare func(msr *mock_repository.MockSlackRepository)
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().UploadFile(gomock.Any(), "sampleChannel", "sampleThreadTimeStamp", "invite.ics", "BEGIN:VCALENDAR\r\n",
+					"2名を招待する予定のファイルを作成しました:spiral_calendar_pad: OutlookやAppleカレンダーで開いてください\n"+
+						"2026/11/02 15:00 - 16:00 Sprint review").Return(nil)
+			},
+		},
+		{
+			name: "NG: error in slackRepository.UploadFile",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().UploadFile(gomock.Any(), "sampleChannel", "sampleThreadTimeStamp", "invite.ics", gomock.Any(), gomock.Any()).
+					Return(errors.New("sample error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(msr)
+			}
+			s := &slackResponseService{
+				slackRepository: msr,
+				errorRepository: mock_repository.NewMockErrorRepository(ctrl),
+			}
+			if err := s.ReplyCalendarFile(context.Background(), target, file); (err != nil) != tt.wantErr {
+				t.Errorf("ReplyCalendarFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_slackResponseService_ReplyCalendarTemplate(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	target := NewMentionTarget(&slackevents.AppMentionEvent{
+		Channel:         "sampleChannel",
+		ThreadTimeStamp: "sampleThreadTimeStamp",
+	})
+	calendarEvent := &model.CalendarEvent{
+		Title:          "Sprint review",
+		Start:          time.Date(2026, 11, 2, 15, 0, 0, 0, jst),
+		End:            time.Date(2026, 11, 2, 16, 0, 0, 0, jst),
+		AttendeeEmails: []string{"sample01@example.com", "sample02@example.com"},
+	}
+	tests := []struct {
+		name     string
+		template *model.CalendarTemplate
+		prepare  func(msr *mock_repository.MockSlackRepository)
+		wantErr  bool
+	}{
+		{
+			name:     "OK",
+			template: &model.CalendarTemplate{Event: calendarEvent, URLs: []string{"https://calendar.google.com/1"}},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+					"リンクからGoogleカレンダーに予定を作成してください。2名をゲストに入力してあります:spiral_calendar_pad:\n"+
+						"2026/11/02 15:00 - 16:00 Sprint review\n"+
+						"<https://calendar.google.com/1|Googleカレンダーで予定を作成>",
+					"sampleThreadTimeStamp").Return(nil)
+			},
+		},
+		{
+			name:     "OK: split into links",
+			template: &model.CalendarTemplate{Event: calendarEvent, URLs: []string{"https://calendar.google.com/1", "https://calendar.google.com/2"}},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+						"リンクからGoogleカレンダーに予定を作成してください。2名をゲストに入力してあります:spiral_calendar_pad:\n"+
+							"2026/11/02 15:00 - 16:00 Sprint review\n"+
+							"参加者が多いため、リンクを2個に分けました。2つ目以降のリンクのゲストは、1つ目のリンクで作成した予定に追加してください。\n"+
+							"<https://calendar.google.com/1|Googleカレンダーで予定を作成 (1/2)>",
+						"sampleThreadTimeStamp").Return(nil),
+					msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+						"<https://calendar.google.com/2|Googleカレンダーで予定を作成 (2/2)>",
+						"sampleThreadTimeStamp").Return(nil),
+				)
+			},
+		},
+		{
+			name:     "NG: error in slackRepository.PostMessage",
+			template: &model.CalendarTemplate{Event: calendarEvent, URLs: []string{"https://calendar.google.com/1", "https://calendar.google.com/2"}},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel", gomock.Any(), "sampleThreadTimeStamp").
+					Return(errors.New("sample error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(msr)
+			}
+			s := &slackResponseService{
+				slackRepository: msr,
+				errorRepository: mock_repository.NewMockErrorRepository(ctrl),
+			}
+			if err := s.ReplyCalendarTemplate(context.Background(), target, tt.template); (err != nil) != tt.wantErr {
+				t.Errorf("ReplyCalendarTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_slackResponseService_ReplyPollResult(t *testing.T) {
+	target := NewMentionTarget(&slackevents.AppMentionEvent{
+		Channel:         "sampleChannel",
+		ThreadTimeStamp: "sampleThreadTimeStamp",
+	})
+	result := &model.PollResult{
+		Slots: []*model.PollSlot{
+			{Number: 2, Reaction: "two", Text: "11/6 15:00-16:00", UserIDs: []string{"user01", "user02"}},
+			{Number: 1, Reaction: "one", Text: "11/5 14:00-15:00", UserIDs: []string{"user01"}, UnavailableUserIDs: []string{"user02"}},
+			{Number: 3, Reaction: "three", Text: "11/7 10:00-11:00", UserIDs: []string{"user02"}, UnavailableUserIDs: []string{"user01"}},
+		},
+		RespondentIDs: []string{"user01", "user02"},
+	}
+	tests := []struct {
+		name    string
+		prepare func(msr *mock_repository.MockSlackRepository)
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+					"日程調整の結果 (回答者 2名)\n"+
+						"1位 :two: 11/6 15:00-16:00 (2名)\n"+
+						"2位 :one: 11/5 14:00-15:00 (1名)\n"+
+						"　　参加できない人: <@user02>\n"+
+						"2位 :three: 11/7 10:00-11:00 (1名)\n"+
+						"　　参加できない人: <@user01>",
+					"sampleThreadTimeStamp").Return(nil)
+			},
+		},
+		{
+			name: "NG: error in slackRepository.PostMessage",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel", gomock.Any(), "sampleThreadTimeStamp").
+					Return(errors.New("sample error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(msr)
+			}
+			s := &slackResponseService{
+				slackRepository: msr,
+				errorRepository: mock_repository.NewMockErrorRepository(ctrl),
+			}
+			if err := s.ReplyPollResult(context.Background(), target, result); (err != nil) != tt.wantErr {
+				t.Errorf("ReplyPollResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_slackResponseService_ReplyPendingResult(t *testing.T) {
+	target := NewMentionTarget(&slackevents.AppMentionEvent{
+		Channel:         "sampleChannel",
+		ThreadTimeStamp: "sampleThreadTimeStamp",
+		User:            "sampleUser",
+	})
+	tests := []struct {
+		name    string
+		result  *model.PendingResult
+		prepare func(msr *mock_repository.MockSlackRepository)
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			result: &model.PendingResult{
+				Members: []*model.MemberSource{{ChannelID: "C01"}, {UserGroupID: "S01", Name: "@design-team"}},
+				UserIDs: []string{"user01", "user02"},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel",
+					"<#C01> @design-team でまだリアクションしていない人 (2名):\n<@user01> <@user02>",
+					"sampleThreadTimeStamp", "sampleUser").Return(nil)
+			},
+		},
+		{
+			name: "OK: with reminders",
+			result: &model.PendingResult{
+				Members: []*model.MemberSource{{ChannelID: "C01"}},
+				UserIDs: []string{"user01", "user02", "user03", "user04"},
+				Reminder: &model.ReminderResult{
+					SentUserIDs:      []string{"user01"},
+					OptedOutUserIDs:  []string{"user02"},
+					OverLimitUserIDs: []string{"user04"},
+					FailedUserIDs:    []string{"user03"},
+				},
+			},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel",
+					"<#C01> でまだリアクションしていない人 (4名):\n<@user01> <@user02> <@user03> <@user04>\n"+
+						"1名にリマインドを送りました。\n"+
+						"リマインドを受け取らない設定の人には送っていません: <@user02>\n"+
+						"一度に送れるリマインドは2名までのため、次の人には送っていません: <@user04>\n"+
+						"次の人にはリマインドを送れませんでした: <@user03>",
+					"sampleThreadTimeStamp", "sampleUser").Return(nil)
+			},
+		},
+		{
+			name:   "OK: everyone has reacted",
+			result: &model.PendingResult{Members: []*model.MemberSource{{ChannelID: "C01"}}},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel", "<#C01> の全員がリアクションしました:tada:",
+					"sampleThreadTimeStamp", "sampleUser").Return(nil)
+			},
+		},
+		{
+			name:   "NG: error in slackRepository.PostEphemeral",
+			result: &model.PendingResult{Members: []*model.MemberSource{{ChannelID: "C01"}}},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel", gomock.Any(), "sampleThreadTimeStamp", "sampleUser").
+					Return(errors.New("sample error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			tt.prepare(msr)
+			s := &slackResponseService{
+				slackRepository: msr,
+				errorRepository: mock_repository.NewMockErrorRepository(ctrl),
+			}
+			if err := s.ReplyPendingResult(context.Background(), target, tt.result); (err != nil) != tt.wantErr {
+				t.Errorf("ReplyPendingResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_slackErrorResponseService_ReplyError(t *testing.T) {
+	command := &slack.SlashCommand{ResponseURL: "https://hooks.slack.com/commands/sample", UserID: "sampleUser"}
 	type args struct {
-		event *slackevents.AppMentionEvent
-		err   error
+		target *ResponseTarget
+		err    error
 	}
 	tests := []struct {
 		name    string
+		locale  i18n.Locale
 		args    args
 		prepare func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository)
 		wantErr bool
@@ -222,11 +783,11 @@ func Test_slackErrorResponseService_ReplyError(t *testing.T) {
 		{
 			name: "OK: err is ErrThreadNotFound",
 			args: args{
-				event: &slackevents.AppMentionEvent{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
 					Channel:         "sampleChannel",
 					ThreadTimeStamp: "sampleThreadTimeStamp",
 					User:            "sampleUser",
-				},
+				}),
 				err: errors.New("thread_not_found"), //errors.New("user_not_found")
 			},
 			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
@@ -241,11 +802,11 @@ func Test_slackErrorResponseService_ReplyError(t *testing.T) {
 		{
 			name: "OK: err is ErrUserNotFound",
 			args: args{
-				event: &slackevents.AppMentionEvent{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
 					Channel:         "sampleChannel",
 					ThreadTimeStamp: "sampleThreadTimeStamp",
 					User:            "sampleUser",
-				},
+				}),
 				err: errors.New("user_not_found"),
 			},
 			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
@@ -258,14 +819,84 @@ func Test_slackErrorResponseService_ReplyError(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "OK: err is datetime.ParseError",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+					User:            "sampleUser",
+				}),
+				err: &datetime.ParseError{Reason: "time is missing for 明日", Err: datetime.ErrAmbiguous},
+			},
+			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel",
+					"日時を読み取れませんでした:neko_namida: (time is missing for 明日)\n"+
+						"`@Auriga :sanka: 明日15時から1時間 タイトル` のように指定してね",
+					"sampleThreadTimeStamp", "sampleUser").Return(nil)
+			},
+		},
+		{
+			name: "OK: err is ErrPollNotFound",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+					User:            "sampleUser",
+				}),
+				err: ErrPollNotFound,
+			},
+			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel",
+					"候補が見つかりませんでした:neko_namida: 親メッセージに `:one: 11/5 14:00-15:00` のように番号付きで候補を書いてね",
+					"sampleThreadTimeStamp", "sampleUser").Return(nil)
+			},
+		},
+		{
+			name: "OK: err is ErrPollTied",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+					User:            "sampleUser",
+				}),
+				err: ErrPollTied,
+			},
+			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+					"同票の候補があるため予約できませんでした:neko_namida:",
+					"sampleThreadTimeStamp").Return(nil)
+			},
+		},
+		{
+			name: "OK: err is ErrCalendarNotConfigured",
+			args: args{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
+					Channel:         "sampleChannel",
+					ThreadTimeStamp: "sampleThreadTimeStamp",
+					User:            "sampleUser",
+				}),
+				err: errors.New("calendar_not_configured"),
+			},
+			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					mer.EXPECT().ErrThreadNotFound(errors.New("calendar_not_configured")).Return(false),
+					mer.EXPECT().ErrUserNotFound(errors.New("calendar_not_configured")).Return(false),
+					mer.EXPECT().ErrCalendarNotConfigured(errors.New("calendar_not_configured")).Return(true),
+					msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel",
+						"Googleカレンダー連携が設定されていません:neko_namida:",
+						"sampleThreadTimeStamp", "sampleUser").Return(nil),
+				)
+			},
+		},
 		{
 			name: "NG: err is ErrThreadNotFound (error in slackRepository.PostEphemeral)",
 			args: args{
-				event: &slackevents.AppMentionEvent{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
 					Channel:         "sampleChannel",
 					ThreadTimeStamp: "sampleThreadTimeStamp",
 					User:            "sampleUser",
-				},
+				}),
 				err: errors.New("thread_not_found"),
 			},
 			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
@@ -281,11 +912,11 @@ func Test_slackErrorResponseService_ReplyError(t *testing.T) {
 		{
 			name: "NG: err is ErrUserNotFound (error in slackRepository.PostMessage)",
 			args: args{
-				event: &slackevents.AppMentionEvent{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
 					Channel:         "sampleChannel",
 					ThreadTimeStamp: "sampleThreadTimeStamp",
 					User:            "sampleUser",
-				},
+				}),
 				err: errors.New("user_not_found"),
 			},
 			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
@@ -302,21 +933,75 @@ func Test_slackErrorResponseService_ReplyError(t *testing.T) {
 		{
 			name: "NG: undefined error",
 			args: args{
-				event: &slackevents.AppMentionEvent{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
 					Channel:         "sampleChannel",
 					ThreadTimeStamp: "sampleThreadTimeStamp",
 					User:            "sampleUser",
-				},
+				}),
 				err: errors.New("undefined error"),
 			},
 			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
 				gomock.InOrder(
 					mer.EXPECT().ErrThreadNotFound(errors.New("undefined error")).Return(false),
 					mer.EXPECT().ErrUserNotFound(errors.New("undefined error")).Return(false),
+					mer.EXPECT().ErrCalendarNotConfigured(errors.New("undefined error")).Return(false),
+					mer.EXPECT().ErrMembersNotFound(errors.New("undefined error")).Return(false),
 				)
 			},
 			wantErr: true,
 		},
+		{
+			name: "OK: the message of the link is not found",
+			args: args{
+				target: NewSlashCommandTarget(command),
+				err:    errors.New("thread_not_found"),
+			},
+			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					mer.EXPECT().ErrThreadNotFound(errors.New("thread_not_found")).Return(true),
+					msr.EXPECT().PostResponse(gomock.Any(), "https://hooks.slack.com/commands/sample",
+						"メッセージが見つかりませんでした:neko_namida: Aurigaが参加しているチャンネルのメッセージのリンクを指定してね", false).Return(nil),
+				)
+			},
+		},
+		{
+			name: "OK: err is ErrInvalidPermalink",
+			args: args{
+				target: NewSlashCommandTarget(command),
+				err:    ErrInvalidPermalink,
+			},
+			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostResponse(gomock.Any(), "https://hooks.slack.com/commands/sample",
+					"メッセージのリンクを指定してね:neko_namida: (`/auriga <メッセージのリンク> :sanka:`)", false).Return(nil)
+			},
+		},
+		{
+			name:   "OK: err is ErrInvalidPermalink in English",
+			locale: i18n.English,
+			args: args{
+				target: NewSlashCommandTarget(command),
+				err:    ErrInvalidPermalink,
+			},
+			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostResponse(gomock.Any(), "https://hooks.slack.com/commands/sample",
+					"Give the link of a message :neko_namida: (`/auriga <link of the message> :sanka:`)", false).Return(nil)
+			},
+		},
+		{
+			name: "OK: only to the user of the shortcut",
+			args: args{
+				target: NewMessageTarget("sampleChannel", "sampleTs", "sampleUser"),
+				err:    errors.New("user_not_found"),
+			},
+			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					mer.EXPECT().ErrThreadNotFound(errors.New("user_not_found")).Return(false),
+					mer.EXPECT().ErrUserNotFound(errors.New("user_not_found")).Return(true),
+					msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel",
+						"参加者はいないようです:neko_namida:", "sampleTs", "sampleUser").Return(nil),
+				)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -331,7 +1016,11 @@ func Test_slackErrorResponseService_ReplyError(t *testing.T) {
 				slackRepository: msr,
 				errorRepository: mer,
 			}
-			if err := s.ReplyError(context.Background(), tt.args.event, tt.args.err); (err != nil) != tt.wantErr {
+			ctx := context.Background()
+			if tt.locale != "" {
+				ctx = i18n.WithLocale(ctx, tt.locale)
+			}
+			if err := s.ReplyError(ctx, tt.args.target, tt.args.err); (err != nil) != tt.wantErr {
 				t.Errorf("ReplyError() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -340,7 +1029,7 @@ func Test_slackErrorResponseService_ReplyError(t *testing.T) {
 
 func Test_slackErrorResponseService_ReplyHelp(t *testing.T) {
 	type args struct {
-		event *slackevents.AppMentionEvent
+		target *ResponseTarget
 	}
 	tests := []struct {
 		name    string
@@ -351,11 +1040,11 @@ func Test_slackErrorResponseService_ReplyHelp(t *testing.T) {
 		{
 			name: "OK",
 			args: args{
-				event: &slackevents.AppMentionEvent{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
 					Channel:         "sampleChannel",
 					ThreadTimeStamp: "sampleThreadTimeStamp",
 					User:            "sampleUser",
-				},
+				}),
 			},
 			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
 				msr.EXPECT().PostEphemeral(
@@ -363,18 +1052,27 @@ func Test_slackErrorResponseService_ReplyHelp(t *testing.T) {
 					"[使い方]\n"+
 						"1. スレッドで `@Auriga :sanka:` のようにAurigaを呼び出し、リアクションを指定してください。\n"+
 						"2. スレッドの開始メッセージに指定のリアクションをしたユーザのメールアドレス一覧を返します。\n"+
-						"3. 結果をGoogleCalenderに貼り付けると一括招待できます！",
+						"3. 結果をGoogleCalenderに貼り付けると一括招待できます！\n"+
+						"`@Auriga :sanka: :maybe: +:onsite: -:absent:` のように、リアクションを並べるといずれか、`+` を付けると両方、`-` を付けると除外になります。\n"+
+						"`@Auriga :sanka: 明日15時から1時間 タイトル` のように日時とタイトルを続けると、Googleカレンダーに予定を作成して招待します。`--ics` を付けるとOutlookやAppleカレンダー向けの予定のファイルを、`--link` を付けるかGoogleカレンダー連携が未設定のときは予定を作成するリンクを返します。\n"+
+						"`@Auriga poll` で親メッセージの :one: :two: などの候補を集計します。`--book` を付けると最多の候補で予定を作成します。\n"+
+						"`@Auriga replies` でスレッドに返信した人を集めます。`replies :sanka:` でリアクションと組み合わせ、`--keyword=参加` や `--regex=...` で返信を絞り込み、`--exclude-me` で自分を除きます。\n"+
+						"`@Auriga @design-team` や `#project-x` でユーザーグループやチャンネルのメンバーを集め、`#project-x -:sanka:` でリアクションしていない人を集めます。\n"+
+						"`@Auriga pending` でチャンネル (や `pending @design-team`) でまだ何もリアクションしていない人をあなただけに表示し、`--remind` でDMでリマインドします。\n"+
+						"`--csv` を付けると、名前やリアクション付きの一覧をCSVファイルで返します。人数が多いときは自動でファイルになります。\n"+
+						"`--format=semicolon` (`lines`、`comma`、`mention`、`markdown-table`) で形式を、`--sort=name` (`email`、`reaction-time`) で並び順を変えます。`--with-names` を付けると `名前 <メールアドレス>` の形になります。\n"+
+						"`--no-guests` でゲストを、`--no-external` でSlackコネクトの社外のユーザーを除きます。`--domain=example.com` でメールアドレスのドメインを絞り込みます。",
 					"sampleThreadTimeStamp", "sampleUser").Return(nil)
 			},
 		},
 		{
 			name: "NG: error in slackRepository.PostEphemeral",
 			args: args{
-				event: &slackevents.AppMentionEvent{
+				target: NewMentionTarget(&slackevents.AppMentionEvent{
 					Channel:         "sampleChannel",
 					ThreadTimeStamp: "sampleThreadTimeStamp",
 					User:            "sampleUser",
-				},
+				}),
 			},
 			prepare: func(mer *mock_repository.MockErrorRepository, msr *mock_repository.MockSlackRepository) {
 				msr.EXPECT().PostEphemeral(
@@ -382,7 +1080,16 @@ func Test_slackErrorResponseService_ReplyHelp(t *testing.T) {
 					"[使い方]\n"+
 						"1. スレッドで `@Auriga :sanka:` のようにAurigaを呼び出し、リアクションを指定してください。\n"+
 						"2. スレッドの開始メッセージに指定のリアクションをしたユーザのメールアドレス一覧を返します。\n"+
-						"3. 結果をGoogleCalenderに貼り付けると一括招待できます！",
+						"3. 結果をGoogleCalenderに貼り付けると一括招待できます！\n"+
+						"`@Auriga :sanka: :maybe: +:onsite: -:absent:` のように、リアクションを並べるといずれか、`+` を付けると両方、`-` を付けると除外になります。\n"+
+						"`@Auriga :sanka: 明日15時から1時間 タイトル` のように日時とタイトルを続けると、Googleカレンダーに予定を作成して招待します。`--ics` を付けるとOutlookやAppleカレンダー向けの予定のファイルを、`--link` を付けるかGoogleカレンダー連携が未設定のときは予定を作成するリンクを返します。\n"+
+						"`@Auriga poll` で親メッセージの :one: :two: などの候補を集計します。`--book` を付けると最多の候補で予定を作成します。\n"+
+						"`@Auriga replies` でスレッドに返信した人を集めます。`replies :sanka:` でリアクションと組み合わせ、`--keyword=参加` や `--regex=...` で返信を絞り込み、`--exclude-me` で自分を除きます。\n"+
+						"`@Auriga @design-team` や `#project-x` でユーザーグループやチャンネルのメンバーを集め、`#project-x -:sanka:` でリアクションしていない人を集めます。\n"+
+						"`@Auriga pending` でチャンネル (や `pending @design-team`) でまだ何もリアクションしていない人をあなただけに表示し、`--remind` でDMでリマインドします。\n"+
+						"`--csv` を付けると、名前やリアクション付きの一覧をCSVファイルで返します。人数が多いときは自動でファイルになります。\n"+
+						"`--format=semicolon` (`lines`、`comma`、`mention`、`markdown-table`) で形式を、`--sort=name` (`email`、`reaction-time`) で並び順を変えます。`--with-names` を付けると `名前 <メールアドレス>` の形になります。\n"+
+						"`--no-guests` でゲストを、`--no-external` でSlackコネクトの社外のユーザーを除きます。`--domain=example.com` でメールアドレスのドメインを絞り込みます。",
 					"sampleThreadTimeStamp", "sampleUser").Return(errors.New("sample error"))
 			},
 			wantErr: true,
@@ -401,9 +1108,59 @@ func Test_slackErrorResponseService_ReplyHelp(t *testing.T) {
 				slackRepository: msr,
 				errorRepository: mer,
 			}
-			if err := s.ReplyHelp(context.Background(), tt.args.event); (err != nil) != tt.wantErr {
+			if err := s.ReplyHelp(context.Background(), tt.args.target); (err != nil) != tt.wantErr {
 				t.Errorf("ReplyHelp() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func Test_slackResponseService_NotifyAttendeeChange(t *testing.T) {
+	binding := &model.EventBinding{
+		ChannelID: "sampleChannel",
+		TimeStamp: "sampleTs",
+		Title:     "Sprint review",
+		URL:       "https://calendar.google.com/event?eid=event01",
+	}
+	tests := []struct {
+		name    string
+		change  *model.AttendeeChange
+		prepare func(msr *mock_repository.MockSlackRepository)
+		wantErr bool
+	}{
+		{
+			name:   "OK",
+			change: &model.AttendeeChange{Added: []string{"user01@example.com", "user02@example.com"}, Removed: []string{"user03@example.com"}},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel",
+					"リアクションに合わせて<https://calendar.google.com/event?eid=event01|Sprint review>の参加者を更新しました:spiral_calendar_pad:\n"+
+						"招待: user01@example.com, user02@example.com\n"+
+						"招待を取り消し: user03@example.com",
+					"sampleTs").Return(nil)
+			},
+		},
+		{
+			name:   "NG: error in slackRepository.PostMessage",
+			change: &model.AttendeeChange{Removed: []string{"user03@example.com"}},
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				msr.EXPECT().PostMessage(gomock.Any(), "sampleChannel", gomock.Any(), "sampleTs").Return(errors.New("sample error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			tt.prepare(msr)
+			s := &slackResponseService{
+				slackRepository: msr,
+				errorRepository: mock_repository.NewMockErrorRepository(ctrl),
+			}
+			if err := s.NotifyAttendeeChange(context.Background(), binding, tt.change); (err != nil) != tt.wantErr {
+				t.Errorf("NotifyAttendeeChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"strings"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/errors"
+	"github.com/moneyforward/auriga/app/pkg/slack"
+)
+
+// ErrInvalidPermalink is returned when the slash command does not start with a message permalink
+var ErrInvalidPermalink = errors.New("invalid_permalink")
+
+type SlashCommandService interface {
+	Parse(text string) (*model.SlashCommandParseResult, error)
+}
+
+type slashCommandService struct {
+	slackMentionedService SlackMentionedService
+}
+
+func NewSlashCommandService() *slashCommandService {
+	return &slashCommandService{
+		slackMentionedService: NewSlackMentionedService(),
+	}
+}
+
+// Parse parses the text of the slash command like "<permalink> :sanka: :maybe: text".
+// The words following the permalink are read in the same way as the mention,
+// whose first word (the bot) is skipped just as the permalink is.
+func (s *slashCommandService) Parse(text string) (*model.SlashCommandParseResult, error) {
+	words := strings.Fields(text)
+	if len(words) == 0 || words[0] == CommandHelp {
+		return &model.SlashCommandParseResult{
+			Arguments: &model.MentionParseResult{
+				Message: text,
+				Command: CommandHelp,
+			},
+		}, nil
+	}
+	channelID, ts, err := slack.ParsePermalink(words[0])
+	if err != nil {
+		return nil, ErrInvalidPermalink
+	}
+	return &model.SlashCommandParseResult{
+		ChannelID: channelID,
+		TimeStamp: ts,
+		Arguments: s.slackMentionedService.Parse(text),
+	}, nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+func Test_slashCommandService_Parse(t *testing.T) {
+	const permalink = "https://example.slack.com/archives/C0123456789/p1667283600123456"
+	tests := []struct {
+		name    string
+		text    string
+		want    *model.SlashCommandParseResult
+		wantErr error
+	}{
+		{
+			name: "OK",
+			text: permalink + " :sanka: -:absent: 明日15時 定例",
+			want: &model.SlashCommandParseResult{
+				ChannelID: "C0123456789",
+				TimeStamp: "1667283600.123456",
+				Arguments: &model.MentionParseResult{
+					Message: permalink + " :sanka: -:absent: 明日15時 定例",
+					Reactions: &model.ReactionFilter{
+						Any:     []string{"sanka"},
+						Exclude: []string{"absent"},
+					},
+					Text: "明日15時 定例",
+				},
+			},
+		},
+		{
+			name: "OK: poll",
+			text: "<" + permalink + "> poll --book",
+			want: &model.SlashCommandParseResult{
+				ChannelID: "C0123456789",
+				TimeStamp: "1667283600.123456",
+				Arguments: &model.MentionParseResult{
+					Message: "<" + permalink + "> poll --book",
+					Command: CommandPoll,
+					Flags:   map[string]string{"book": ""},
+				},
+			},
+		},
+		{
+			name: "OK: help",
+			text: "help",
+			want: &model.SlashCommandParseResult{
+				Arguments: &model.MentionParseResult{
+					Message: "help",
+					Command: CommandHelp,
+				},
+			},
+		},
+		{
+			name: "OK: no arguments",
+			text: "",
+			want: &model.SlashCommandParseResult{
+				Arguments: &model.MentionParseResult{
+					Command: CommandHelp,
+				},
+			},
+		},
+		{
+			name:    "NG: no permalink",
+			text:    ":sanka:",
+			wantErr: ErrInvalidPermalink,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSlashCommandService()
+			got, err := s.Parse(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
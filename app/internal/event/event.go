@@ -19,29 +19,48 @@ package event
 import (
 	"context"
 
-	"github.com/moneyforward/auriga/app/pkg/slack"
+	pkgslack "github.com/moneyforward/auriga/app/pkg/slack"
+	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 )
 
 type eventHandlerFactory struct {
 	appUserID      string
-	handlerFactory slack.HandlerFactory
+	handlerFactory pkgslack.HandlerFactory
 }
 
-func NewEventHandlerFactory(appUserID string, handlerFactory slack.HandlerFactory) *eventHandlerFactory {
+func NewEventHandlerFactory(appUserID string, handlerFactory pkgslack.HandlerFactory) *eventHandlerFactory {
 	return &eventHandlerFactory{
 		appUserID:      appUserID,
 		handlerFactory: handlerFactory,
 	}
 }
 
-func (f *eventHandlerFactory) GetFunc() slack.EventHandlerFunc {
+func (f *eventHandlerFactory) GetFunc() pkgslack.EventHandlerFunc {
 	return func(ctx context.Context, event slackevents.EventsAPIInnerEvent) {
 		switch innerEv := event.Data.(type) {
 		case *slackevents.AppMentionEvent:
 			if innerEv.User != f.appUserID {
 				f.handlerFactory.MentionEventHandler()(context.Background(), innerEv)
 			}
+		case *slack.UserChangeEvent:
+			f.handlerFactory.UserChangeEventHandler()(context.Background(), innerEv)
+		case *slackevents.ReactionAddedEvent:
+			f.handlerFactory.ReactionEventHandler()(context.Background(), innerEv.User, innerEv.Reaction, innerEv.Item)
+		case *slackevents.ReactionRemovedEvent:
+			f.handlerFactory.ReactionEventHandler()(context.Background(), innerEv.User, innerEv.Reaction, innerEv.Item)
 		}
 	}
 }
+
+func (f *eventHandlerFactory) GetSlashCommandFunc() pkgslack.SlashCommandHandlerFunc {
+	return func(ctx context.Context, command slack.SlashCommand) {
+		f.handlerFactory.SlashCommandHandler()(context.Background(), &command)
+	}
+}
+
+func (f *eventHandlerFactory) GetInteractionFunc() pkgslack.InteractionHandlerFunc {
+	return func(ctx context.Context, callback slack.InteractionCallback) {
+		f.handlerFactory.InteractionHandler()(context.Background(), &callback)
+	}
+}
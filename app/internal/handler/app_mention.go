@@ -18,11 +18,12 @@ package handler
 
 import (
 	"context"
-	"log"
+	"time"
 
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
 	"github.com/moneyforward/auriga/app/internal/domain/service"
-
-	"github.com/moneyforward/auriga/app/internal/repository"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
 	"github.com/moneyforward/auriga/app/pkg/slack"
 	"github.com/slack-go/slack/slackevents"
 )
@@ -32,45 +33,31 @@ type AppMentionHandler interface {
 }
 
 type appMentionHandler struct {
-	slackReactionUsersService service.SlackReactionUsersService
-	slackResponseService      service.SlackResponseService
-	slackMentionedService     service.SlackMentionedService
+	slackResponseService  service.SlackResponseService
+	slackMentionedService service.SlackMentionedService
+	localeService         service.LocaleService
+	collector             *collector
 }
 
-func NewAppMentionHandler(client slack.Client) *appMentionHandler {
-	factory := repository.NewFactory(client)
+func NewAppMentionHandler(factory repository.Factory, location *time.Location, defaultLocale i18n.Locale, userFilter *model.UserFilter, reminderPolicy *model.ReminderPolicy) *appMentionHandler {
 	return &appMentionHandler{
-		slackReactionUsersService: service.NewSlackReactionUsersService(factory),
-		slackResponseService:      service.NewSlackResponseService(factory),
-		slackMentionedService:     service.NewSlackMentionedService(),
+		slackResponseService:  service.NewSlackResponseService(factory),
+		slackMentionedService: service.NewSlackMentionedService(),
+		localeService:         service.NewLocaleService(factory, defaultLocale),
+		collector:             newCollector(factory, location, userFilter, reminderPolicy),
 	}
 }
 
 func (h *appMentionHandler) GetFunc() slack.MentionEventHandler {
 	return func(ctx context.Context, event *slackevents.AppMentionEvent) {
+		ctx = h.localeService.WithUserLocale(ctx, event.User)
 		reaction := h.slackMentionedService.Parse(event.Text)
-		if reaction.Command == service.CommandHelp {
-			if err := h.slackResponseService.ReplyHelp(ctx, event); err != nil {
-				log.Printf("Failed to reply help: %v", err)
-			}
-			return
-		}
-		if reaction.Reaction == "" {
-			if err := h.slackResponseService.ReplyHelp(ctx, event); err != nil {
-				log.Printf("Failed to reply help: %v", err)
-			}
-			return
-		}
-		emails, err := h.slackReactionUsersService.ListUsersEmailByReaction(ctx, event.Channel, event.ThreadTimeStamp, reaction.Reaction)
-		if err != nil {
-			if err = h.slackResponseService.ReplyError(ctx, event, err); err != nil {
-				log.Printf("Failed to reply error: %v", err)
-			}
-			return
-		}
-		err = h.slackResponseService.ReplyEmailList(ctx, event, emails)
-		if err != nil {
-			log.Printf("Failed to reply: %v", err)
+		r := &responder{
+			slackResponseService: h.slackResponseService,
+			target:               service.NewMentionTarget(event),
+			reactions:            reaction.Reactions,
+			options:              reaction.EmailListOptions(),
 		}
+		h.collector.collect(ctx, r, event.Channel, event.ThreadTimeStamp, event.User, reaction)
 	}
 }
@@ -0,0 +1,231 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/domain/service"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/datetime"
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+// collector lists the users who reacted to a message, or creates the event for them,
+// and sends the result through a responder. It is shared by the mention, the slash command and the shortcut.
+type collector struct {
+	slackReactionUsersService service.SlackReactionUsersService
+	googleCalenderService     service.GoogleCalenderService
+	calendarFileService       service.CalendarFileService
+	parseDatetimeService      service.ParseDatetimeService
+	slackPollService          service.SlackPollService
+	slackReminderService      service.SlackReminderService
+	errorRepository           repository.ErrorRepository
+	// userFilter is applied to the users unless the arguments override it
+	userFilter *model.UserFilter
+	// reminderPolicy limits the reminders of "pending --remind"
+	reminderPolicy *model.ReminderPolicy
+}
+
+func newCollector(factory repository.Factory, location *time.Location, userFilter *model.UserFilter, reminderPolicy *model.ReminderPolicy) *collector {
+	return &collector{
+		slackReactionUsersService: service.NewSlackReactionUsersService(factory),
+		googleCalenderService:     service.NewGoogleCalenderService(factory),
+		calendarFileService:       service.NewCalendarFileService(factory),
+		parseDatetimeService:      service.NewParseDatetimeService(factory, location),
+		slackPollService:          service.NewSlackPollService(factory),
+		slackReminderService:      service.NewSlackReminderService(factory),
+		errorRepository:           factory.ErrorRepository(),
+		userFilter:                userFilter,
+		reminderPolicy:            reminderPolicy,
+	}
+}
+
+// collect handles the arguments for the message identified by channelID and ts, called by userID
+func (c *collector) collect(ctx context.Context, r *responder, channelID, ts, userID string, parsed *model.MentionParseResult) {
+	if parsed.Command == service.CommandHelp || (parsed.Command == "" && parsed.Reactions.IsEmpty()) {
+		if err := r.help(ctx); err != nil {
+			log.Printf("Failed to reply help: %v", err)
+		}
+		return
+	}
+	if parsed.Command == service.CommandPoll {
+		c.poll(ctx, r, channelID, ts, userID, parsed)
+		return
+	}
+	if parsed.Command == service.CommandPending {
+		c.pending(ctx, r, channelID, ts, userID, parsed)
+		return
+	}
+	var schedule *model.Schedule
+	if parsed.Text != "" {
+		var err error
+		// the text without date and time is just a note, for which the email list is replied as before
+		if schedule, err = c.parseDatetimeService.Parse(ctx, parsed.Text, userID); err != nil && !errors.Is(err, datetime.ErrNotFound) {
+			replyError(ctx, r, err)
+			return
+		}
+	}
+	if parsed.Reactions.Replies != nil && parsed.HasFlag("exclude-me") {
+		parsed.Reactions.Replies.ExcludeUserIDs = append(parsed.Reactions.Replies.ExcludeUserIDs, userID)
+	}
+	emails, err := c.slackReactionUsersService.ListUsersEmailByReaction(ctx, channelID, ts, parsed.Reactions)
+	if err != nil {
+		replyError(ctx, r, err)
+		return
+	}
+	userFilter := parsed.UserFilter().With(c.userFilter)
+	emails = c.slackReactionUsersService.FilterUsers(emails, userFilter)
+	if schedule != nil {
+		c.scheduleEvent(ctx, r, channelID, ts, userID, parsed, schedule, emails, parsed.Reactions, userFilter)
+		return
+	}
+	if err = r.emailList(ctx, emails); err != nil {
+		log.Printf("Failed to reply: %v", err)
+	}
+}
+
+// poll replies the ranking of the candidate slots, and books the winner with "--book"
+func (c *collector) poll(ctx context.Context, r *responder, channelID, ts, userID string, parsed *model.MentionParseResult) {
+	result, err := c.slackPollService.TallyPoll(ctx, channelID, ts)
+	if err != nil {
+		replyError(ctx, r, err)
+		return
+	}
+	if err = r.pollResult(ctx, result); err != nil {
+		log.Printf("Failed to reply: %v", err)
+		return
+	}
+	if !parsed.HasFlag("book") {
+		return
+	}
+	winner, err := c.slackPollService.PickWinner(result)
+	if err != nil {
+		replyError(ctx, r, err)
+		return
+	}
+	schedule, err := c.parseDatetimeService.Parse(ctx, winner.Text, userID)
+	if err != nil {
+		replyError(ctx, r, err)
+		return
+	}
+	if parsed.Text != "" {
+		schedule.Title = parsed.Text
+	}
+	emails, err := c.slackReactionUsersService.ListUsersEmail(ctx, winner.UserIDs)
+	if err != nil {
+		replyError(ctx, r, err)
+		return
+	}
+	userFilter := parsed.UserFilter().With(c.userFilter)
+	emails = c.slackReactionUsersService.FilterUsers(emails, userFilter)
+	c.scheduleEvent(ctx, r, channelID, ts, userID, parsed, schedule, emails, &model.ReactionFilter{Any: []string{winner.Reaction}}, userFilter)
+}
+
+// pending replies the members of the channel, or of the given user groups and channels, who have not reacted,
+// excluding the caller, the bots and the deactivated users, and reminds them with "--remind"
+func (c *collector) pending(ctx context.Context, r *responder, channelID, ts, userID string, parsed *model.MentionParseResult) {
+	filter := parsed.Reactions
+	if len(filter.Members) == 0 {
+		filter.Members = []*model.MemberSource{{ChannelID: channelID}}
+	}
+	emails, err := c.slackReactionUsersService.ListUsersEmailByReaction(ctx, channelID, ts, filter)
+	if err != nil {
+		replyError(ctx, r, err)
+		return
+	}
+	emails = c.slackReactionUsersService.FilterUsers(emails, parsed.UserFilter().With(c.userFilter))
+	result := &model.PendingResult{Members: filter.Members}
+	for _, email := range emails {
+		if email.ID == userID || email.Status == model.SlackUserStatusBot || email.Status == model.SlackUserStatusDeleted {
+			continue
+		}
+		result.UserIDs = append(result.UserIDs, email.ID)
+	}
+	if parsed.HasFlag("remind") && len(result.UserIDs) > 0 {
+		if result.Reminder, err = c.slackReminderService.Remind(ctx, channelID, ts, userID, result.UserIDs, c.reminderPolicy); err != nil {
+			replyError(ctx, r, err)
+			return
+		}
+	}
+	if err = r.pendingResult(ctx, result); err != nil {
+		log.Printf("Failed to reply: %v", err)
+	}
+}
+
+// scheduleEvent creates the event on Google Calendar, the iCalendar file of it with "--ics",
+// or the links to create it on Google Calendar by the user with "--link"
+func (c *collector) scheduleEvent(ctx context.Context, r *responder, channelID, ts, userID string, parsed *model.MentionParseResult, schedule *model.Schedule, emails []*model.SlackUserEmail, filter *model.ReactionFilter, userFilter *model.UserFilter) {
+	if parsed.HasFlag("link") {
+		c.createTemplate(ctx, r, channelID, ts, schedule, emails)
+		return
+	}
+	if !parsed.HasFlag("ics") {
+		c.createEvent(ctx, r, channelID, ts, schedule, emails, filter, userFilter)
+		return
+	}
+	file, err := c.calendarFileService.CreateCalendarFile(ctx, channelID, ts, userID, schedule, emails)
+	if err != nil {
+		replyError(ctx, r, err)
+		return
+	}
+	if err = r.calendarFile(ctx, file); err != nil {
+		log.Printf("Failed to reply: %v", err)
+	}
+}
+
+// createEvent creates the event for the users selected by filter and userFilter,
+// and binds it to the message so that the attendees follow the reactions changed later
+func (c *collector) createEvent(ctx context.Context, r *responder, channelID, ts string, schedule *model.Schedule, emails []*model.SlackUserEmail, filter *model.ReactionFilter, userFilter *model.UserFilter) {
+	calendarEvent, err := c.googleCalenderService.CreateEvent(ctx, channelID, ts, schedule, emails)
+	if c.errorRepository.ErrCalendarNotConfigured(err) {
+		// without the credentials, the user creates the event from the links instead
+		c.createTemplate(ctx, r, channelID, ts, schedule, emails)
+		return
+	}
+	if err != nil {
+		replyError(ctx, r, err)
+		return
+	}
+	if err = c.googleCalenderService.BindEvent(ctx, channelID, ts, calendarEvent, filter, userFilter); err != nil {
+		log.Printf("Failed to bind the event %s: %v", calendarEvent.ID, err)
+	}
+	if err = r.calendarEvent(ctx, calendarEvent); err != nil {
+		log.Printf("Failed to reply: %v", err)
+	}
+}
+
+// createTemplate replies the links to create the event on Google Calendar, prefilled with the schedule and the users
+func (c *collector) createTemplate(ctx context.Context, r *responder, channelID, ts string, schedule *model.Schedule, emails []*model.SlackUserEmail) {
+	template, err := c.googleCalenderService.CreateTemplate(ctx, channelID, ts, schedule, emails)
+	if err != nil {
+		replyError(ctx, r, err)
+		return
+	}
+	if err = r.calendarTemplate(ctx, template); err != nil {
+		log.Printf("Failed to reply: %v", err)
+	}
+}
+
+func replyError(ctx context.Context, r *responder, err error) {
+	if err = r.replyError(ctx, err); err != nil {
+		log.Printf("Failed to reply error: %v", err)
+	}
+}
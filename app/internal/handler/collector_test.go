@@ -0,0 +1,133 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/domain/service"
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+// fakeFactory returns the slack and error repositories, and nil for the others
+type fakeFactory struct {
+	repository.Factory
+	slackRepository repository.SlackRepository
+	errorRepository repository.ErrorRepository
+}
+
+func (f *fakeFactory) SlackRepository() repository.SlackRepository {
+	return f.slackRepository
+}
+
+func (f *fakeFactory) ErrorRepository() repository.ErrorRepository {
+	return f.errorRepository
+}
+
+// fakeReactionUsersService returns the emails for any reactions
+type fakeReactionUsersService struct {
+	service.SlackReactionUsersService
+	emails []*model.SlackUserEmail
+}
+
+func (s *fakeReactionUsersService) ListUsersEmailByReaction(ctx context.Context, channelID, ts string, filter *model.ReactionFilter) ([]*model.SlackUserEmail, error) {
+	return s.emails, nil
+}
+
+func (s *fakeReactionUsersService) FilterUsers(emails []*model.SlackUserEmail, filter *model.UserFilter) []*model.SlackUserEmail {
+	return emails
+}
+
+// fakeResponseService records the email list and the error replied
+type fakeResponseService struct {
+	service.SlackResponseService
+	emails []*model.SlackUserEmail
+	err    error
+}
+
+func (s *fakeResponseService) ReplyEmailList(ctx context.Context, target *service.ResponseTarget, filter *model.ReactionFilter, emails []*model.SlackUserEmail, options *model.EmailListOptions) error {
+	s.emails = emails
+	return nil
+}
+
+func (s *fakeResponseService) ReplyError(ctx context.Context, target *service.ResponseTarget, err error) error {
+	s.err = err
+	return nil
+}
+
+func Test_collector_collect(t *testing.T) {
+	emails := []*model.SlackUserEmail{
+		{ID: "user01", Email: "user01@example.com", Status: model.SlackUserStatusOK},
+	}
+	tests := []struct {
+		name       string
+		text       string
+		wantEmails []*model.SlackUserEmail
+		wantErr    bool
+	}{
+		{
+			name:       "OK: without text",
+			wantEmails: emails,
+		},
+		{
+			name:       "OK: text without date and time still lists the emails",
+			text:       "みなさん確認お願いします",
+			wantEmails: emails,
+		},
+		{
+			name:       "OK: unknown flags left in the text",
+			text:       "--draft版",
+			wantEmails: emails,
+		},
+		{
+			name:    "NG: date without time",
+			text:    "明日 Sprint review",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			msr.EXPECT().GetUserTimeZone(gomock.Any(), "user01").Return("Asia/Tokyo", nil).AnyTimes()
+			c := &collector{
+				slackReactionUsersService: &fakeReactionUsersService{emails: emails},
+				parseDatetimeService:      service.NewParseDatetimeService(&fakeFactory{slackRepository: msr}, time.UTC),
+			}
+			rs := &fakeResponseService{}
+			r := &responder{slackResponseService: rs, target: service.NewMessageTarget("sampleCID", "sampleTs", "user01")}
+			c.collect(context.Background(), r, "sampleCID", "sampleTs", "user01", &model.MentionParseResult{
+				Reactions: &model.ReactionFilter{Any: []string{"join"}},
+				Text:      tt.text,
+			})
+			if (rs.err != nil) != tt.wantErr {
+				t.Errorf("collect() replied error = %v, wantErr %v", rs.err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(rs.emails, tt.wantEmails) {
+				t.Errorf("collect() replied emails = %v, want %v", rs.emails, tt.wantEmails)
+			}
+		})
+	}
+}
@@ -17,19 +17,53 @@
 package handler
 
 import (
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
 	"github.com/moneyforward/auriga/app/pkg/slack"
 )
 
 type handlerFactory struct {
-	slackClient slack.Client
+	repositoryFactory repository.Factory
+	location          *time.Location
+	defaultLocale     i18n.Locale
+	userFilter        *model.UserFilter
+	reminderPolicy    *model.ReminderPolicy
 }
 
-func NewHandlerFactory(client slack.Client) *handlerFactory {
+// NewHandlerFactory builds a handler factory.
+// The repositories built by repositoryFactory are shared by the handlers, e.g. to share the cache of the users.
+// defaultLocale is used for the users whose locale is unknown or not supported.
+// userFilter is applied to the lists unless the arguments override it.
+// reminderPolicy limits the reminders sent to the members who have not reacted.
+func NewHandlerFactory(repositoryFactory repository.Factory, location *time.Location, defaultLocale i18n.Locale, userFilter *model.UserFilter, reminderPolicy *model.ReminderPolicy) *handlerFactory {
 	return &handlerFactory{
-		slackClient: client,
+		repositoryFactory: repositoryFactory,
+		location:          location,
+		defaultLocale:     defaultLocale,
+		userFilter:        userFilter,
+		reminderPolicy:    reminderPolicy,
 	}
 }
 
 func (f *handlerFactory) MentionEventHandler() slack.MentionEventHandler {
-	return NewAppMentionHandler(f.slackClient).GetFunc()
+	return NewAppMentionHandler(f.repositoryFactory, f.location, f.defaultLocale, f.userFilter, f.reminderPolicy).GetFunc()
+}
+
+func (f *handlerFactory) SlashCommandHandler() slack.SlashCommandHandler {
+	return NewSlashCommandHandler(f.repositoryFactory, f.location, f.defaultLocale, f.userFilter, f.reminderPolicy).GetFunc()
+}
+
+func (f *handlerFactory) InteractionHandler() slack.InteractionHandler {
+	return NewInteractionHandler(f.repositoryFactory, f.location, f.defaultLocale, f.userFilter, f.reminderPolicy).GetFunc()
+}
+
+func (f *handlerFactory) ReactionEventHandler() slack.ReactionEventHandler {
+	return NewReactionHandler(f.repositoryFactory, f.defaultLocale).GetFunc()
+}
+
+func (f *handlerFactory) UserChangeEventHandler() slack.UserChangeEventHandler {
+	return NewUserChangeHandler(f.repositoryFactory).GetFunc()
 }
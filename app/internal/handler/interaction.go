@@ -0,0 +1,152 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/domain/service"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/internal/renderer"
+	pkgslack "github.com/moneyforward/auriga/app/pkg/slack"
+	"github.com/slack-go/slack"
+)
+
+type InteractionHandler interface {
+	GetFunc() pkgslack.InteractionHandler
+}
+
+type interactionHandler struct {
+	slackResponseService service.SlackResponseService
+	slackModalService    service.SlackModalService
+	localeService        service.LocaleService
+	collector            *collector
+}
+
+func NewInteractionHandler(factory repository.Factory, location *time.Location, defaultLocale i18n.Locale, userFilter *model.UserFilter, reminderPolicy *model.ReminderPolicy) *interactionHandler {
+	return &interactionHandler{
+		slackResponseService: service.NewSlackResponseService(factory),
+		slackModalService:    service.NewSlackModalService(factory),
+		localeService:        service.NewLocaleService(factory, defaultLocale),
+		collector:            newCollector(factory, location, userFilter, reminderPolicy),
+	}
+}
+
+// GetFunc returns the handler of the "Collect attendees" message shortcut and its modal,
+// and the buttons of the email list
+func (h *interactionHandler) GetFunc() pkgslack.InteractionHandler {
+	return func(ctx context.Context, callback *slack.InteractionCallback) {
+		ctx = h.localeService.WithUserLocale(ctx, callback.User.ID)
+		switch callback.Type {
+		case slack.InteractionTypeMessageAction:
+			if callback.CallbackID == service.CallbackIDCollectAttendees {
+				h.openModal(ctx, callback)
+			}
+		case slack.InteractionTypeBlockActions:
+			if callback.View.CallbackID == service.CallbackIDCollectAttendees && hasAction(callback, service.ActionIDRefreshReactions) {
+				if err := h.slackModalService.RefreshCollectModal(ctx, &callback.View); err != nil {
+					log.Printf("Failed to refresh modal: %v", err)
+				}
+			}
+			for _, action := range callback.ActionCallback.BlockActions {
+				switch action.ActionID {
+				case renderer.ActionIDCreateEvent, renderer.ActionIDExportCSV, renderer.ActionIDRefresh:
+					h.emailListAction(ctx, callback, action)
+				}
+			}
+		case slack.InteractionTypeViewSubmission:
+			if callback.View.CallbackID == service.CallbackIDCollectAttendees {
+				h.submitModal(ctx, callback)
+			}
+		}
+	}
+}
+
+func (h *interactionHandler) openModal(ctx context.Context, callback *slack.InteractionCallback) {
+	ts := callback.Message.Timestamp
+	if ts == "" {
+		ts = callback.MessageTs
+	}
+	if err := h.slackModalService.OpenCollectModal(ctx, callback.TriggerID, callback.Channel.ID, ts); err != nil {
+		r := &responder{
+			slackResponseService: h.slackResponseService,
+			target:               service.NewMessageTarget(callback.Channel.ID, ts, callback.User.ID),
+		}
+		replyError(ctx, r, err)
+	}
+}
+
+func (h *interactionHandler) submitModal(ctx context.Context, callback *slack.InteractionCallback) {
+	input, err := h.slackModalService.ReadCollectModal(&callback.View)
+	if err != nil {
+		log.Printf("Failed to read modal: %v", err)
+		return
+	}
+	r := &responder{
+		slackResponseService: h.slackResponseService,
+		target:               service.NewMessageTarget(input.ChannelID, input.TimeStamp, callback.User.ID),
+		options:              &model.EmailListOptions{Format: input.Format},
+	}
+	parsed := &model.MentionParseResult{
+		Reactions: &model.ReactionFilter{Any: input.Reactions},
+	}
+	if input.Datetime != "" {
+		parsed.Text = strings.TrimSpace(input.Datetime + " " + input.Title)
+	}
+	h.collector.collect(ctx, r, input.ChannelID, input.TimeStamp, callback.User.ID, parsed)
+}
+
+// emailListAction handles the buttons of the email list
+func (h *interactionHandler) emailListAction(ctx context.Context, callback *slack.InteractionCallback, action *slack.BlockAction) {
+	value, err := renderer.ParseActionValue(action.Value)
+	if err != nil {
+		log.Printf("Failed to read action: %v", err)
+		return
+	}
+	r := &responder{
+		slackResponseService: h.slackResponseService,
+		target:               service.NewMessageTarget(value.ChannelID, value.TimeStamp, callback.User.ID),
+	}
+	parsed := &model.MentionParseResult{Reactions: value.Reactions}
+	switch action.ActionID {
+	case renderer.ActionIDCreateEvent:
+		if err := h.slackModalService.OpenCollectModal(ctx, callback.TriggerID, value.ChannelID, value.TimeStamp); err != nil {
+			replyError(ctx, r, err)
+		}
+	case renderer.ActionIDExportCSV:
+		r.options = &model.EmailListOptions{Format: model.EmailListFormatCSV}
+		h.collector.collect(ctx, r, value.ChannelID, value.TimeStamp, callback.User.ID, parsed)
+	case renderer.ActionIDRefresh:
+		r.target = service.NewRefreshTarget(callback.ResponseURL, value.ChannelID, value.TimeStamp, callback.User.ID)
+		r.reactions = value.Reactions
+		h.collector.collect(ctx, r, value.ChannelID, value.TimeStamp, callback.User.ID, parsed)
+	}
+}
+
+func hasAction(callback *slack.InteractionCallback, actionID string) bool {
+	for _, action := range callback.ActionCallback.BlockActions {
+		if action.ActionID == actionID {
+			return true
+		}
+	}
+	return false
+}
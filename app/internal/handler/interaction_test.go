@@ -0,0 +1,88 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/slack-go/slack"
+
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/domain/service"
+	"github.com/moneyforward/auriga/app/internal/renderer"
+)
+
+// fakeModalService fails to open the modal
+type fakeModalService struct {
+	service.SlackModalService
+	err error
+}
+
+func (s *fakeModalService) OpenCollectModal(ctx context.Context, triggerID, channelID, ts string) error {
+	return s.err
+}
+
+func Test_interactionHandler_openModalError(t *testing.T) {
+	tests := []struct {
+		name     string
+		callback *slack.InteractionCallback
+		action   *slack.BlockAction
+	}{
+		{
+			name: "OK: the message shortcut",
+			callback: &slack.InteractionCallback{
+				Channel: slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{ID: "sampleChannel"}}},
+				Message: slack.Message{Msg: slack.Msg{Timestamp: "sampleTs"}},
+				User:    slack.User{ID: "sampleUser"},
+			},
+		},
+		{
+			name:     "OK: the button of the email list",
+			callback: &slack.InteractionCallback{User: slack.User{ID: "sampleUser"}},
+			action: &slack.BlockAction{
+				ActionID: renderer.ActionIDCreateEvent,
+				Value:    `{"channel_id":"sampleChannel","ts":"sampleTs"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			mer := mock_repository.NewMockErrorRepository(ctrl)
+			err := errors.New("thread_not_found")
+			gomock.InOrder(
+				mer.EXPECT().ErrThreadNotFound(err).Return(true),
+				msr.EXPECT().PostEphemeral(gomock.Any(), "sampleChannel",
+					"メッセージが見つかりませんでした:neko_namida: Aurigaをチャンネルに招待してね", "sampleTs", "sampleUser").Return(nil),
+			)
+			h := &interactionHandler{
+				slackResponseService: service.NewSlackResponseService(&fakeFactory{slackRepository: msr, errorRepository: mer}),
+				slackModalService:    &fakeModalService{err: err},
+			}
+			if tt.action != nil {
+				h.emailListAction(context.Background(), tt.callback, tt.action)
+			} else {
+				h.openModal(context.Background(), tt.callback)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"log"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/domain/service"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	pkgslack "github.com/moneyforward/auriga/app/pkg/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+type ReactionHandler interface {
+	GetFunc() pkgslack.ReactionEventHandler
+}
+
+type reactionHandler struct {
+	slackReactionUsersService service.SlackReactionUsersService
+	googleCalenderService     service.GoogleCalenderService
+	slackResponseService      service.SlackResponseService
+	localeService             service.LocaleService
+}
+
+func NewReactionHandler(factory repository.Factory, defaultLocale i18n.Locale) *reactionHandler {
+	return &reactionHandler{
+		slackReactionUsersService: service.NewSlackReactionUsersService(factory),
+		googleCalenderService:     service.NewGoogleCalenderService(factory),
+		slackResponseService:      service.NewSlackResponseService(factory),
+		localeService:             service.NewLocaleService(factory, defaultLocale),
+	}
+}
+
+// GetFunc returns the handler of reaction_added and reaction_removed, which updates the attendees
+// of the event created from the message in the same way as when it was created, and posts the changes in the thread.
+// The attendees are selected again from the current reactions rather than by the reaction of the event,
+// so that the events delivered late or out of order do not leave them wrong.
+// The events of the same message sync them one at a time, as the attendees are read and written back.
+func (h *reactionHandler) GetFunc() pkgslack.ReactionEventHandler {
+	return func(ctx context.Context, userID, reaction string, item slackevents.Item) {
+		if item.Type != "message" {
+			return
+		}
+		binding, err := h.googleCalenderService.FindEventBinding(ctx, item.Channel, item.Timestamp, reaction)
+		if err != nil {
+			log.Printf("Failed to find the event of %s/%s: %v", item.Channel, item.Timestamp, err)
+			return
+		}
+		if binding == nil {
+			return
+		}
+		unlock, err := h.googleCalenderService.LockEventBinding(ctx, binding.ChannelID, binding.TimeStamp)
+		if err != nil {
+			log.Printf("Failed to lock the event of %s/%s: %v", item.Channel, item.Timestamp, err)
+			return
+		}
+		defer unlock()
+		// the binding may be updated by the event which held the lock
+		binding, err = h.googleCalenderService.FindEventBinding(ctx, item.Channel, item.Timestamp, reaction)
+		if err != nil {
+			log.Printf("Failed to find the event of %s/%s: %v", item.Channel, item.Timestamp, err)
+			return
+		}
+		if binding == nil {
+			return
+		}
+		emails, err := h.slackReactionUsersService.ListUsersEmailByReaction(ctx, binding.ChannelID, binding.TimeStamp, binding.Reactions)
+		if err != nil {
+			log.Printf("Failed to list the attendees of %s: %v", binding.EventID, err)
+			return
+		}
+		emails = h.slackReactionUsersService.FilterUsers(emails, binding.UserFilter)
+		change, err := h.googleCalenderService.SyncAttendees(ctx, binding, emails)
+		if err != nil {
+			log.Printf("Failed to update the attendees of %s: %v", binding.EventID, err)
+			return
+		}
+		if change.IsEmpty() {
+			return
+		}
+		ctx = h.localeService.WithUserLocale(ctx, userID)
+		if err = h.slackResponseService.NotifyAttendeeChange(ctx, binding, change); err != nil {
+			log.Printf("Failed to notify the attendees of %s: %v", binding.EventID, err)
+		}
+	}
+}
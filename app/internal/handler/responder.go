@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+
+	"github.com/moneyforward/auriga/app/internal/domain/service"
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+// responder sends the results back to the target, where Auriga was called from
+type responder struct {
+	slackResponseService service.SlackResponseService
+	target               *service.ResponseTarget
+	// reactions and options are of the email list
+	reactions *model.ReactionFilter
+	options   *model.EmailListOptions
+}
+
+func (r *responder) emailList(ctx context.Context, emails []*model.SlackUserEmail) error {
+	return r.slackResponseService.ReplyEmailList(ctx, r.target, r.reactions, emails, r.options)
+}
+
+func (r *responder) calendarEvent(ctx context.Context, calendarEvent *model.CalendarEvent) error {
+	return r.slackResponseService.ReplyCalendarEvent(ctx, r.target, calendarEvent)
+}
+
+func (r *responder) calendarFile(ctx context.Context, file *model.CalendarFile) error {
+	return r.slackResponseService.ReplyCalendarFile(ctx, r.target, file)
+}
+
+func (r *responder) calendarTemplate(ctx context.Context, template *model.CalendarTemplate) error {
+	return r.slackResponseService.ReplyCalendarTemplate(ctx, r.target, template)
+}
+
+func (r *responder) pollResult(ctx context.Context, result *model.PollResult) error {
+	return r.slackResponseService.ReplyPollResult(ctx, r.target, result)
+}
+
+func (r *responder) pendingResult(ctx context.Context, result *model.PendingResult) error {
+	return r.slackResponseService.ReplyPendingResult(ctx, r.target, result)
+}
+
+func (r *responder) replyError(ctx context.Context, err error) error {
+	return r.slackResponseService.ReplyError(ctx, r.target, err)
+}
+
+func (r *responder) help(ctx context.Context) error {
+	return r.slackResponseService.ReplyHelp(ctx, r.target)
+}
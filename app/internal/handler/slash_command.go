@@ -0,0 +1,68 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/domain/service"
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
+	pkgslack "github.com/moneyforward/auriga/app/pkg/slack"
+	"github.com/slack-go/slack"
+)
+
+type SlashCommandHandler interface {
+	GetFunc() pkgslack.SlashCommandHandler
+}
+
+type slashCommandHandler struct {
+	slackResponseService service.SlackResponseService
+	slashCommandService  service.SlashCommandService
+	localeService        service.LocaleService
+	collector            *collector
+}
+
+func NewSlashCommandHandler(factory repository.Factory, location *time.Location, defaultLocale i18n.Locale, userFilter *model.UserFilter, reminderPolicy *model.ReminderPolicy) *slashCommandHandler {
+	return &slashCommandHandler{
+		slackResponseService: service.NewSlackResponseService(factory),
+		slashCommandService:  service.NewSlashCommandService(),
+		localeService:        service.NewLocaleService(factory, defaultLocale),
+		collector:            newCollector(factory, location, userFilter, reminderPolicy),
+	}
+}
+
+// GetFunc returns the handler of "/auriga <permalink> :reaction:",
+// which works like the mention for the message of the permalink.
+func (h *slashCommandHandler) GetFunc() pkgslack.SlashCommandHandler {
+	return func(ctx context.Context, command *slack.SlashCommand) {
+		ctx = h.localeService.WithUserLocale(ctx, command.UserID)
+		r := &responder{
+			slackResponseService: h.slackResponseService,
+			target:               service.NewSlashCommandTarget(command),
+		}
+		parsed, err := h.slashCommandService.Parse(command.Text)
+		if err != nil {
+			replyError(ctx, r, err)
+			return
+		}
+		r.options = parsed.Arguments.EmailListOptions()
+		h.collector.collect(ctx, r, parsed.ChannelID, parsed.TimeStamp, command.UserID, parsed.Arguments)
+	}
+}
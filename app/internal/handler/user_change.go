@@ -0,0 +1,51 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"log"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/domain/service"
+	pkgslack "github.com/moneyforward/auriga/app/pkg/slack"
+	"github.com/slack-go/slack"
+)
+
+type UserChangeHandler interface {
+	GetFunc() pkgslack.UserChangeEventHandler
+}
+
+type userChangeHandler struct {
+	slackReactionUsersService service.SlackReactionUsersService
+}
+
+func NewUserChangeHandler(factory repository.Factory) *userChangeHandler {
+	return &userChangeHandler{
+		slackReactionUsersService: service.NewSlackReactionUsersService(factory),
+	}
+}
+
+// GetFunc returns the handler of the user_change event, which drops the cached user
+// so that the next list has the new email and status
+func (h *userChangeHandler) GetFunc() pkgslack.UserChangeEventHandler {
+	return func(ctx context.Context, event *slack.UserChangeEvent) {
+		if err := h.slackReactionUsersService.InvalidateUser(ctx, event.User.ID); err != nil {
+			log.Printf("Failed to invalidate %s: %v", event.User.ID, err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package i18n
+
+var en = map[Key]string{
+	EmailListTitle:              "Attendees",
+	EmailListTitleWithCount:     "Attendees (%d)",
+	EmailListTitleWithReactions: "Attendees %s (%d)",
+	EmailListFileSent:           "Sent the attendee list as a file in DM :envelope_with_arrow:",
+	EmailListNoEmails:           "No users with known email addresses",
+	EmailListUnknownEmails:      "Users without known email addresses: %s",
+	EmailListGuests:             "Guests with hidden email addresses: %s",
+	EmailListBots:               "Bots: %s",
+	EmailListDeletedUsers:       "Deactivated users: %s",
+	EmailListCreateEvent:        "Create event",
+	EmailListExportCSV:          "Export CSV",
+	EmailListRefresh:            "Refresh",
+	EmailListTableName:          "Name",
+	EmailListTableEmail:         "Email",
+	EmailListTableReactions:     "Reactions",
+
+	CalendarEventCreated:  "Created the event and invited %d people :spiral_calendar_pad:\n%s - %s %s\n%s",
+	AttendeesUpdated:      "Updated the attendees of <%s|%s> following the reactions :spiral_calendar_pad:",
+	AttendeesAdded:        "Invited: %s",
+	AttendeesRemoved:      "Cancelled: %s",
+	CalendarFileCreated:   "Created the event file inviting %d people :spiral_calendar_pad: Open it with Outlook, Apple Calendar and others\n%s - %s %s",
+	CalendarFileSent:      "Sent the event file in DM :envelope_with_arrow:",
+	CalendarTemplate:      "Create the event on Google Calendar from the link. %d guests are filled in :spiral_calendar_pad:\n%s - %s %s",
+	CalendarTemplateSplit: "The link is split into %d since there are many attendees. Add the guests of the other links to the event created from the first one.",
+	CalendarTemplateLink:  "<%s|Create the event on Google Calendar>",
+	CalendarTemplatePart:  "<%s|Create the event on Google Calendar (%d/%d)>",
+	PollResultTitle:       "Poll result (%d respondents)",
+	PollResultSlot:        "#%d :%s: %s (%d)",
+	PollResultUnavailable: "      Can't attend: %s",
+
+	PendingResultTitle: "Members of %s who haven't reacted yet (%d):\n%s",
+	PendingResultNone:  "Everyone in %s has reacted :tada:",
+	ReminderMessage:    "<@%s> is waiting for your response to <%s|this message> :pray: Please react to it.",
+	ReminderSent:       "Sent reminders to %d members.",
+	ReminderOptedOut:   "Didn't remind the members who opted out: %s",
+	ReminderOverLimit:  "Didn't remind the following members since the reminders are limited to %d at once: %s",
+	ReminderFailed:     "Couldn't send reminders to: %s",
+
+	ErrorParseDatetime: "Couldn't read the date and time :neko_namida: (%s)\n" +
+		"Write it like `@Auriga :sanka: tomorrow 3pm for 1h Title`",
+	ErrorPollNotFound:          "No candidates found :neko_namida: Write numbered candidates like `:one: 11/5 14:00-15:00` in the parent message",
+	ErrorPollNoVotes:           "Couldn't book since there are no votes yet :neko_namida:",
+	ErrorPollTied:              "Couldn't book since some candidates are tied :neko_namida:",
+	ErrorInvalidPermalink:      "Give the link of a message :neko_namida: (`/auriga <link of the message> :sanka:`)",
+	ErrorThreadNotFound:        "Call me in a thread :neko_namida:",
+	ErrorUserNotFound:          "No attendees found :neko_namida:",
+	ErrorCalendarNotConfigured: "Google Calendar integration is not configured :neko_namida:",
+	ErrorPermalinkNotFound:     "Message not found :neko_namida: Give the link of a message in a channel Auriga has joined",
+	ErrorMessageNotFound:       "Message not found :neko_namida: Invite Auriga to the channel",
+	ErrorNoReactionSelected:    "Select at least one reaction :neko_namida:",
+	ErrorInvalidReplyPattern:   "The regular expression of `--regex` is invalid :neko_namida:",
+	ErrorMembersNotFound:       "User group or channel not found :neko_namida: Invite Auriga to the channel",
+
+	HelpMention: "[Usage]\n" +
+		"1. Call Auriga in a thread like `@Auriga :sanka:` with a reaction.\n" +
+		"2. Auriga returns the email addresses of the users who reacted to the parent message with it.\n" +
+		"3. Paste them into Google Calendar to invite everyone at once!\n" +
+		"Listing reactions like `@Auriga :sanka: :maybe: +:onsite: -:absent:` selects either of them, both with `+` and excludes with `-`.\n" +
+		"Following with a date, time and title like `@Auriga :sanka: tomorrow 3pm for 1h Title` creates the event on Google Calendar and invites them. `--ics` returns the event file for Outlook or Apple Calendar instead, and `--link` (or no Google Calendar integration) the link to create the event yourself.\n" +
+		"`@Auriga poll` tallies the candidates like :one: :two: in the parent message. `--book` creates the event at the winner.\n" +
+		"`@Auriga replies` collects the users who replied in the thread. `replies :sanka:` combines them with the reactions, `--keyword=join` or `--regex=...` filters the replies and `--exclude-me` excludes you.\n" +
+		"`@Auriga @design-team` or `#project-x` collects the members of the user group or the channel, and `#project-x -:sanka:` the ones who haven't reacted.\n" +
+		"`@Auriga pending` shows only to you the members of the channel (or `pending @design-team`) who haven't reacted at all, and `--remind` reminds them in the direct messages.\n" +
+		"`--csv` returns the list with names and reactions as a CSV file. Long lists are sent as a file automatically.\n" +
+		"`--format=semicolon` (`lines`, `comma`, `mention`, `markdown-table`) changes the format and `--sort=name` (`email`, `reaction-time`) the order. `--with-names` writes them like `Name <email>`.\n" +
+		"`--no-guests` excludes guests and `--no-external` the users of other organizations in Slack Connect. `--domain=example.com` keeps only the emails of the domain.",
+	HelpSlashCommand: "[Usage]\n" +
+		"1. Give the link of a message and reactions like `/auriga <link of the message> :sanka:`.\n" +
+		"2. Auriga returns the email addresses of the users who reacted to the message with them.\n" +
+		"Combining reactions, a date and title, and `poll` work the same as calling `@Auriga` in a thread.",
+
+	ModalTitle:               "Collect attendees",
+	ModalSubmit:              "Submit",
+	ModalClose:               "Cancel",
+	ModalNoReactions:         "The message has no reactions yet",
+	ModalReactionOption:      ":%s: (%d)",
+	ModalReactionsLabel:      "Reactions (users with any of them)",
+	ModalRefreshReactions:    "Reload reactions",
+	ModalFormatLabel:         "Format of the email addresses",
+	ModalFormatLines:         "One per line",
+	ModalFormatComma:         "Comma-separated",
+	ModalFormatCSV:           "CSV file (with names and reactions)",
+	ModalDatetimeLabel:       "Date and time",
+	ModalDatetimePlaceholder: "tomorrow 3pm for 1h",
+	ModalDatetimeHint:        "Fill in to create the event on Google Calendar and invite them",
+	ModalTitleLabel:          "Title",
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package i18n translates the messages of Auriga into the locale of the user.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Locale string
+
+const (
+	Japanese Locale = "ja"
+	English  Locale = "en"
+
+	// DefaultLocale is used when the locale is not resolved
+	DefaultLocale = Japanese
+)
+
+var catalogs = map[Locale]map[Key]string{
+	Japanese: ja,
+	English:  en,
+}
+
+type localeKey struct{}
+
+// WithLocale returns the context carrying the locale
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale of the context, or DefaultLocale if it is not set
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// ParseLocale reads the locale of Slack (e.g. "ja-JP", "en-US") or the config (e.g. "en").
+// ok is false if the locale is not supported.
+func ParseLocale(s string) (locale Locale, ok bool) {
+	language := strings.ToLower(strings.SplitN(strings.ReplaceAll(s, "_", "-"), "-", 2)[0])
+	if _, ok := catalogs[Locale(language)]; !ok {
+		return "", false
+	}
+	return Locale(language), true
+}
+
+// T returns the message of key in the locale, formatted with args.
+// The message of DefaultLocale is used if the locale lacks it.
+func (l Locale) T(key Key, args ...interface{}) string {
+	format, ok := catalogs[l][key]
+	if !ok {
+		format, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		return string(key)
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// T returns the message of key in the locale of the context
+func T(ctx context.Context, key Key, args ...interface{}) string {
+	return FromContext(ctx).T(key, args...)
+}
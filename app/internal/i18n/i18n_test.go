@@ -0,0 +1,103 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package i18n
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// Test_catalogs checks that every locale has the same messages with the same number of arguments
+func Test_catalogs(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for key, format := range catalogs[DefaultLocale] {
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s lacks %s", locale, key)
+				continue
+			}
+			if got, want := strings.Count(translated, "%")-2*strings.Count(translated, "%%"), strings.Count(format, "%")-2*strings.Count(format, "%%"); got != want {
+				t.Errorf("%s of %s has %d arguments, want %d", key, locale, got, want)
+			}
+		}
+		if len(catalog) != len(catalogs[DefaultLocale]) {
+			t.Errorf("%s has %d messages, want %d", locale, len(catalog), len(catalogs[DefaultLocale]))
+		}
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		want   Locale
+		wantOK bool
+	}{
+		{name: "OK: Slack locale", s: "ja-JP", want: Japanese, wantOK: true},
+		{name: "OK: English variant", s: "en-GB", want: English, wantOK: true},
+		{name: "OK: language only", s: "EN", want: English, wantOK: true},
+		{name: "NG: not supported", s: "fr-FR"},
+		{name: "NG: empty", s: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseLocale(tt.s)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParseLocale() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		key  Key
+		args []interface{}
+		want string
+	}{
+		{
+			name: "OK: default locale",
+			ctx:  context.Background(),
+			key:  EmailListTitleWithCount,
+			args: []interface{}{3},
+			want: "参加者一覧 (3名)",
+		},
+		{
+			name: "OK: locale of the context",
+			ctx:  WithLocale(context.Background(), English),
+			key:  EmailListTitleWithCount,
+			args: []interface{}{3},
+			want: "Attendees (3)",
+		},
+		{
+			name: "NG: unknown key",
+			ctx:  WithLocale(context.Background(), English),
+			key:  Key("unknown"),
+			want: "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := T(tt.ctx, tt.key, tt.args...); got != tt.want {
+				t.Errorf("T() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
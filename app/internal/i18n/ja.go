@@ -0,0 +1,106 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package i18n
+
+var ja = map[Key]string{
+	EmailListTitle:              "参加者一覧",
+	EmailListTitleWithCount:     "参加者一覧 (%d名)",
+	EmailListTitleWithReactions: "参加者一覧 %s (%d名)",
+	EmailListFileSent:           "参加者一覧のファイルをDMで送りました:envelope_with_arrow:",
+	EmailListNoEmails:           "メールアドレスが分かるユーザーはいません",
+	EmailListUnknownEmails:      "メールアドレスが分からないユーザー: %s",
+	EmailListGuests:             "メールアドレスが非公開のゲスト: %s",
+	EmailListBots:               "ボット: %s",
+	EmailListDeletedUsers:       "無効化されたユーザー: %s",
+	EmailListCreateEvent:        "予定を作成",
+	EmailListExportCSV:          "CSVで出力",
+	EmailListRefresh:            "更新",
+	EmailListTableName:          "名前",
+	EmailListTableEmail:         "メールアドレス",
+	EmailListTableReactions:     "リアクション",
+
+	CalendarEventCreated:  "予定を作成して%d名を招待しました:spiral_calendar_pad:\n%s - %s %s\n%s",
+	AttendeesUpdated:      "リアクションに合わせて<%s|%s>の参加者を更新しました:spiral_calendar_pad:",
+	AttendeesAdded:        "招待: %s",
+	AttendeesRemoved:      "招待を取り消し: %s",
+	CalendarFileCreated:   "%d名を招待する予定のファイルを作成しました:spiral_calendar_pad: OutlookやAppleカレンダーで開いてください\n%s - %s %s",
+	CalendarFileSent:      "予定のファイルをDMで送りました:envelope_with_arrow:",
+	CalendarTemplate:      "リンクからGoogleカレンダーに予定を作成してください。%d名をゲストに入力してあります:spiral_calendar_pad:\n%s - %s %s",
+	CalendarTemplateSplit: "参加者が多いため、リンクを%d個に分けました。2つ目以降のリンクのゲストは、1つ目のリンクで作成した予定に追加してください。",
+	CalendarTemplateLink:  "<%s|Googleカレンダーで予定を作成>",
+	CalendarTemplatePart:  "<%s|Googleカレンダーで予定を作成 (%d/%d)>",
+	PollResultTitle:       "日程調整の結果 (回答者 %d名)",
+	PollResultSlot:        "%d位 :%s: %s (%d名)",
+	PollResultUnavailable: "　　参加できない人: %s",
+
+	PendingResultTitle: "%s でまだリアクションしていない人 (%d名):\n%s",
+	PendingResultNone:  "%s の全員がリアクションしました:tada:",
+	ReminderMessage:    "<@%s> さんが<%s|このメッセージ>への回答を待っています:pray: リアクションしてください。",
+	ReminderSent:       "%d名にリマインドを送りました。",
+	ReminderOptedOut:   "リマインドを受け取らない設定の人には送っていません: %s",
+	ReminderOverLimit:  "一度に送れるリマインドは%d名までのため、次の人には送っていません: %s",
+	ReminderFailed:     "次の人にはリマインドを送れませんでした: %s",
+
+	ErrorParseDatetime: "日時を読み取れませんでした:neko_namida: (%s)\n" +
+		"`@Auriga :sanka: 明日15時から1時間 タイトル` のように指定してね",
+	ErrorPollNotFound:          "候補が見つかりませんでした:neko_namida: 親メッセージに `:one: 11/5 14:00-15:00` のように番号付きで候補を書いてね",
+	ErrorPollNoVotes:           "まだ投票がないため予約できませんでした:neko_namida:",
+	ErrorPollTied:              "同票の候補があるため予約できませんでした:neko_namida:",
+	ErrorInvalidPermalink:      "メッセージのリンクを指定してね:neko_namida: (`/auriga <メッセージのリンク> :sanka:`)",
+	ErrorThreadNotFound:        "スレッドで呼び出してね:neko_namida:",
+	ErrorUserNotFound:          "参加者はいないようです:neko_namida:",
+	ErrorCalendarNotConfigured: "Googleカレンダー連携が設定されていません:neko_namida:",
+	ErrorPermalinkNotFound:     "メッセージが見つかりませんでした:neko_namida: Aurigaが参加しているチャンネルのメッセージのリンクを指定してね",
+	ErrorMessageNotFound:       "メッセージが見つかりませんでした:neko_namida: Aurigaをチャンネルに招待してね",
+	ErrorNoReactionSelected:    "リアクションを1つ以上選んでね:neko_namida:",
+	ErrorInvalidReplyPattern:   "`--regex` の正規表現が正しくありません:neko_namida:",
+	ErrorMembersNotFound:       "ユーザーグループかチャンネルが見つかりません:neko_namida: Aurigaをチャンネルに招待してください",
+
+	HelpMention: "[使い方]\n" +
+		"1. スレッドで `@Auriga :sanka:` のようにAurigaを呼び出し、リアクションを指定してください。\n" +
+		"2. スレッドの開始メッセージに指定のリアクションをしたユーザのメールアドレス一覧を返します。\n" +
+		"3. 結果をGoogleCalenderに貼り付けると一括招待できます！\n" +
+		"`@Auriga :sanka: :maybe: +:onsite: -:absent:` のように、リアクションを並べるといずれか、`+` を付けると両方、`-` を付けると除外になります。\n" +
+		"`@Auriga :sanka: 明日15時から1時間 タイトル` のように日時とタイトルを続けると、Googleカレンダーに予定を作成して招待します。`--ics` を付けるとOutlookやAppleカレンダー向けの予定のファイルを、`--link` を付けるかGoogleカレンダー連携が未設定のときは予定を作成するリンクを返します。\n" +
+		"`@Auriga poll` で親メッセージの :one: :two: などの候補を集計します。`--book` を付けると最多の候補で予定を作成します。\n" +
+		"`@Auriga replies` でスレッドに返信した人を集めます。`replies :sanka:` でリアクションと組み合わせ、`--keyword=参加` や `--regex=...` で返信を絞り込み、`--exclude-me` で自分を除きます。\n" +
+		"`@Auriga @design-team` や `#project-x` でユーザーグループやチャンネルのメンバーを集め、`#project-x -:sanka:` でリアクションしていない人を集めます。\n" +
+		"`@Auriga pending` でチャンネル (や `pending @design-team`) でまだ何もリアクションしていない人をあなただけに表示し、`--remind` でDMでリマインドします。\n" +
+		"`--csv` を付けると、名前やリアクション付きの一覧をCSVファイルで返します。人数が多いときは自動でファイルになります。\n" +
+		"`--format=semicolon` (`lines`、`comma`、`mention`、`markdown-table`) で形式を、`--sort=name` (`email`、`reaction-time`) で並び順を変えます。`--with-names` を付けると `名前 <メールアドレス>` の形になります。\n" +
+		"`--no-guests` でゲストを、`--no-external` でSlackコネクトの社外のユーザーを除きます。`--domain=example.com` でメールアドレスのドメインを絞り込みます。",
+	HelpSlashCommand: "[使い方]\n" +
+		"1. `/auriga <メッセージのリンク> :sanka:` のように、メッセージのリンクとリアクションを指定してください。\n" +
+		"2. そのメッセージに指定のリアクションをしたユーザのメールアドレス一覧を返します。\n" +
+		"リアクションの組み合わせや日時とタイトルの指定、`poll` はスレッドで `@Auriga` を呼び出すときと同じように使えます。",
+
+	ModalTitle:               "参加者を集める",
+	ModalSubmit:              "送信",
+	ModalClose:               "キャンセル",
+	ModalNoReactions:         "メッセージにリアクションがまだありません",
+	ModalReactionOption:      ":%s: (%d名)",
+	ModalReactionsLabel:      "リアクション (いずれかをした人)",
+	ModalRefreshReactions:    "リアクションを再読み込み",
+	ModalFormatLabel:         "メールアドレスの形式",
+	ModalFormatLines:         "1行ずつ",
+	ModalFormatComma:         "カンマ区切り",
+	ModalFormatCSV:           "CSVファイル (名前とリアクション付き)",
+	ModalDatetimeLabel:       "日時",
+	ModalDatetimePlaceholder: "明日15時から1時間",
+	ModalDatetimeHint:        "入力するとGoogleカレンダーに予定を作成して招待します",
+	ModalTitleLabel:          "タイトル",
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package i18n
+
+// Key identifies a message in the catalogs
+type Key string
+
+// email list
+const (
+	EmailListTitle              Key = "email_list.title"
+	EmailListTitleWithCount     Key = "email_list.title_with_count"
+	EmailListTitleWithReactions Key = "email_list.title_with_reactions"
+	EmailListFileSent           Key = "email_list.file_sent"
+	EmailListNoEmails           Key = "email_list.no_emails"
+	EmailListUnknownEmails      Key = "email_list.unknown_emails"
+	EmailListGuests             Key = "email_list.guests"
+	EmailListBots               Key = "email_list.bots"
+	EmailListDeletedUsers       Key = "email_list.deleted_users"
+	EmailListCreateEvent        Key = "email_list.create_event"
+	EmailListExportCSV          Key = "email_list.export_csv"
+	EmailListRefresh            Key = "email_list.refresh"
+	EmailListTableName          Key = "email_list.table_name"
+	EmailListTableEmail         Key = "email_list.table_email"
+	EmailListTableReactions     Key = "email_list.table_reactions"
+)
+
+// calendar event and poll
+const (
+	CalendarEventCreated  Key = "calendar_event.created"
+	AttendeesUpdated      Key = "calendar_event.attendees_updated"
+	AttendeesAdded        Key = "calendar_event.attendees_added"
+	AttendeesRemoved      Key = "calendar_event.attendees_removed"
+	CalendarFileCreated   Key = "calendar_file.created"
+	CalendarFileSent      Key = "calendar_file.sent"
+	CalendarTemplate      Key = "calendar_template.created"
+	CalendarTemplateSplit Key = "calendar_template.split"
+	CalendarTemplateLink  Key = "calendar_template.link"
+	CalendarTemplatePart  Key = "calendar_template.part"
+	PollResultTitle       Key = "poll_result.title"
+	PollResultSlot        Key = "poll_result.slot"
+	PollResultUnavailable Key = "poll_result.unavailable"
+)
+
+// pending members and reminders
+const (
+	PendingResultTitle Key = "pending_result.title"
+	PendingResultNone  Key = "pending_result.none"
+	ReminderMessage    Key = "reminder.message"
+	ReminderSent       Key = "reminder.sent"
+	ReminderOptedOut   Key = "reminder.opted_out"
+	ReminderOverLimit  Key = "reminder.over_limit"
+	ReminderFailed     Key = "reminder.failed"
+)
+
+// errors
+const (
+	ErrorParseDatetime         Key = "error.parse_datetime"
+	ErrorPollNotFound          Key = "error.poll_not_found"
+	ErrorPollNoVotes           Key = "error.poll_no_votes"
+	ErrorPollTied              Key = "error.poll_tied"
+	ErrorInvalidPermalink      Key = "error.invalid_permalink"
+	ErrorThreadNotFound        Key = "error.thread_not_found"
+	ErrorUserNotFound          Key = "error.user_not_found"
+	ErrorCalendarNotConfigured Key = "error.calendar_not_configured"
+	ErrorPermalinkNotFound     Key = "error.permalink_not_found"
+	ErrorMessageNotFound       Key = "error.message_not_found"
+	ErrorNoReactionSelected    Key = "error.no_reaction_selected"
+	ErrorInvalidReplyPattern   Key = "error.invalid_reply_pattern"
+	ErrorMembersNotFound       Key = "error.members_not_found"
+)
+
+// help
+const (
+	HelpMention      Key = "help.mention"
+	HelpSlashCommand Key = "help.slash_command"
+)
+
+// "Collect attendees" modal
+const (
+	ModalTitle               Key = "modal.title"
+	ModalSubmit              Key = "modal.submit"
+	ModalClose               Key = "modal.close"
+	ModalNoReactions         Key = "modal.no_reactions"
+	ModalReactionOption      Key = "modal.reaction_option"
+	ModalReactionsLabel      Key = "modal.reactions_label"
+	ModalRefreshReactions    Key = "modal.refresh_reactions"
+	ModalFormatLabel         Key = "modal.format_label"
+	ModalFormatLines         Key = "modal.format_lines"
+	ModalFormatComma         Key = "modal.format_comma"
+	ModalFormatCSV           Key = "modal.format_csv"
+	ModalDatetimeLabel       Key = "modal.datetime_label"
+	ModalDatetimePlaceholder Key = "modal.datetime_placeholder"
+	ModalDatetimeHint        Key = "modal.datetime_hint"
+	ModalTitleLabel          Key = "modal.title_label"
+)
@@ -0,0 +1,43 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+type CalendarEvent struct {
+	ID             string
+	Title          string
+	Description    string
+	Start          time.Time
+	End            time.Time
+	AttendeeEmails []string
+	URL            string
+}
+
+// CalendarFile is the event written in an iCalendar (.ics) file for the calendars other than Google Calendar
+type CalendarFile struct {
+	Event   *CalendarEvent
+	Name    string
+	Content string
+}
+
+// CalendarTemplate is the event prefilled in the links to Google Calendar, from which the user creates it without the credentials
+type CalendarTemplate struct {
+	Event *CalendarEvent
+	// URLs has more than one link if the attendees do not fit in one
+	URLs []string
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const (
+	// EmailListFormatLines lists an email address per line
+	EmailListFormatLines = "lines"
+	// EmailListFormatComma joins the email addresses with commas, which can be pasted into the guest field of calendars
+	EmailListFormatComma = "comma"
+	// EmailListFormatSemicolon joins the email addresses with semicolons, which can be pasted into the recipients of Outlook
+	EmailListFormatSemicolon = "semicolon"
+	// EmailListFormatMention mentions all the users, including the ones whose emails are not known
+	EmailListFormatMention = "mention"
+	// EmailListFormatMarkdownTable lists the names, the email addresses and the reactions in a Markdown table
+	EmailListFormatMarkdownTable = "markdown-table"
+	// EmailListFormatCSV uploads a CSV file with the names and the reactions of the users
+	EmailListFormatCSV = "csv"
+	// EmailListFormatTSV uploads a TSV file, which can be pasted into spreadsheets
+	EmailListFormatTSV = "tsv"
+)
+
+// CollectModalInput is the input of the "Collect attendees" modal
+type CollectModalInput struct {
+	// ChannelID and TimeStamp identify the message the shortcut was called on
+	ChannelID string
+	TimeStamp string
+	Reactions []string
+	Format    string
+	// Datetime and Title are filled in to create a calendar event (optional)
+	Datetime string
+	Title    string
+}
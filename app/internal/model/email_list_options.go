@@ -0,0 +1,37 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const (
+	// EmailListSortName sorts the users by the display names, or the real names if not set
+	EmailListSortName = "name"
+	// EmailListSortEmail sorts the users by the email addresses
+	EmailListSortEmail = "email"
+	// EmailListSortReactionTime sorts the users in the order they reacted.
+	// Slack does not tell when the users reacted, so the order of the users in the reactions is used instead.
+	EmailListSortReactionTime = "reaction-time"
+)
+
+// EmailListOptions tells how to show the email list
+type EmailListOptions struct {
+	// Format is one of EmailListFormatXxx, or "" for the Block Kit message
+	Format string
+	// Sort is one of EmailListSortXxx, or "" for the order of the reactions on the message
+	Sort string
+	// WithNames writes the entries like "Name <email>", which Google Calendar and Outlook read as the guests
+	WithNames bool
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// EventBinding links the calendar event created from the thread to the reactions selecting its attendees,
+// so that the attendees follow the reactions added or removed later
+type EventBinding struct {
+	ChannelID string
+	TimeStamp string
+	EventID   string
+	Title     string
+	URL       string
+	End       time.Time
+	// Reactions and UserFilter select the attendees in the same way as when the event was created
+	Reactions  *ReactionFilter
+	UserFilter *UserFilter `json:",omitempty"`
+	// AttendeeEmails are the attendees invited by Auriga, who are removed when they are no longer selected.
+	// The attendees added on the calendar by hand are kept.
+	AttendeeEmails []string
+}
+
+// AttendeeChange is the emails of the attendees added to and removed from the event
+type AttendeeChange struct {
+	Added   []string
+	Removed []string
+}
+
+// IsEmpty returns true if no attendees are changed
+func (c *AttendeeChange) IsEmpty() bool {
+	return c == nil || len(c.Added)+len(c.Removed) == 0
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// PendingResult is the members of the user groups and the channels who have not reacted to the message at all
+type PendingResult struct {
+	// Members are the user groups and the channels whose members were checked
+	Members []*MemberSource
+	// UserIDs are the members who have not reacted
+	UserIDs []string
+	// Reminder is the result of the reminders sent to UserIDs, or nil if they were not sent
+	Reminder *ReminderResult
+}
+
+// ReminderResult tells which users the reminders were sent to, and why not to the others
+type ReminderResult struct {
+	SentUserIDs []string
+	// OptedOutUserIDs are the users who opted out of the reminders
+	OptedOutUserIDs []string
+	// OverLimitUserIDs are the users not reminded since the reminders reached the limit
+	OverLimitUserIDs []string
+	// FailedUserIDs are the users the reminders could not be sent to, e.g. who blocked the direct messages
+	FailedUserIDs []string
+}
+
+// ReminderPolicy limits the direct messages of the reminders
+type ReminderPolicy struct {
+	// MaxMessages is the maximum number of the reminders sent at once, or zero for the default
+	MaxMessages int
+	// OptOutUserIDs are the users who never get the reminders
+	OptOutUserIDs []string
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// PollSlot is a candidate of a date poll and the users who voted for it
+type PollSlot struct {
+	Number   int
+	Reaction string
+	Text     string
+	UserIDs  []string
+	// UnavailableUserIDs are the users who voted for other slots but not for this one
+	UnavailableUserIDs []string
+}
+
+// PollResult is the slots of a date poll ranked by the number of votes
+type PollResult struct {
+	Slots []*PollSlot
+	// RespondentIDs are the users who voted for any slot
+	RespondentIDs []string
+}
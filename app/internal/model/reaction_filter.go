@@ -0,0 +1,133 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ReactionFilter selects users by their reactions.
+// Users who reacted with any of Any, all of All and none of Exclude are selected.
+// If both Any and All are empty, every user who reacted is a candidate.
+type ReactionFilter struct {
+	Any     []string
+	All     []string
+	Exclude []string
+	// Replies selects the users who replied in the thread as well, if not nil.
+	// The users who reacted are candidates only if Any or All is specified then.
+	Replies *ReplyFilter `json:",omitempty"`
+	// Members selects the members of the user groups and the channels instead of the users who reacted.
+	// The reactions filter the members then, e.g. "-:sanka:" selects the members who have not reacted with :sanka:.
+	Members []*MemberSource `json:",omitempty"`
+	// NoReactions excludes the members who reacted with any reaction, which is used to find who has not responded
+	NoReactions bool `json:",omitempty"`
+}
+
+// IsEmpty returns true if none of reactions, replies and members are specified
+func (f *ReactionFilter) IsEmpty() bool {
+	return f == nil || (len(f.Any)+len(f.All)+len(f.Exclude) == 0 && f.Replies == nil && len(f.Members) == 0)
+}
+
+// SelectsReactedUsers returns true if the users who reacted are the candidates,
+// that is, no members are specified, and any reaction is specified or the replies are not
+func (f *ReactionFilter) SelectsReactedUsers() bool {
+	return len(f.Members) == 0 && (f.Replies == nil || len(f.Any)+len(f.All) > 0)
+}
+
+// String formats the filter in the same way as the mention (e.g. ":sanka: :maybe: +:onsite: -:absent:")
+func (f *ReactionFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	words := make([]string, 0, len(f.Members)+len(f.Any)+len(f.All)+len(f.Exclude)+1)
+	for _, member := range f.Members {
+		words = append(words, member.String())
+	}
+	if f.Replies != nil {
+		words = append(words, f.Replies.String())
+	}
+	for _, name := range f.Any {
+		words = append(words, ":"+name+":")
+	}
+	for _, name := range f.All {
+		words = append(words, "+:"+name+":")
+	}
+	for _, name := range f.Exclude {
+		words = append(words, "-:"+name+":")
+	}
+	return strings.Join(words, " ")
+}
+
+// ReplyFilter selects the replies in the thread whose authors are the users
+type ReplyFilter struct {
+	// Keyword is the text the replies contain, ignoring the case
+	Keyword string `json:",omitempty"`
+	// Pattern is the regular expression the replies match
+	Pattern string `json:",omitempty"`
+	// ExcludeUserIDs are the users not to select, such as the caller
+	ExcludeUserIDs []string `json:",omitempty"`
+}
+
+// Match returns true if the reply contains Keyword, matches pattern (compiled from Pattern) and is not by ExcludeUserIDs
+func (f *ReplyFilter) Match(reply *SlackReply, pattern *regexp.Regexp) bool {
+	for _, userID := range f.ExcludeUserIDs {
+		if reply.UserID == userID {
+			return false
+		}
+	}
+	if f.Keyword != "" && !strings.Contains(strings.ToLower(reply.Text), strings.ToLower(f.Keyword)) {
+		return false
+	}
+	return pattern == nil || pattern.MatchString(reply.Text)
+}
+
+// String formats the filter in the same way as the mention (e.g. "replies --keyword=参加")
+func (f *ReplyFilter) String() string {
+	words := []string{"replies"}
+	if f.Keyword != "" {
+		words = append(words, "--keyword="+f.Keyword)
+	}
+	if f.Pattern != "" {
+		words = append(words, "--regex="+f.Pattern)
+	}
+	return strings.Join(words, " ")
+}
+
+// MemberSource is a user group or a channel whose members are the candidates
+type MemberSource struct {
+	// UserGroupID is the ID of the user group (e.g. S0123456789), or empty for the channel
+	UserGroupID string `json:",omitempty"`
+	// ChannelID is the ID of the channel (e.g. C0123456789), or empty for the user group
+	ChannelID string `json:",omitempty"`
+	// Name is the handle of the user group or the name of the channel, which may be empty
+	Name string `json:",omitempty"`
+}
+
+// String formats the source like "@design-team" or "#project-x", or with the ID if the name is unknown
+func (s *MemberSource) String() string {
+	if s.UserGroupID != "" {
+		if s.Name == "" {
+			return "@" + s.UserGroupID
+		}
+		return "@" + strings.TrimPrefix(s.Name, "@")
+	}
+	if s.Name == "" {
+		return "#" + s.ChannelID
+	}
+	return "#" + s.Name
+}
@@ -17,7 +17,51 @@
 package model
 
 type MentionParseResult struct {
-	Message  string
-	Command  string
-	Reaction string
+	Message   string
+	Command   string
+	Reactions *ReactionFilter
+	// Text is the rest of the message following the reactions (e.g. schedule and title of the event)
+	Text string
+	// Flags are the options written like "--book" or "--format=comma"
+	Flags map[string]string
+}
+
+// HasFlag returns true if the flag is specified
+func (r *MentionParseResult) HasFlag(name string) bool {
+	_, ok := r.Flags[name]
+	return ok
+}
+
+// EmailListFormat returns the format of the email list specified by "--csv", "--tsv" or "--format=...".
+// It returns "" if no format is specified.
+func (r *MentionParseResult) EmailListFormat() string {
+	switch {
+	case r.HasFlag(EmailListFormatCSV):
+		return EmailListFormatCSV
+	case r.HasFlag(EmailListFormatTSV):
+		return EmailListFormatTSV
+	}
+	return r.Flags["format"]
+}
+
+// EmailListOptions returns the options of the email list specified by the format,
+// "--sort=name|email|reaction-time" and "--with-names"
+func (r *MentionParseResult) EmailListOptions() *EmailListOptions {
+	return &EmailListOptions{
+		Format:    r.EmailListFormat(),
+		Sort:      r.Flags["sort"],
+		WithNames: r.HasFlag("with-names"),
+	}
+}
+
+// UserFilter returns the filter of the users specified by "--no-guests", "--no-external" and "--domain=example.com,example.org"
+func (r *MentionParseResult) UserFilter() *UserFilter {
+	filter := &UserFilter{
+		NoGuests:   r.HasFlag("no-guests"),
+		NoExternal: r.HasFlag("no-external"),
+	}
+	if domains := r.Flags["domain"]; domains != "" {
+		filter.Domains = ParseDomains(domains)
+	}
+	return filter
 }
@@ -18,16 +18,69 @@ package model
 
 type SlackMessage struct {
 	ChannelID string
+	Text      string
 	Reactions []*SlackReaction
 }
 
+// SlackReply is a reply in the thread by a user
+type SlackReply struct {
+	UserID string
+	Text   string
+}
+
 type SlackReaction struct {
 	Name    string
 	Count   int
 	UserIDs []string
 }
 
+// SlackUserStatus tells whether the email of the user can be invited, and why not
+type SlackUserStatus string
+
+const (
+	SlackUserStatusOK SlackUserStatus = "ok"
+	// SlackUserStatusNoEmail is the user whose email is not shown, e.g. without the users:read.email scope
+	SlackUserStatusNoEmail SlackUserStatus = "no_email"
+	SlackUserStatusBot     SlackUserStatus = "bot"
+	SlackUserStatusDeleted SlackUserStatus = "deleted"
+	// SlackUserStatusGuest is the multi-channel or single-channel guest whose email is hidden
+	SlackUserStatusGuest SlackUserStatus = "guest"
+)
+
+// UnresolvedSlackUserStatuses are the statuses of the users whose emails are not listed, in the order to report them
+var UnresolvedSlackUserStatuses = []SlackUserStatus{
+	SlackUserStatusNoEmail,
+	SlackUserStatusGuest,
+	SlackUserStatusBot,
+	SlackUserStatusDeleted,
+}
+
 type SlackUserEmail struct {
-	ID    string
-	Email string
+	ID     string
+	Email  string
+	Status SlackUserStatus
+	// IsGuest is true for the multi-channel and single-channel guests
+	IsGuest bool
+	// IsExternal is true for the users of the other organizations in the Slack Connect channels
+	IsExternal  bool
+	DisplayName string
+	RealName    string
+	// Reactions are the names of the reactions the user reacted with, in the order on the message
+	Reactions []string
+	// ReactionOrder is the position of the user in the reactions, starting from 1.
+	// Slack does not tell when the user reacted, but the users of a reaction are listed in the order they reacted.
+	ReactionOrder int
+}
+
+// Resolved reports whether the email of the user is known and can be invited
+func (e *SlackUserEmail) Resolved() bool {
+	return e.Status == SlackUserStatusOK && e.Email != ""
+}
+
+// Name returns the display name of the user, or the real name if the display name is not set
+func (e *SlackUserEmail) Name() string {
+	if e.DisplayName != "" {
+		return e.DisplayName
+	}
+	return e.RealName
 }
@@ -0,0 +1,26 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// SlashCommandParseResult is the result of parsing the slash command like "/auriga <permalink> :sanka:"
+type SlashCommandParseResult struct {
+	// ChannelID and TimeStamp identify the message given by the permalink
+	ChannelID string
+	TimeStamp string
+	// Arguments are the words following the permalink, parsed in the same way as the mention
+	Arguments *MentionParseResult
+}
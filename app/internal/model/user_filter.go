@@ -0,0 +1,112 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// UserFilter narrows the users down to the full members of the organization
+type UserFilter struct {
+	// NoGuests excludes the multi-channel and single-channel guests
+	NoGuests bool
+	// NoExternal excludes the users of the other organizations in the Slack Connect channels
+	NoExternal bool
+	// Domains keeps only the users whose emails are of the domains (e.g. example.com) if not empty.
+	// The users whose emails are unknown are kept to be reported.
+	Domains []string
+}
+
+// With returns the filter combined with the default one. The domains of f take precedence over the default ones.
+func (f *UserFilter) With(defaults *UserFilter) *UserFilter {
+	if defaults == nil {
+		return f
+	}
+	combined := &UserFilter{
+		NoGuests:   f.NoGuests || defaults.NoGuests,
+		NoExternal: f.NoExternal || defaults.NoExternal,
+		Domains:    f.Domains,
+	}
+	if len(combined.Domains) == 0 {
+		combined.Domains = defaults.Domains
+	}
+	return combined
+}
+
+// IsEmpty returns true if the filter keeps all the users
+func (f *UserFilter) IsEmpty() bool {
+	return f == nil || (!f.NoGuests && !f.NoExternal && len(f.Domains) == 0)
+}
+
+// Match returns true if the filter keeps the user
+func (f *UserFilter) Match(email *SlackUserEmail) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	if f.NoGuests && email.IsGuest {
+		return false
+	}
+	if f.NoExternal && email.IsExternal {
+		return false
+	}
+	if len(f.Domains) == 0 || email.Email == "" {
+		return true
+	}
+	at := strings.LastIndex(email.Email, "@")
+	for _, domain := range f.Domains {
+		if at >= 0 && strings.EqualFold(email.Email[at+1:], normalizeDomain(domain)) {
+			return true
+		}
+	}
+	return false
+}
+
+// regLink matches the link markup which Slack adds to the domains and the emails in the text
+// (e.g. "<http://example.com|example.com>" or "<mailto:taro@example.com|taro@example.com>")
+var regLink = regexp.MustCompile(`<([^<>|]*)(?:\|([^<>]*))?>`)
+
+// ParseDomains splits the comma-separated domains (e.g. "example.com,example.org"),
+// reading the label of the link markup Slack sends instead of the domain.
+func ParseDomains(s string) []string {
+	s = regLink.ReplaceAllStringFunc(s, func(link string) string {
+		m := regLink.FindStringSubmatch(link)
+		if m[2] != "" {
+			return m[2]
+		}
+		return m[1]
+	})
+	var domains []string
+	for _, domain := range strings.Split(s, ",") {
+		if domain = normalizeDomain(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// normalizeDomain returns the domain without the scheme and the local part (e.g. "example.com" for "http://example.com/")
+func normalizeDomain(domain string) string {
+	domain = strings.TrimSpace(domain)
+	for _, scheme := range []string{"http://", "https://", "mailto:"} {
+		domain = strings.TrimPrefix(domain, scheme)
+	}
+	if at := strings.LastIndex(domain, "@"); at >= 0 {
+		domain = domain[at+1:]
+	}
+	return strings.TrimSuffix(domain, "/")
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package renderer builds the Block Kit payloads of the replies.
+package renderer
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/errors"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// ActionIDCreateEvent opens the modal to create the event for the users
+	ActionIDCreateEvent = "email_list_create_event"
+	// ActionIDExportCSV sends the list as a CSV file
+	ActionIDExportCSV = "email_list_export_csv"
+	// ActionIDRefresh replaces the list with the current reactions
+	ActionIDRefresh = "email_list_refresh"
+
+	blockIDEmailListActions = "email_list_actions"
+
+	// maxSectionTextLength is the limit of the text of a section block
+	maxSectionTextLength = 3000
+	// maxHeaderTextLength is the limit of the text of a header block
+	maxHeaderTextLength = 150
+)
+
+// EmailList is the list of the users who reacted to the message
+type EmailList struct {
+	ChannelID string
+	TimeStamp string
+	Reactions *model.ReactionFilter
+	Emails    []*model.SlackUserEmail
+	// Locale is the language of the texts, i18n.DefaultLocale if empty
+	Locale i18n.Locale
+	// WithNames lists the emails like "Name <email>"
+	WithNames bool
+}
+
+func (l *EmailList) locale() i18n.Locale {
+	if l.Locale == "" {
+		return i18n.DefaultLocale
+	}
+	return l.Locale
+}
+
+// ActionValue is the value of the buttons, which tells the list to work on
+type ActionValue struct {
+	ChannelID string                `json:"channel_id"`
+	TimeStamp string                `json:"ts"`
+	Reactions *model.ReactionFilter `json:"reactions"`
+}
+
+// ParseActionValue reads the value of the button of the list
+func ParseActionValue(value string) (*ActionValue, error) {
+	var v ActionValue
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return nil, errors.Wrap(err, "failed to read the value of the action")
+	}
+	return &v, nil
+}
+
+// EmailListText is the text shown in the notifications of the list
+func EmailListText(list *EmailList) string {
+	return list.locale().T(i18n.EmailListTitleWithCount, len(list.Emails))
+}
+
+// EmailListBlocks renders a header with the reactions and the count, code blocks with the comma-separated emails
+// which can be pasted into the guest box of Google Calendar, a context for the users without emails and the buttons.
+func EmailListBlocks(list *EmailList) ([]slack.Block, error) {
+	header := EmailListText(list)
+	if reactions := list.Reactions.String(); reactions != "" {
+		header = list.locale().T(i18n.EmailListTitleWithReactions, reactions, len(list.Emails))
+	}
+	if len([]rune(header)) > maxHeaderTextLength {
+		header = string([]rune(header)[:maxHeaderTextLength-1]) + "…"
+	}
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, header, true, false)),
+	}
+
+	var addresses []string
+	for _, email := range list.Emails {
+		if email.Resolved() {
+			addresses = append(addresses, EmailAddress(email, list.WithNames))
+		}
+	}
+	if len(addresses) == 0 {
+		blocks = append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, list.locale().T(i18n.EmailListNoEmails), false, false), nil, nil))
+	}
+	for _, chunk := range joinInChunks(addresses, ", ", maxSectionTextLength-len("``````")) {
+		blocks = append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, "```"+chunk+"```", false, false), nil, nil))
+	}
+	for _, text := range UnresolvedUsersTexts(list.locale(), list.Emails) {
+		blocks = append(blocks, slack.NewContextBlock("",
+			slack.NewTextBlockObject(slack.MarkdownType, text, false, false)))
+	}
+
+	value, err := json.Marshal(&ActionValue{ChannelID: list.ChannelID, TimeStamp: list.TimeStamp, Reactions: list.Reactions})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to write the value of the action")
+	}
+	createEvent := slack.NewButtonBlockElement(ActionIDCreateEvent, string(value),
+		slack.NewTextBlockObject(slack.PlainTextType, list.locale().T(i18n.EmailListCreateEvent), false, false))
+	createEvent.Style = slack.StylePrimary
+	exportCSV := slack.NewButtonBlockElement(ActionIDExportCSV, string(value),
+		slack.NewTextBlockObject(slack.PlainTextType, list.locale().T(i18n.EmailListExportCSV), false, false))
+	refresh := slack.NewButtonBlockElement(ActionIDRefresh, string(value),
+		slack.NewTextBlockObject(slack.PlainTextType, list.locale().T(i18n.EmailListRefresh), false, false))
+	blocks = append(blocks, slack.NewActionBlock(blockIDEmailListActions, createEvent, exportCSV, refresh))
+	return blocks, nil
+}
+
+// EmailAddress returns the email of the user, or "Name <email>" if withNames,
+// which Google Calendar and Outlook read as the guest with the name
+func EmailAddress(email *model.SlackUserEmail, withNames bool) string {
+	name := email.Name()
+	if !withNames || name == "" {
+		return email.Email
+	}
+	return quoteName(name) + " <" + email.Email + ">"
+}
+
+// quoteName quotes the name if it has the special characters of RFC 5322,
+// such as the commas which would split the name in the comma-separated list
+func quoteName(name string) string {
+	if !strings.ContainsAny(name, `"(),.:;<>@[\]`) {
+		return name
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name) + `"`
+}
+
+var unresolvedUsersKeys = map[model.SlackUserStatus]i18n.Key{
+	model.SlackUserStatusNoEmail: i18n.EmailListUnknownEmails,
+	model.SlackUserStatusGuest:   i18n.EmailListGuests,
+	model.SlackUserStatusBot:     i18n.EmailListBots,
+	model.SlackUserStatusDeleted: i18n.EmailListDeletedUsers,
+}
+
+// UnresolvedUsersTexts mentions the users whose emails are not listed, one text for each reason,
+// so that the organizer can invite them manually
+func UnresolvedUsersTexts(locale i18n.Locale, emails []*model.SlackUserEmail) []string {
+	mentions := map[model.SlackUserStatus][]string{}
+	for _, email := range emails {
+		if email.Resolved() {
+			continue
+		}
+		status := email.Status
+		if _, ok := unresolvedUsersKeys[status]; !ok {
+			status = model.SlackUserStatusNoEmail
+		}
+		mentions[status] = append(mentions[status], "<@"+email.ID+">")
+	}
+	var texts []string
+	for _, status := range model.UnresolvedSlackUserStatuses {
+		if len(mentions[status]) > 0 {
+			texts = append(texts, locale.T(unresolvedUsersKeys[status], strings.Join(mentions[status], " ")))
+		}
+	}
+	return texts
+}
+
+// joinInChunks joins the words with sep into chunks up to size bytes
+func joinInChunks(words []string, sep string, size int) []string {
+	var chunks []string
+	var b strings.Builder
+	for _, word := range words {
+		if b.Len() > 0 && b.Len()+len(sep)+len(word) > size {
+			chunks = append(chunks, b.String())
+			b.Reset()
+		}
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(word)
+	}
+	if b.Len() > 0 {
+		chunks = append(chunks, b.String())
+	}
+	return chunks
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package renderer
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/moneyforward/auriga/app/internal/i18n"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/slack-go/slack"
+)
+
+// update rewrites the golden files with the current output: go test ./internal/renderer -update
+var update = flag.Bool("update", false, "update the golden files")
+
+// assertGolden compares the JSON payload of the blocks with testdata/<name>.golden.json
+func assertGolden(t *testing.T, name string, blocks []slack.Block) {
+	t.Helper()
+	got, err := json.MarshalIndent(slack.Blocks{BlockSet: blocks}, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("blocks of %s differ from the golden file\ngot:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestEmailListBlocks(t *testing.T) {
+	manyEmails := make([]*model.SlackUserEmail, 0, 120)
+	for i := 0; i < 120; i++ {
+		manyEmails = append(manyEmails, &model.SlackUserEmail{ID: fmt.Sprintf("U%03d", i), Email: fmt.Sprintf("user_%03d@long-example-domain.com", i), Status: model.SlackUserStatusOK})
+	}
+	tests := []struct {
+		name string
+		list *EmailList
+	}{
+		{
+			name: "email_list",
+			list: &EmailList{
+				ChannelID: "C01",
+				TimeStamp: "1667283600.000100",
+				Reactions: &model.ReactionFilter{Any: []string{"sanka", "maybe"}, All: []string{"onsite"}, Exclude: []string{"absent"}},
+				Emails: []*model.SlackUserEmail{
+					{ID: "U01", Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{ID: "U02", Email: "sample02@example.com", Status: model.SlackUserStatusOK},
+					{ID: "U03", Status: model.SlackUserStatusNoEmail},
+					{ID: "U04", Email: "bot@example.com", Status: model.SlackUserStatusBot},
+					{ID: "U05", Status: model.SlackUserStatusGuest},
+				},
+			},
+		},
+		{
+			name: "email_list_without_emails",
+			list: &EmailList{
+				ChannelID: "C01",
+				TimeStamp: "1667283600.000100",
+				Reactions: &model.ReactionFilter{Any: []string{"sanka"}},
+				Emails:    []*model.SlackUserEmail{{ID: "U03", Status: model.SlackUserStatusNoEmail}},
+			},
+		},
+		{
+			name: "email_list_en",
+			list: &EmailList{
+				ChannelID: "C01",
+				TimeStamp: "1667283600.000100",
+				Reactions: &model.ReactionFilter{Any: []string{"sanka"}},
+				Emails: []*model.SlackUserEmail{
+					{ID: "U01", Email: "sample01@example.com", Status: model.SlackUserStatusOK},
+					{ID: "U03", Status: model.SlackUserStatusDeleted},
+				},
+				Locale: i18n.English,
+			},
+		},
+		{
+			name: "email_list_with_names",
+			list: &EmailList{
+				ChannelID: "C01",
+				TimeStamp: "1667283600.000100",
+				Reactions: &model.ReactionFilter{Any: []string{"sanka"}},
+				Emails: []*model.SlackUserEmail{
+					{ID: "U01", Email: "sample01@example.com", Status: model.SlackUserStatusOK, DisplayName: "sample01"},
+					{ID: "U02", Email: "sample02@example.com", Status: model.SlackUserStatusOK, RealName: "Doe, John"},
+					{ID: "U03", Email: "sample03@example.com", Status: model.SlackUserStatusOK},
+				},
+				WithNames: true,
+			},
+		},
+		{
+			name: "email_list_long",
+			list: &EmailList{
+				ChannelID: "C01",
+				TimeStamp: "1667283600.000100",
+				Reactions: &model.ReactionFilter{Any: []string{"sanka"}},
+				Emails:    manyEmails,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := EmailListBlocks(tt.list)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, tt.name, blocks)
+			for _, block := range blocks {
+				if section, ok := block.(*slack.SectionBlock); ok && len(section.Text.Text) > maxSectionTextLength {
+					t.Errorf("section is too long: %d", len(section.Text.Text))
+				}
+			}
+		})
+	}
+}
+
+func TestEmailAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     *model.SlackUserEmail
+		withNames bool
+		want      string
+	}{
+		{
+			name:  "OK: without names",
+			email: &model.SlackUserEmail{Email: "sample@example.com", DisplayName: "sample"},
+			want:  "sample@example.com",
+		},
+		{
+			name:      "OK: display name",
+			email:     &model.SlackUserEmail{Email: "sample@example.com", DisplayName: "sample", RealName: "Sample User"},
+			withNames: true,
+			want:      "sample <sample@example.com>",
+		},
+		{
+			name:      "OK: real name without display name",
+			email:     &model.SlackUserEmail{Email: "sample@example.com", RealName: "山田 太郎"},
+			withNames: true,
+			want:      "山田 太郎 <sample@example.com>",
+		},
+		{
+			name:      "OK: quoted name",
+			email:     &model.SlackUserEmail{Email: "sample@example.com", RealName: `Doe, "JD" John`},
+			withNames: true,
+			want:      `"Doe, \"JD\" John" <sample@example.com>`,
+		},
+		{
+			name:      "OK: no name",
+			email:     &model.SlackUserEmail{Email: "sample@example.com"},
+			withNames: true,
+			want:      "sample@example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EmailAddress(tt.email, tt.withNames); got != tt.want {
+				t.Errorf("EmailAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseActionValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *ActionValue
+		wantErr bool
+	}{
+		{
+			name:  "OK",
+			value: `{"channel_id":"C01","ts":"1667283600.000100","reactions":{"Any":["sanka"],"All":null,"Exclude":["absent"]}}`,
+			want: &ActionValue{
+				ChannelID: "C01",
+				TimeStamp: "1667283600.000100",
+				Reactions: &model.ReactionFilter{Any: []string{"sanka"}, Exclude: []string{"absent"}},
+			},
+		},
+		{
+			name:    "NG: not JSON",
+			value:   "sample",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseActionValue(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseActionValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseActionValue() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_joinInChunks(t *testing.T) {
+	got := joinInChunks([]string{"aaaa", "bbbb", "cccc"}, ", ", 10)
+	if want := []string{"aaaa, bbbb", "cccc"}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("joinInChunks() = %v, want %v", got, want)
+	}
+}
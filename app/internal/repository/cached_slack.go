@@ -0,0 +1,108 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/cache"
+)
+
+// cachedSlackRepository keeps the users looked up by ListUsersEmail in the cache,
+// so that the threads with hundreds of reactions do not call users.info for everyone every time.
+// The errors of the cache are logged and the users are looked up as if they were not cached.
+type cachedSlackRepository struct {
+	repository.SlackRepository
+	cache cache.Store
+}
+
+func newCachedSlackRepository(r repository.SlackRepository, store cache.Store) *cachedSlackRepository {
+	return &cachedSlackRepository{
+		SlackRepository: r,
+		cache:           store,
+	}
+}
+
+func userCacheKey(userID string) string {
+	return "user:" + userID
+}
+
+// ListUsersEmail looks up only the users missing in the cache, and returns the users in the order of userIDs
+func (r *cachedSlackRepository) ListUsersEmail(ctx context.Context, userIDs []string) ([]*model.SlackUserEmail, error) {
+	cached := make(map[string]*model.SlackUserEmail, len(userIDs))
+	var missing []string
+	for _, userID := range userIDs {
+		if email := r.get(ctx, userID); email != nil {
+			cached[userID] = email
+		} else {
+			missing = append(missing, userID)
+		}
+	}
+	if len(missing) > 0 {
+		emails, err := r.SlackRepository.ListUsersEmail(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, email := range emails {
+			r.set(ctx, email)
+			cached[email.ID] = email
+		}
+	}
+	emails := make([]*model.SlackUserEmail, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if email, ok := cached[userID]; ok {
+			emails = append(emails, email)
+		}
+	}
+	return emails, nil
+}
+
+func (r *cachedSlackRepository) InvalidateUser(ctx context.Context, userID string) error {
+	return r.cache.Delete(ctx, userCacheKey(userID))
+}
+
+func (r *cachedSlackRepository) get(ctx context.Context, userID string) *model.SlackUserEmail {
+	value, ok, err := r.cache.Get(ctx, userCacheKey(userID))
+	if err != nil {
+		log.Printf("Failed to get %s from the cache: %v", userID, err)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	var email model.SlackUserEmail
+	if err := json.Unmarshal(value, &email); err != nil {
+		log.Printf("Failed to read %s from the cache: %v", userID, err)
+		return nil
+	}
+	return &email
+}
+
+func (r *cachedSlackRepository) set(ctx context.Context, email *model.SlackUserEmail) {
+	value, err := json.Marshal(email)
+	if err != nil {
+		log.Printf("Failed to write %s to the cache: %v", email.ID, err)
+		return
+	}
+	if err := r.cache.Set(ctx, userCacheKey(email.ID), value); err != nil {
+		log.Printf("Failed to set %s to the cache: %v", email.ID, err)
+	}
+}
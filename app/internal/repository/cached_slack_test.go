@@ -0,0 +1,90 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mock_repository "github.com/moneyforward/auriga/app/internal/domain/repository/mock"
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/cache"
+)
+
+func Test_cachedSlackRepository_ListUsersEmail(t *testing.T) {
+	user01 := &model.SlackUserEmail{ID: "user01", Email: "user01@example.com", Status: model.SlackUserStatusOK}
+	user02 := &model.SlackUserEmail{ID: "user02", Email: "user02@example.com", Status: model.SlackUserStatusOK}
+	user03 := &model.SlackUserEmail{ID: "user03", Status: model.SlackUserStatusNoEmail}
+	tests := []struct {
+		name       string
+		invalidate string
+		prepare    func(msr *mock_repository.MockSlackRepository)
+		want       []*model.SlackUserEmail
+	}{
+		{
+			name: "OK: only the missing users are looked up",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user01", "user02"}).Return([]*model.SlackUserEmail{user01, user02}, nil),
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user03"}).Return([]*model.SlackUserEmail{user03}, nil),
+				)
+			},
+			want: []*model.SlackUserEmail{user03, user02, user01},
+		},
+		{
+			name:       "OK: the invalidated user is looked up again",
+			invalidate: "user02",
+			prepare: func(msr *mock_repository.MockSlackRepository) {
+				gomock.InOrder(
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user01", "user02"}).Return([]*model.SlackUserEmail{user01, user02}, nil),
+					msr.EXPECT().ListUsersEmail(gomock.Any(), []string{"user03", "user02"}).Return([]*model.SlackUserEmail{user03, user02}, nil),
+				)
+			},
+			want: []*model.SlackUserEmail{user03, user02, user01},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msr := mock_repository.NewMockSlackRepository(ctrl)
+			tt.prepare(msr)
+			r := newCachedSlackRepository(msr, cache.NewLRUStore(10, time.Hour))
+
+			if _, err := r.ListUsersEmail(ctx, []string{"user01", "user02"}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.invalidate != "" {
+				if err := r.InvalidateUser(ctx, tt.invalidate); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := r.ListUsersEmail(ctx, []string{"user03", "user02", "user01"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListUsersEmail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
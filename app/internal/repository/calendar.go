@@ -0,0 +1,134 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/google/calendar"
+)
+
+type calendarRepository struct {
+	client     calendar.Client
+	calendarID string
+	// timeZone is the IANA time zone name of the events, or empty to create them in UTC
+	timeZone string
+}
+
+func newCalendarRepository(client calendar.Client, calendarID, timeZone string) *calendarRepository {
+	return &calendarRepository{
+		client:     client,
+		calendarID: calendarID,
+		timeZone:   timeZone,
+	}
+}
+
+func (r *calendarRepository) CreateEvent(ctx context.Context, event *model.CalendarEvent) (*model.CalendarEvent, error) {
+	if r.client == nil {
+		return nil, errCalendarNotConfigured
+	}
+	attendees := make([]*calendar.Attendee, 0, len(event.AttendeeEmails))
+	for _, email := range event.AttendeeEmails {
+		attendees = append(attendees, &calendar.Attendee{Email: email})
+	}
+	created, err := r.client.InsertEvent(ctx, r.calendarID, &calendar.Event{
+		Summary:     event.Title,
+		Description: event.Description,
+		Start:       toEventTime(event.Start, r.timeZone),
+		End:         toEventTime(event.End, r.timeZone),
+		Attendees:   attendees,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCalendarEvent(created, event), nil
+}
+
+func (r *calendarRepository) UpdateAttendees(ctx context.Context, eventID string, added, removed []string) (*model.AttendeeChange, error) {
+	if r.client == nil {
+		return nil, errCalendarNotConfigured
+	}
+	event, err := r.client.GetEvent(ctx, r.calendarID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	// the addresses are case-insensitive
+	toRemove := make(map[string]bool, len(removed))
+	for _, email := range removed {
+		toRemove[strings.ToLower(email)] = true
+	}
+	change := &model.AttendeeChange{}
+	attendees := make([]*calendar.Attendee, 0, len(event.Attendees)+len(added))
+	invited := make(map[string]bool, len(event.Attendees)+len(added))
+	for _, attendee := range event.Attendees {
+		if toRemove[strings.ToLower(attendee.Email)] {
+			change.Removed = append(change.Removed, attendee.Email)
+			continue
+		}
+		invited[strings.ToLower(attendee.Email)] = true
+		attendees = append(attendees, attendee)
+	}
+	for _, email := range added {
+		if invited[strings.ToLower(email)] {
+			continue
+		}
+		invited[strings.ToLower(email)] = true
+		change.Added = append(change.Added, email)
+		attendees = append(attendees, &calendar.Attendee{Email: email})
+	}
+	if change.IsEmpty() {
+		return change, nil
+	}
+	if _, err := r.client.PatchAttendees(ctx, r.calendarID, eventID, attendees); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// toEventTime converts the time with the IANA time zone name.
+// The name of t.Location() is not used, since it may be "Local" or the name of a fixed zone,
+// which Google Calendar rejects.
+func toEventTime(t time.Time, timeZone string) *calendar.EventTime {
+	if timeZone == "" {
+		return &calendar.EventTime{DateTime: t.UTC().Format(time.RFC3339)}
+	}
+	return &calendar.EventTime{
+		DateTime: t.Format(time.RFC3339),
+		TimeZone: timeZone,
+	}
+}
+
+// toCalendarEvent converts the API response, falling back to the requested event for times
+// which the API returns in the calendar's time zone
+func toCalendarEvent(e *calendar.Event, requested *model.CalendarEvent) *model.CalendarEvent {
+	emails := make([]string, 0, len(e.Attendees))
+	for _, attendee := range e.Attendees {
+		emails = append(emails, attendee.Email)
+	}
+	return &model.CalendarEvent{
+		ID:             e.ID,
+		Title:          e.Summary,
+		Description:    e.Description,
+		Start:          requested.Start,
+		End:            requested.End,
+		AttendeeEmails: emails,
+		URL:            e.HTMLLink,
+	}
+}
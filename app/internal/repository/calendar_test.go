@@ -0,0 +1,132 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/google/calendar"
+)
+
+// fakeCalendarClient keeps the attendees of an event
+type fakeCalendarClient struct {
+	calendar.Client
+	attendees []*calendar.Attendee
+	patched   bool
+}
+
+func (c *fakeCalendarClient) GetEvent(ctx context.Context, calendarID, eventID string) (*calendar.Event, error) {
+	return &calendar.Event{ID: eventID, Attendees: c.attendees}, nil
+}
+
+func (c *fakeCalendarClient) PatchAttendees(ctx context.Context, calendarID, eventID string, attendees []*calendar.Attendee) (*calendar.Event, error) {
+	c.attendees = attendees
+	c.patched = true
+	return &calendar.Event{ID: eventID, Attendees: attendees}, nil
+}
+
+func Test_calendarRepository_UpdateAttendees(t *testing.T) {
+	tests := []struct {
+		name          string
+		attendees     []*calendar.Attendee
+		added         []string
+		removed       []string
+		want          *model.AttendeeChange
+		wantAttendees []*calendar.Attendee
+		wantPatched   bool
+	}{
+		{
+			name: "OK: the other attendees are kept",
+			attendees: []*calendar.Attendee{
+				{Email: "owner@example.com", ResponseStatus: "accepted"},
+				{Email: "User01@example.com", ResponseStatus: "accepted"},
+				{Email: "user02@example.com", Optional: true},
+			},
+			added:   []string{"user03@example.com", "user02@example.com"},
+			removed: []string{"user01@example.com", "user04@example.com"},
+			want:    &model.AttendeeChange{Added: []string{"user03@example.com"}, Removed: []string{"User01@example.com"}},
+			wantAttendees: []*calendar.Attendee{
+				{Email: "owner@example.com", ResponseStatus: "accepted"},
+				{Email: "user02@example.com", Optional: true},
+				{Email: "user03@example.com"},
+			},
+			wantPatched: true,
+		},
+		{
+			name:          "OK: not patched without changes",
+			attendees:     []*calendar.Attendee{{Email: "user01@example.com"}},
+			added:         []string{"user01@example.com"},
+			removed:       []string{"user02@example.com"},
+			want:          &model.AttendeeChange{},
+			wantAttendees: []*calendar.Attendee{{Email: "user01@example.com"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeCalendarClient{attendees: tt.attendees}
+			r := newCalendarRepository(client, "primary", "Asia/Tokyo")
+			got, err := r.UpdateAttendees(context.Background(), "event01", tt.added, tt.removed)
+			if err != nil {
+				t.Fatalf("UpdateAttendees() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UpdateAttendees() got = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(client.attendees, tt.wantAttendees) || client.patched != tt.wantPatched {
+				t.Errorf("attendees = %+v (patched %v), want %+v (patched %v)", client.attendees, client.patched, tt.wantAttendees, tt.wantPatched)
+			}
+		})
+	}
+}
+
+func Test_toEventTime(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name     string
+		time     time.Time
+		timeZone string
+		want     *calendar.EventTime
+	}{
+		{
+			name:     "OK: the time zone name",
+			time:     time.Date(2026, 11, 5, 14, 0, 0, 0, jst),
+			timeZone: "Asia/Tokyo",
+			want:     &calendar.EventTime{DateTime: "2026-11-05T14:00:00+09:00", TimeZone: "Asia/Tokyo"},
+		},
+		{
+			name: "OK: UTC without the time zone name",
+			time: time.Date(2026, 11, 5, 14, 0, 0, 0, jst),
+			want: &calendar.EventTime{DateTime: "2026-11-05T05:00:00Z"},
+		},
+		{
+			name: "OK: not the name of time.Local",
+			time: time.Date(2026, 11, 5, 14, 0, 0, 0, time.Local),
+			want: &calendar.EventTime{DateTime: time.Date(2026, 11, 5, 14, 0, 0, 0, time.Local).UTC().Format(time.RFC3339)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toEventTime(tt.time, tt.timeZone); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toEventTime() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
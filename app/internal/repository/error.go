@@ -21,6 +21,10 @@ import "errors"
 var (
 	errThreadNotfound = errors.New("thread_not_found")
 	errUserNotFound   = errors.New("user_not_found")
+	// errMembersNotFound is returned when the user group or the channel is not found, or Auriga has not joined the channel
+	errMembersNotFound = errors.New("members_not_found")
+
+	errCalendarNotConfigured = errors.New("calendar_not_configured")
 )
 
 type errorRepository struct {
@@ -37,3 +41,11 @@ func (r *errorRepository) ErrThreadNotFound(err error) bool {
 func (r *errorRepository) ErrUserNotFound(err error) bool {
 	return errors.Is(err, errUserNotFound)
 }
+
+func (r *errorRepository) ErrMembersNotFound(err error) bool {
+	return errors.Is(err, errMembersNotFound)
+}
+
+func (r *errorRepository) ErrCalendarNotConfigured(err error) bool {
+	return errors.Is(err, errCalendarNotConfigured)
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/internal/model"
+)
+
+const (
+	// EventBindingRetention keeps the bindings for a while after the events end
+	EventBindingRetention = 7 * 24 * time.Hour
+	// eventBindingLockLease is long enough to sync the attendees of an event
+	eventBindingLockLease = time.Minute
+)
+
+// eventBindingRepository keeps the bindings as the states
+type eventBindingRepository struct {
+	stateRepository repository.StateRepository
+}
+
+func newEventBindingRepository(stateRepository repository.StateRepository) *eventBindingRepository {
+	return &eventBindingRepository{
+		stateRepository: stateRepository,
+	}
+}
+
+func eventBindingKey(channelID, ts string) string {
+	return channelID + ":" + ts
+}
+
+func (r *eventBindingRepository) SaveEventBinding(ctx context.Context, binding *model.EventBinding) error {
+	var expiresAt time.Time
+	if !binding.End.IsZero() {
+		expiresAt = binding.End.Add(EventBindingRetention)
+	}
+	return r.stateRepository.PutState(ctx, repository.StateKindEventBinding, eventBindingKey(binding.ChannelID, binding.TimeStamp), binding, expiresAt)
+}
+
+func (r *eventBindingRepository) GetEventBinding(ctx context.Context, channelID, ts string) (*model.EventBinding, error) {
+	var binding model.EventBinding
+	ok, err := r.stateRepository.GetState(ctx, repository.StateKindEventBinding, eventBindingKey(channelID, ts), &binding)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &binding, nil
+}
+
+func (r *eventBindingRepository) LockEventBinding(ctx context.Context, channelID, ts string) (func(), error) {
+	return r.stateRepository.LockState(ctx, repository.StateKindEventBinding, eventBindingKey(channelID, ts), eventBindingLockLease)
+}
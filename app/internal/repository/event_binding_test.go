@@ -0,0 +1,70 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/model"
+	"github.com/moneyforward/auriga/app/pkg/state"
+)
+
+func Test_eventBindingRepository(t *testing.T) {
+	end := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name          string
+		binding       *model.EventBinding
+		wantExpiresAt time.Time
+	}{
+		{
+			name:          "OK: kept for a while after the event ends",
+			binding:       &model.EventBinding{ChannelID: "C01", TimeStamp: "1667283600.123456", EventID: "event01", End: end},
+			wantExpiresAt: end.Add(EventBindingRetention),
+		},
+		{
+			name:    "OK: kept without the end",
+			binding: &model.EventBinding{ChannelID: "C01", TimeStamp: "1667283600.123456", EventID: "event01"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := state.NewMemoryStore()
+			r := newEventBindingRepository(newStateRepository(store))
+			if err := r.SaveEventBinding(ctx, tt.binding); err != nil {
+				t.Fatal(err)
+			}
+			record, err := store.Get(ctx, "event_binding:C01:1667283600.123456")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if record == nil || !record.ExpiresAt.Equal(tt.wantExpiresAt) {
+				t.Fatalf("SaveEventBinding() stored %+v, want expiring at %v", record, tt.wantExpiresAt)
+			}
+			got, err := r.GetEventBinding(ctx, "C01", "1667283600.123456")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.binding) {
+				t.Errorf("GetEventBinding() = %+v, want %+v", got, tt.binding)
+			}
+		})
+	}
+}
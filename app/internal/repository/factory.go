@@ -18,23 +18,81 @@ package repository
 
 import (
 	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/pkg/cache"
+	"github.com/moneyforward/auriga/app/pkg/google/calendar"
 	"github.com/moneyforward/auriga/app/pkg/slack"
+	"github.com/moneyforward/auriga/app/pkg/state"
 )
 
 type factory struct {
-	client slack.Client
+	client         slack.Client
+	calendarClient calendar.Client
+	calendarID     string
+	timeZone       string
+	userCache      cache.Store
+	stateStore     state.Store
 }
 
-func NewFactory(client slack.Client) *factory {
-	return &factory{
-		client: client,
+type Option func(*factory)
+
+// UserCacheOption caches the users looked up from Slack in store
+func UserCacheOption(store cache.Store) Option {
+	return func(f *factory) {
+		f.userCache = store
+	}
+}
+
+// TimeZoneOption creates the calendar events in the IANA time zone of name, which are created in UTC otherwise
+func TimeZoneOption(name string) Option {
+	return func(f *factory) {
+		f.timeZone = name
+	}
+}
+
+// StateStoreOption keeps the state of the bot such as the bindings of the messages and the calendar events in store,
+// which is kept in memory otherwise
+func StateStoreOption(store state.Store) Option {
+	return func(f *factory) {
+		f.stateStore = store
 	}
 }
 
+// NewFactory builds a repository factory.
+// calendarClient may be nil when the Google Calendar integration is not configured.
+func NewFactory(client slack.Client, calendarClient calendar.Client, calendarID string, opts ...Option) *factory {
+	f := &factory{
+		client:         client,
+		calendarClient: calendarClient,
+		calendarID:     calendarID,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if f.stateStore == nil {
+		f.stateStore = state.NewMemoryStore()
+	}
+	return f
+}
+
 func (f *factory) SlackRepository() repository.SlackRepository {
+	if f.userCache != nil {
+		return newCachedSlackRepository(newSlackRepository(f.client), f.userCache)
+	}
 	return newSlackRepository(f.client)
 }
 
 func (f *factory) ErrorRepository() repository.ErrorRepository {
 	return newErrorRepository()
 }
+
+func (f *factory) CalendarRepository() repository.CalendarRepository {
+	return newCalendarRepository(f.calendarClient, f.calendarID, f.timeZone)
+}
+
+func (f *factory) EventBindingRepository() repository.EventBindingRepository {
+	return newEventBindingRepository(f.StateRepository())
+}
+
+func (f *factory) StateRepository() repository.StateRepository {
+	return newStateRepository(f.stateStore)
+}
@@ -18,6 +18,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 
 	"github.com/slack-go/slack"
 
@@ -28,6 +29,9 @@ import (
 	"github.com/moneyforward/auriga/app/pkg/errors"
 )
 
+// slackbotUserID is the ID of Slackbot, which is not flagged as a bot
+const slackbotUserID = "USLACKBOT"
+
 type slackRepository struct {
 	client pkgslack.Client
 }
@@ -46,6 +50,30 @@ func (r *slackRepository) PostEphemeral(ctx context.Context, channelID, message,
 	return r.client.PostEphemeral(ctx, channelID, userID, ts, message)
 }
 
+func (r *slackRepository) PostBlocks(ctx context.Context, channelID, ts, text string, blocks []slack.Block) error {
+	return r.client.PostBlocks(ctx, channelID, ts, text, blocks)
+}
+
+func (r *slackRepository) PostResponse(ctx context.Context, responseURL, message string, inChannel bool) error {
+	return r.client.PostResponse(ctx, responseURL, message, inChannel)
+}
+
+func (r *slackRepository) ReplaceResponse(ctx context.Context, responseURL, text string, blocks []slack.Block) error {
+	return r.client.ReplaceResponse(ctx, responseURL, text, blocks)
+}
+
+func (r *slackRepository) OpenView(ctx context.Context, triggerID string, view slack.ModalViewRequest) error {
+	return r.client.OpenView(ctx, triggerID, view)
+}
+
+func (r *slackRepository) UpdateView(ctx context.Context, viewID, hash string, view slack.ModalViewRequest) error {
+	return r.client.UpdateView(ctx, viewID, hash, view)
+}
+
+func (r *slackRepository) UploadFile(ctx context.Context, channelID, ts, filename, content, comment string) error {
+	return r.client.UploadFile(ctx, channelID, ts, filename, content, comment)
+}
+
 // GetParentMessage get the first message that started the thread
 func (r *slackRepository) GetParentMessage(ctx context.Context, channelID, ts string) (*model.SlackMessage, error) {
 	msgs, err := r.client.GetConversationReplies(ctx, channelID, ts)
@@ -77,10 +105,70 @@ func (r *slackRepository) GetParentMessage(ctx context.Context, channelID, ts st
 	}
 	return &model.SlackMessage{
 		ChannelID: parentMessage.Channel,
+		Text:      parentMessage.Text,
 		Reactions: reactions,
 	}, nil
 }
 
+func (r *slackRepository) ListThreadReplies(ctx context.Context, channelID, ts string) ([]*model.SlackReply, error) {
+	msgs, err := r.client.GetThreadReplies(ctx, channelID, ts)
+	if err != nil {
+		if errors.Is(err, pkgslack.ErrThreadNotFound) {
+			return nil, errThreadNotfound
+		}
+		return nil, err
+	}
+	appUserID := r.client.GetAppUserID()
+	replies := make([]*model.SlackReply, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Timestamp == ts || msg.User == "" || msg.BotID != "" || msg.User == appUserID || msg.User == slackbotUserID {
+			continue
+		}
+		if strings.Contains(msg.Text, "<@"+appUserID+">") {
+			// the mentions calling Auriga are not the answers
+			continue
+		}
+		replies = append(replies, &model.SlackReply{
+			UserID: msg.User,
+			Text:   msg.Text,
+		})
+	}
+	return replies, nil
+}
+
+func (r *slackRepository) ListUserGroupMembers(ctx context.Context, userGroupID string) ([]string, error) {
+	members, err := r.client.GetUserGroupMembers(ctx, userGroupID)
+	if err != nil {
+		if errors.Is(err, pkgslack.ErrUserGroupNotFound) {
+			return nil, errMembersNotFound
+		}
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *slackRepository) ListChannelMembers(ctx context.Context, channelID string) ([]string, error) {
+	members, err := r.client.GetChannelMembers(ctx, channelID)
+	if err != nil {
+		if errors.Is(err, pkgslack.ErrChannelNotFound) {
+			return nil, errMembersNotFound
+		}
+		return nil, err
+	}
+	appUserID := r.client.GetAppUserID()
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		if member != appUserID {
+			userIDs = append(userIDs, member)
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *slackRepository) GetPermalink(ctx context.Context, channelID, ts string) (string, error) {
+	return r.client.GetPermalink(ctx, channelID, ts)
+}
+
 // isIncompleteReaction returns true if more fetches is required
 // reactions[*].Count may be greater than len(reactions[*].Users), at which point a fetch is required.
 func (r *slackRepository) isIncompleteReaction(reactions []slack.ItemReaction) bool {
@@ -92,6 +180,35 @@ func (r *slackRepository) isIncompleteReaction(reactions []slack.ItemReaction) b
 	return false
 }
 
+func (r *slackRepository) GetUserTimeZone(ctx context.Context, userID string) (string, error) {
+	users, err := r.client.GetUsersInfo(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pkgslack.ErrUserNotFound) {
+			return "", errUserNotFound
+		} else {
+			return "", err
+		}
+	}
+	for _, user := range *users {
+		return user.TZ, nil
+	}
+	return "", errUserNotFound
+}
+
+func (r *slackRepository) GetUserLocale(ctx context.Context, userID string) (string, error) {
+	users, err := r.client.GetUsersInfo(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pkgslack.ErrUserNotFound) {
+			return "", errUserNotFound
+		}
+		return "", err
+	}
+	for _, user := range *users {
+		return user.Locale, nil
+	}
+	return "", errUserNotFound
+}
+
 func (r *slackRepository) ListUsersEmail(ctx context.Context, userID []string) ([]*model.SlackUserEmail, error) {
 	users, err := r.client.GetUsersInfo(ctx, userID...)
 	if err != nil {
@@ -105,9 +222,47 @@ func (r *slackRepository) ListUsersEmail(ctx context.Context, userID []string) (
 	var slackUsers []*model.SlackUserEmail
 	for _, user := range *users {
 		slackUsers = append(slackUsers, &model.SlackUserEmail{
-			ID:    user.ID,
-			Email: user.Profile.Email,
+			ID:          user.ID,
+			Email:       user.Profile.Email,
+			Status:      userStatus(&user),
+			IsGuest:     user.IsRestricted || user.IsUltraRestricted,
+			IsExternal:  isExternal(&user, r.client.GetTeamID(), r.client.GetEnterpriseID()),
+			DisplayName: user.Profile.DisplayName,
+			RealName:    user.Profile.RealName,
 		})
 	}
 	return slackUsers, nil
 }
+
+// InvalidateUser does nothing since the users are not cached
+func (r *slackRepository) InvalidateUser(ctx context.Context, userID string) error {
+	return nil
+}
+
+// isExternal tells whether the user belongs to another organization, as in a Slack Connect channel.
+// The members of the other workspaces in the same Enterprise Grid organization are not external.
+func isExternal(user *slack.User, teamID, enterpriseID string) bool {
+	if user.IsStranger {
+		return true
+	}
+	if user.TeamID == "" || user.TeamID == teamID {
+		return false
+	}
+	return enterpriseID == "" || user.Enterprise.EnterpriseID != enterpriseID
+}
+
+// userStatus tells whether the email of the user can be invited
+func userStatus(user *slack.User) model.SlackUserStatus {
+	switch {
+	case user.Deleted:
+		return model.SlackUserStatusDeleted
+	case user.IsBot || user.ID == slackbotUserID:
+		return model.SlackUserStatusBot
+	case user.Profile.Email != "":
+		return model.SlackUserStatusOK
+	case user.IsRestricted || user.IsUltraRestricted:
+		return model.SlackUserStatusGuest
+	default:
+		return model.SlackUserStatusNoEmail
+	}
+}
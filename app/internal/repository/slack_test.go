@@ -0,0 +1,66 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func Test_isExternal(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         *slack.User
+		enterpriseID string
+		want         bool
+	}{
+		{
+			name: "OK: member of the workspace",
+			user: &slack.User{ID: "U01", TeamID: "T01"},
+		},
+		{
+			name: "OK: member of another workspace",
+			user: &slack.User{ID: "U02", TeamID: "T02"},
+			want: true,
+		},
+		{
+			name:         "OK: member of another workspace in the same Enterprise Grid",
+			user:         &slack.User{ID: "U03", TeamID: "T02", Enterprise: slack.EnterpriseUser{EnterpriseID: "E01"}},
+			enterpriseID: "E01",
+		},
+		{
+			name:         "OK: member of another Enterprise Grid",
+			user:         &slack.User{ID: "U04", TeamID: "T03", Enterprise: slack.EnterpriseUser{EnterpriseID: "E02"}},
+			enterpriseID: "E01",
+			want:         true,
+		},
+		{
+			name:         "OK: stranger",
+			user:         &slack.User{ID: "U05", IsStranger: true},
+			enterpriseID: "E01",
+			want:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isExternal(tt.user, "T01", tt.enterpriseID); got != tt.want {
+				t.Errorf("isExternal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
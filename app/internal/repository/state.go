@@ -0,0 +1,172 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/moneyforward/auriga/app/internal/domain/repository"
+	"github.com/moneyforward/auriga/app/pkg/errors"
+	"github.com/moneyforward/auriga/app/pkg/state"
+)
+
+// stateMigrations upgrades the states written by the older versions of Auriga, by the kind.
+// Append a migration when the JSON of a kind changes incompatibly.
+var stateMigrations = map[string]state.Migrations{
+	repository.StateKindEventBinding: {},
+}
+
+// stateLockInterval is how often LockState tries again to take the lock held by the other
+const stateLockInterval = 200 * time.Millisecond
+
+// stateRepository keeps the states in the store as JSON
+type stateRepository struct {
+	store      state.Store
+	migrations map[string]state.Migrations
+}
+
+func newStateRepository(store state.Store) *stateRepository {
+	return &stateRepository{
+		store:      store,
+		migrations: stateMigrations,
+	}
+}
+
+func stateKey(kind, key string) string {
+	return kind + ":" + key
+}
+
+func (r *stateRepository) GetState(ctx context.Context, kind, key string, value interface{}) (bool, error) {
+	record, err := r.store.Get(ctx, stateKey(kind, key))
+	if err != nil || record == nil {
+		return false, err
+	}
+	data, err := r.migrations[kind].Migrate(record.Value, record.Version)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to migrate the state %s", record.Key)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *stateRepository) PutState(ctx context.Context, kind, key string, value interface{}, expiresAt time.Time) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.store.Put(ctx, &state.Record{
+		Key:       stateKey(kind, key),
+		Value:     data,
+		Version:   r.migrations[kind].Version(),
+		ExpiresAt: expiresAt,
+	})
+}
+
+func (r *stateRepository) DeleteState(ctx context.Context, kind, key string) error {
+	return r.store.Delete(ctx, stateKey(kind, key))
+}
+
+// LockState takes the lock by claiming the record of the lock in the store, whose value is a random token of the holder.
+// The holder renews the lease while it holds the lock, so the record outlives only the lock of a stopped holder,
+// and releasing the lock never removes the one taken by the other after the lease.
+func (r *stateRepository) LockState(ctx context.Context, kind, key string, lease time.Duration) (func(), error) {
+	lockKey := "lock:" + stateKey(kind, key)
+	owner, err := newLockOwner()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to lock %s", lockKey)
+	}
+	claim := func(ctx context.Context) (bool, error) {
+		return r.store.Claim(ctx, &state.Record{
+			Key:       lockKey,
+			Value:     owner,
+			Version:   1,
+			ExpiresAt: time.Now().Add(lease),
+		})
+	}
+	for {
+		ok, err := claim(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to lock %s", lockKey)
+		}
+		if ok {
+			return r.holdLock(ctx, lockKey, owner, lease, claim), nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "gave up locking %s", lockKey)
+		case <-time.After(stateLockInterval):
+		}
+	}
+}
+
+// holdLock renews the lease of the lock until the returned func releases it, or until ctx is done
+// when the holder has given up, after which the lock expires at the end of the lease
+func (r *stateRepository) holdLock(ctx context.Context, lockKey string, owner json.RawMessage, lease time.Duration, claim func(context.Context) (bool, error)) func() {
+	released := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(lease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-released:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ok, err := claim(ctx)
+				if err != nil {
+					log.Printf("Failed to renew the lock %s: %v", lockKey, err)
+					continue
+				}
+				if !ok {
+					log.Printf("Lost the lock %s", lockKey)
+					return
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(released)
+			<-stopped
+			// not ctx, which may be done when the holder gives up
+			if _, err := r.store.DeleteIf(context.Background(), lockKey, owner); err != nil {
+				log.Printf("Failed to unlock %s: %v", lockKey, err)
+			}
+		})
+	}
+}
+
+// newLockOwner returns the value of the record of the lock, which identifies the holder
+func newLockOwner() (json.RawMessage, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]string{"owner": hex.EncodeToString(b)})
+}
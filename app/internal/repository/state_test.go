@@ -0,0 +1,190 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/state"
+)
+
+func Test_stateRepository_GetState(t *testing.T) {
+	type value struct {
+		Title string `json:"title"`
+	}
+	migrations := map[string]state.Migrations{
+		"kind": {
+			// version 2 renames "name" to "title"
+			func(v json.RawMessage) (json.RawMessage, error) {
+				var m map[string]interface{}
+				if err := json.Unmarshal(v, &m); err != nil {
+					return nil, err
+				}
+				m["title"] = m["name"]
+				delete(m, "name")
+				return json.Marshal(m)
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		record  *state.Record
+		want    *value
+		wantOK  bool
+		wantErr error
+	}{
+		{
+			name:   "OK: current version",
+			record: &state.Record{Key: "kind:key", Value: json.RawMessage(`{"title":"Sprint review"}`), Version: 2},
+			want:   &value{Title: "Sprint review"},
+			wantOK: true,
+		},
+		{
+			name:   "OK: migrated from version 1",
+			record: &state.Record{Key: "kind:key", Value: json.RawMessage(`{"name":"Sprint review"}`), Version: 1},
+			want:   &value{Title: "Sprint review"},
+			wantOK: true,
+		},
+		{
+			name:   "OK: another key",
+			record: &state.Record{Key: "kind:another", Value: json.RawMessage(`{"title":"Sprint review"}`), Version: 2},
+			want:   &value{},
+		},
+		{
+			name:    "NG: written by a newer version",
+			record:  &state.Record{Key: "kind:key", Value: json.RawMessage(`{"title":"Sprint review"}`), Version: 3},
+			want:    &value{},
+			wantErr: state.ErrUnknownVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := state.NewMemoryStore()
+			if err := store.Put(ctx, tt.record); err != nil {
+				t.Fatal(err)
+			}
+			r := &stateRepository{store: store, migrations: migrations}
+			got := &value{}
+			ok, err := r.GetState(ctx, "kind", "key", got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("GetState() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetState() value = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_stateRepository_PutState(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	r := &stateRepository{store: store, migrations: map[string]state.Migrations{"kind": {nil, nil}}}
+	if err := r.PutState(ctx, "kind", "key", map[string]string{"title": "Sprint review"}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.Get(ctx, "kind:key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &state.Record{Key: "kind:key", Value: json.RawMessage(`{"title":"Sprint review"}`), Version: 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PutState() stored %+v, want %+v", got, want)
+	}
+
+	if err := r.DeleteState(ctx, "kind", "key"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := r.GetState(ctx, "kind", "key", &map[string]string{}); ok || err != nil {
+		t.Errorf("GetState() after DeleteState() = %v, %v", ok, err)
+	}
+}
+
+func Test_stateRepository_LockState(t *testing.T) {
+	ctx := context.Background()
+	r := &stateRepository{store: state.NewMemoryStore()}
+	unlock, err := r.LockState(ctx, "kind", "key", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 3*stateLockInterval)
+	defer cancel()
+	if _, err := r.LockState(waitCtx, "kind", "key", time.Minute); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("LockState() while locked error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	another, err := r.LockState(ctx, "kind", "another", time.Minute)
+	if err != nil {
+		t.Fatalf("LockState() of another key error = %v", err)
+	}
+	another()
+
+	unlock()
+	unlock, err = r.LockState(ctx, "kind", "key", time.Minute)
+	if err != nil {
+		t.Fatalf("LockState() after unlock error = %v", err)
+	}
+	unlock()
+
+	// the lock of the holder which stopped expires after lease
+	stoppedCtx, stop := context.WithCancel(ctx)
+	stale, err := r.LockState(stoppedCtx, "kind", "key", 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop()
+	time.Sleep(20 * time.Millisecond)
+	unlock, err = r.LockState(ctx, "kind", "key", time.Minute)
+	if err != nil {
+		t.Fatalf("LockState() after lease error = %v", err)
+	}
+	defer unlock()
+
+	// the stale holder does not release the lock of the other
+	stale()
+	waitCtx, cancel = context.WithTimeout(ctx, 3*stateLockInterval)
+	defer cancel()
+	if _, err := r.LockState(waitCtx, "kind", "key", time.Minute); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("LockState() after the stale unlock error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func Test_stateRepository_LockState_renew(t *testing.T) {
+	ctx := context.Background()
+	r := &stateRepository{store: state.NewMemoryStore()}
+	unlock, err := r.LockState(ctx, "kind", "key", 30*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+	// the lease is renewed while the lock is held
+	time.Sleep(100 * time.Millisecond)
+	waitCtx, cancel := context.WithTimeout(ctx, 3*stateLockInterval)
+	defer cancel()
+	if _, err := r.LockState(waitCtx, "kind", "key", time.Minute); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("LockState() while renewed error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
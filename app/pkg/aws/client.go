@@ -0,0 +1,140 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+// APIError is returned when the API responds with an error status
+type APIError struct {
+	StatusCode int
+	// Code is the type of the error (e.g. ConditionalCheckFailedException) if the API tells it
+	Code    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("aws api error: status %d: %s", e.StatusCode, e.Message)
+}
+
+func newAPIError(res *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	apiErr := &APIError{StatusCode: res.StatusCode, Message: strings.TrimSpace(string(b))}
+	// the JSON protocols tell the type like "com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException"
+	var body struct {
+		Type string `json:"__type"`
+	}
+	if err := json.Unmarshal(b, &body); err == nil && body.Type != "" {
+		apiErr.Code = body.Type[strings.LastIndex(body.Type, "#")+1:]
+	}
+	if apiErr.Code == "" {
+		apiErr.Code = res.Header.Get("X-Amzn-ErrorType")
+	}
+	return apiErr
+}
+
+type Option func(c *client)
+
+// EndpointOption overrides the API endpoint (e.g. a local stand-in for tests)
+func EndpointOption(endpoint string) Option {
+	return func(c *client) {
+		c.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// HTTPClientOption overrides the http.Client used for API requests
+func HTTPClientOption(httpClient *http.Client) Option {
+	return func(c *client) {
+		c.httpClient = httpClient
+	}
+}
+
+// client sends signed requests to a service
+type client struct {
+	endpoint   string
+	httpClient *http.Client
+	signer     *Signer
+}
+
+func newClient(credentials *Credentials, region, service string, options ...Option) *client {
+	c := &client{
+		endpoint:   fmt.Sprintf("https://%s.%s.amazonaws.com", service, region),
+		httpClient: http.DefaultClient,
+		signer:     NewSigner(credentials, region, service),
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+func (c *client) do(ctx context.Context, method, path string, header http.Header, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if err := c.signer.Sign(req); err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, newAPIError(res)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// LambdaClient invokes a Lambda function
+type LambdaClient interface {
+	// InvokeAsync queues an event for the function and returns without waiting for it
+	InvokeAsync(ctx context.Context, functionName string, payload []byte) error
+}
+
+type lambdaClient struct {
+	*client
+}
+
+func NewLambdaClient(credentials *Credentials, region string, options ...Option) *lambdaClient {
+	return &lambdaClient{newClient(credentials, region, "lambda", options...)}
+}
+
+func (c *lambdaClient) InvokeAsync(ctx context.Context, functionName string, payload []byte) error {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Amz-Invocation-Type", "Event")
+	path := "/2015-03-31/functions/" + url.PathEscape(functionName) + "/invocations"
+	if _, err := c.do(ctx, http.MethodPost, path, header, payload); err != nil {
+		return errors.Wrap(err, "failed to invoke function")
+	}
+	return nil
+}
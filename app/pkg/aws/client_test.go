@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aws
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+func Test_lambdaClient_InvokeAsync(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name: "OK",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/2015-03-31/functions/auriga-cmd/invocations" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get("X-Amz-Invocation-Type"); got != "Event" {
+					t.Errorf("X-Amz-Invocation-Type = %v", got)
+				}
+				if got := r.Header.Get("Authorization"); !strings.HasPrefix(got, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+					t.Errorf("Authorization = %v", got)
+				}
+				if got := r.Header.Get("X-Amz-Security-Token"); got != "sample_token" {
+					t.Errorf("X-Amz-Security-Token = %v", got)
+				}
+				if body, _ := io.ReadAll(r.Body); string(body) != `{"sample":true}` {
+					t.Errorf("body = %s", body)
+				}
+				w.WriteHeader(http.StatusAccepted)
+			},
+		},
+		{
+			name: "NG: api error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"Message":"not authorized"}`))
+			},
+			wantStatus: http.StatusForbidden,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+			credentials := &Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", SessionToken: "sample_token"}
+			c := NewLambdaClient(credentials, "ap-northeast-1", EndpointOption(server.URL))
+			err := c.InvokeAsync(context.Background(), "auriga-cmd", []byte(`{"sample":true}`))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InvokeAsync() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Errorf("InvokeAsync() error = %v, want status %v", err, tt.wantStatus)
+				}
+			}
+		})
+	}
+}
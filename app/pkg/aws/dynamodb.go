@@ -0,0 +1,129 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+const dynamoDBTargetPrefix = "DynamoDB_20120810."
+
+// ErrConditionalCheckFailed is returned when the condition of PutItem or DeleteItem is not satisfied
+var ErrConditionalCheckFailed = errors.New("conditional check failed")
+
+// AttributeValue is a value of an attribute in DynamoDB. Only strings and numbers are supported.
+type AttributeValue struct {
+	S string `json:"S,omitempty"`
+	// N is a number written in a string
+	N string `json:"N,omitempty"`
+}
+
+// Item is a set of attributes
+type Item map[string]AttributeValue
+
+type PutItemInput struct {
+	TableName string `json:"TableName"`
+	Item      Item   `json:"Item"`
+	// ConditionExpression is a condition like "attribute_not_exists(#id) OR #expires_at < :now"
+	ConditionExpression       string            `json:"ConditionExpression,omitempty"`
+	ExpressionAttributeNames  map[string]string `json:"ExpressionAttributeNames,omitempty"`
+	ExpressionAttributeValues Item              `json:"ExpressionAttributeValues,omitempty"`
+}
+
+type DeleteItemInput struct {
+	TableName string `json:"TableName"`
+	Key       Item   `json:"Key"`
+	// ConditionExpression is a condition like "#value = :value"
+	ConditionExpression       string            `json:"ConditionExpression,omitempty"`
+	ExpressionAttributeNames  map[string]string `json:"ExpressionAttributeNames,omitempty"`
+	ExpressionAttributeValues Item              `json:"ExpressionAttributeValues,omitempty"`
+}
+
+// DynamoDBClient reads and writes the items of DynamoDB
+type DynamoDBClient interface {
+	// GetItem returns nil if the item is not found
+	GetItem(ctx context.Context, tableName string, key Item) (Item, error)
+	// PutItem returns ErrConditionalCheckFailed if the condition is not satisfied
+	PutItem(ctx context.Context, input *PutItemInput) error
+	// DeleteItem returns ErrConditionalCheckFailed if the condition is not satisfied.
+	// It is not an error if the item is not found without the condition.
+	DeleteItem(ctx context.Context, input *DeleteItemInput) error
+}
+
+type dynamoDBClient struct {
+	*client
+}
+
+func NewDynamoDBClient(credentials *Credentials, region string, options ...Option) *dynamoDBClient {
+	return &dynamoDBClient{newClient(credentials, region, "dynamodb", options...)}
+}
+
+func (c *dynamoDBClient) GetItem(ctx context.Context, tableName string, key Item) (Item, error) {
+	var output struct {
+		Item Item `json:"Item"`
+	}
+	input := map[string]interface{}{"TableName": tableName, "Key": key, "ConsistentRead": true}
+	if err := c.call(ctx, "GetItem", input, &output); err != nil {
+		return nil, errors.Wrap(err, "failed to get item")
+	}
+	return output.Item, nil
+}
+
+func (c *dynamoDBClient) PutItem(ctx context.Context, input *PutItemInput) error {
+	if err := c.call(ctx, "PutItem", input, nil); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code == "ConditionalCheckFailedException" {
+			return ErrConditionalCheckFailed
+		}
+		return errors.Wrap(err, "failed to put item")
+	}
+	return nil
+}
+
+func (c *dynamoDBClient) DeleteItem(ctx context.Context, input *DeleteItemInput) error {
+	if err := c.call(ctx, "DeleteItem", input, nil); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code == "ConditionalCheckFailedException" {
+			return ErrConditionalCheckFailed
+		}
+		return errors.Wrap(err, "failed to delete item")
+	}
+	return nil
+}
+
+// call invokes the operation with the JSON protocol
+func (c *dynamoDBClient) call(ctx context.Context, operation string, input, output interface{}) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/x-amz-json-1.0")
+	header.Set("X-Amz-Target", dynamoDBTargetPrefix+operation)
+	res, err := c.do(ctx, http.MethodPost, "/", header, body)
+	if err != nil {
+		return err
+	}
+	if output == nil {
+		return nil
+	}
+	return json.Unmarshal(res, output)
+}
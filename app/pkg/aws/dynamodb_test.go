@@ -0,0 +1,192 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+func Test_dynamoDBClient_PutItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{
+			name:   "OK",
+			status: http.StatusOK,
+			body:   `{}`,
+		},
+		{
+			name:    "NG: conditional check failed",
+			status:  http.StatusBadRequest,
+			body:    `{"__type":"com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException","message":"The conditional request failed"}`,
+			wantErr: ErrConditionalCheckFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("X-Amz-Target"); got != "DynamoDB_20120810.PutItem" {
+					t.Errorf("X-Amz-Target = %v", got)
+				}
+				var input PutItemInput
+				if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Item["id"].S != "event:Ev01" {
+					t.Errorf("input = %+v, err = %v", input, err)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			c := NewDynamoDBClient(&Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, "ap-northeast-1", EndpointOption(server.URL))
+			err := c.PutItem(context.Background(), &PutItemInput{
+				TableName:           "auriga-dedupe",
+				Item:                Item{"id": {S: "event:Ev01"}},
+				ConditionExpression: "attribute_not_exists(id)",
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PutItem() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_dynamoDBClient_GetItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Amz-Target"); got != "DynamoDB_20120810.GetItem" {
+			t.Errorf("X-Amz-Target = %v", got)
+		}
+		_, _ = w.Write([]byte(`{"Item":{"id":{"S":"event:Ev01"},"expires_at":{"N":"1667318400"}}}`))
+	}))
+	defer server.Close()
+	c := NewDynamoDBClient(&Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, "ap-northeast-1", EndpointOption(server.URL))
+	got, err := c.GetItem(context.Background(), "auriga-dedupe", Item{"id": {S: "event:Ev01"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Item{"id": {S: "event:Ev01"}, "expires_at": {N: "1667318400"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetItem() = %v, want %v", got, want)
+	}
+}
+
+func TestLocalDynamoDB_PutItem(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		wantErr   error
+	}{
+		{
+			name: "OK: without condition",
+		},
+		{
+			name:      "OK: expired",
+			condition: "attribute_not_exists(#id) OR #expires_at < :now",
+		},
+		{
+			name:      "NG: exists",
+			condition: "attribute_not_exists(#id)",
+			wantErr:   ErrConditionalCheckFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewLocalDynamoDB()
+			d.CreateTable("auriga-dedupe", "id")
+			_ = d.PutItem(context.Background(), &PutItemInput{TableName: "auriga-dedupe", Item: Item{"id": {S: "event:Ev01"}, "expires_at": {N: "100"}}})
+			err := d.PutItem(context.Background(), &PutItemInput{
+				TableName:                 "auriga-dedupe",
+				Item:                      Item{"id": {S: "event:Ev01"}, "expires_at": {N: "300"}},
+				ConditionExpression:       tt.condition,
+				ExpressionAttributeNames:  map[string]string{"#id": "id", "#expires_at": "expires_at"},
+				ExpressionAttributeValues: Item{":now": {N: "200"}},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PutItem() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_dynamoDBClient_DeleteItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Amz-Target"); got != "DynamoDB_20120810.DeleteItem" {
+			t.Errorf("X-Amz-Target = %v", got)
+		}
+		var input DeleteItemInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Key["id"].S != "lock:event" {
+			t.Errorf("input = %+v, err = %v", input, err)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException","message":"The conditional request failed"}`))
+	}))
+	defer server.Close()
+	c := NewDynamoDBClient(&Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, "ap-northeast-1", EndpointOption(server.URL))
+	err := c.DeleteItem(context.Background(), &DeleteItemInput{
+		TableName:                 "auriga-state",
+		Key:                       Item{"id": {S: "lock:event"}},
+		ConditionExpression:       "#value = :value",
+		ExpressionAttributeNames:  map[string]string{"#value": "value"},
+		ExpressionAttributeValues: Item{":value": {S: `{"owner":"a"}`}},
+	})
+	if !errors.Is(err, ErrConditionalCheckFailed) {
+		t.Errorf("DeleteItem() error = %v, want %v", err, ErrConditionalCheckFailed)
+	}
+}
+
+func TestLocalDynamoDB_DeleteItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		{
+			name:  "OK: the same string",
+			value: `{"owner":"a"}`,
+		},
+		{
+			name:    "NG: another string",
+			value:   `{"owner":"b"}`,
+			wantErr: ErrConditionalCheckFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewLocalDynamoDB()
+			d.CreateTable("auriga-state", "id")
+			_ = d.PutItem(context.Background(), &PutItemInput{TableName: "auriga-state", Item: Item{"id": {S: "lock:event"}, "value": {S: `{"owner":"a"}`}}})
+			err := d.DeleteItem(context.Background(), &DeleteItemInput{
+				TableName:                 "auriga-state",
+				Key:                       Item{"id": {S: "lock:event"}},
+				ConditionExpression:       "#value = :value",
+				ExpressionAttributeNames:  map[string]string{"#value": "value"},
+				ExpressionAttributeValues: Item{":value": {S: tt.value}},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteItem() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aws
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+var (
+	notExistsPattern  = regexp.MustCompile(`^attribute_not_exists\((\S+)\)$`)
+	comparisonPattern = regexp.MustCompile(`^(\S+) (=|<|>) (\S+)$`)
+)
+
+// LocalDynamoDB is an in-memory stand-in for DynamoDB, for tests and running locally.
+// ConditionExpression supports only attribute_not_exists, comparisons of numbers and equality of strings joined with OR.
+type LocalDynamoDB struct {
+	mu sync.Mutex
+	// keyNames are the names of the key attributes of each table
+	keyNames map[string][]string
+	tables   map[string]map[string]Item
+}
+
+func NewLocalDynamoDB() *LocalDynamoDB {
+	return &LocalDynamoDB{
+		keyNames: map[string][]string{},
+		tables:   map[string]map[string]Item{},
+	}
+}
+
+// CreateTable creates the table with the key attributes
+func (d *LocalDynamoDB) CreateTable(tableName string, keyNames ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.keyNames[tableName] = keyNames
+	d.tables[tableName] = map[string]Item{}
+}
+
+func (d *LocalDynamoDB) GetItem(ctx context.Context, tableName string, key Item) (Item, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	table, k, err := d.lookup(tableName, key)
+	if err != nil {
+		return nil, err
+	}
+	return table[k], nil
+}
+
+func (d *LocalDynamoDB) PutItem(ctx context.Context, input *PutItemInput) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	table, k, err := d.lookup(input.TableName, input.Item)
+	if err != nil {
+		return err
+	}
+	if input.ConditionExpression != "" {
+		ok, err := evaluate(input.ConditionExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues, table[k])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrConditionalCheckFailed
+		}
+	}
+	item := Item{}
+	for name, v := range input.Item {
+		item[name] = v
+	}
+	table[k] = item
+	return nil
+}
+
+func (d *LocalDynamoDB) DeleteItem(ctx context.Context, input *DeleteItemInput) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	table, k, err := d.lookup(input.TableName, input.Key)
+	if err != nil {
+		return err
+	}
+	if input.ConditionExpression != "" {
+		ok, err := evaluate(input.ConditionExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues, table[k])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrConditionalCheckFailed
+		}
+	}
+	delete(table, k)
+	return nil
+}
+
+// lookup returns the table and the key of the item in it
+func (d *LocalDynamoDB) lookup(tableName string, item Item) (map[string]Item, string, error) {
+	table, ok := d.tables[tableName]
+	if !ok {
+		return nil, "", &APIError{StatusCode: 400, Code: "ResourceNotFoundException", Message: "table not found: " + tableName}
+	}
+	var values []string
+	for _, name := range d.keyNames[tableName] {
+		v, ok := item[name]
+		if !ok {
+			return nil, "", &APIError{StatusCode: 400, Code: "ValidationException", Message: "missing key: " + name}
+		}
+		values = append(values, v.S+"\x00"+v.N)
+	}
+	return table, strings.Join(values, "\x00"), nil
+}
+
+// evaluate returns true if the current item satisfies the condition
+func evaluate(condition string, names map[string]string, values Item, current Item) (bool, error) {
+	name := func(s string) string {
+		if n, ok := names[s]; ok {
+			return n
+		}
+		return s
+	}
+	for _, clause := range strings.Split(condition, " OR ") {
+		clause = strings.TrimSpace(clause)
+		if m := notExistsPattern.FindStringSubmatch(clause); m != nil {
+			if _, ok := current[name(m[1])]; !ok {
+				return true, nil
+			}
+			continue
+		}
+		m := comparisonPattern.FindStringSubmatch(clause)
+		if m == nil {
+			return false, errors.Errorf("unsupported condition: %s", clause)
+		}
+		v, ok := current[name(m[1])]
+		if !ok {
+			continue
+		}
+		if value := values[m[3]]; value.S != "" {
+			if m[2] == "=" && v.S == value.S {
+				return true, nil
+			}
+			continue
+		}
+		left, err := strconv.ParseFloat(v.N, 64)
+		if err != nil {
+			return false, errors.Wrap(err, "not a number")
+		}
+		right, err := strconv.ParseFloat(values[m[3]].N, 64)
+		if err != nil {
+			return false, errors.Wrap(err, "not a number")
+		}
+		if (m[2] == "=" && left == right) || (m[2] == "<" && left < right) || (m[2] == ">" && left > right) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package aws is a minimal client of the AWS APIs Auriga uses, signed with Signature Version 4.
+// It is written by hand on purpose instead of depending on the AWS SDK, which is far larger than the few calls Auriga makes.
+package aws
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+)
+
+// Credentials are the keys to sign the requests with
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is set for temporary credentials such as the ones of a Lambda function
+	SessionToken string
+}
+
+// CredentialsFromEnv reads the credentials from the environment variables set by the Lambda runtime or the user
+func CredentialsFromEnv() (*Credentials, error) {
+	c := &Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return nil, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required")
+	}
+	return c, nil
+}
+
+// Signer signs the requests to a service in a region
+type Signer struct {
+	credentials *Credentials
+	region      string
+	service     string
+	now         func() time.Time
+}
+
+func NewSigner(credentials *Credentials, region, service string) *Signer {
+	return &Signer{
+		credentials: credentials,
+		region:      region,
+		service:     service,
+		now:         time.Now,
+	}
+}
+
+// Sign adds the Authorization header to r. The body of r is read and restored.
+func (s *Signer) Sign(r *http.Request) error {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return errors.Wrap(err, "failed to read body")
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	now := s.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	r.Header.Set("X-Amz-Date", amzDate)
+	if s.credentials.SessionToken != "" {
+		r.Header.Set("X-Amz-Security-Token", s.credentials.SessionToken)
+	}
+
+	signedHeaders, canonicalHeaders := canonicalHeaders(r)
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		canonicalPath(r.URL),
+		canonicalQuery(r.URL),
+		canonicalHeaders,
+		signedHeaders,
+		hashHex(body),
+	}, "\n")
+	scope := strings.Join([]string{now.Format("20060102"), s.region, s.service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.credentials.SecretAccessKey), now.Format("20060102"))
+	for _, k := range []string{s.region, s.service, "aws4_request"} {
+		key = hmacSHA256(key, k)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	r.Header.Set("Authorization", signingAlgorithm+
+		" Credential="+s.credentials.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+signature)
+	return nil
+}
+
+func canonicalHeaders(r *http.Request) (signed string, canonical string) {
+	headers := map[string]string{"host": r.Host}
+	if r.Host == "" {
+		headers["host"] = r.URL.Host
+	}
+	for k, v := range r.Header {
+		name := strings.ToLower(k)
+		if name == "authorization" || name == "user-agent" {
+			continue
+		}
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			values = append(values, strings.Join(strings.Fields(value), " "))
+		}
+		headers[name] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name + ":" + headers[name] + "\n")
+	}
+	return strings.Join(names, ";"), b.String()
+}
+
+func canonicalPath(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func canonicalQuery(u *url.URL) string {
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, escape(k)+"="+escape(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escape encodes the string as RFC 3986, which differs from url.QueryEscape in the space and "~"
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hashHex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
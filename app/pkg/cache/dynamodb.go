@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/aws"
+)
+
+const (
+	// dynamoDBKey is the partition key of the table
+	dynamoDBKey = "id"
+	// dynamoDBValue is the attribute of the value
+	dynamoDBValue = "value"
+	// dynamoDBExpiresAt is the attribute for the TTL of DynamoDB, in Unix time
+	dynamoDBExpiresAt = "expires_at"
+)
+
+// dynamoDBStore keeps the values in a DynamoDB table, so that they are shared by the Lambda instances.
+// Enable the TTL of the table on expires_at to clean up the expired values.
+type dynamoDBStore struct {
+	client    aws.DynamoDBClient
+	tableName string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+func NewDynamoDBStore(client aws.DynamoDBClient, tableName string, ttl time.Duration) *dynamoDBStore {
+	return &dynamoDBStore{
+		client:    client,
+		tableName: tableName,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// Get ignores the expired values since the TTL of DynamoDB may delete them late
+func (s *dynamoDBStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	item, err := s.client.GetItem(ctx, s.tableName, aws.Item{dynamoDBKey: {S: key}})
+	if err != nil || item == nil {
+		return nil, false, err
+	}
+	expiresAt, err := strconv.ParseInt(item[dynamoDBExpiresAt].N, 10, 64)
+	if err != nil || s.now().Unix() >= expiresAt {
+		return nil, false, nil
+	}
+	return []byte(item[dynamoDBValue].S), true, nil
+}
+
+func (s *dynamoDBStore) Set(ctx context.Context, key string, value []byte) error {
+	return s.client.PutItem(ctx, &aws.PutItemInput{
+		TableName: s.tableName,
+		Item: aws.Item{
+			dynamoDBKey:       {S: key},
+			dynamoDBValue:     {S: string(value)},
+			dynamoDBExpiresAt: {N: strconv.FormatInt(s.now().Add(s.ttl).Unix(), 10)},
+		},
+	})
+}
+
+func (s *dynamoDBStore) Delete(ctx context.Context, key string) error {
+	return s.client.DeleteItem(ctx, &aws.DeleteItemInput{TableName: s.tableName, Key: aws.Item{dynamoDBKey: {S: key}}})
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/aws"
+)
+
+func Test_dynamoDBStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   string
+		after   time.Duration
+		delete  bool
+		wantOK  bool
+		wantErr bool
+	}{
+		{
+			name:   "OK: cached",
+			table:  "auriga-cache",
+			after:  time.Minute,
+			wantOK: true,
+		},
+		{
+			name:  "OK: expired",
+			table: "auriga-cache",
+			after: 2 * time.Hour,
+		},
+		{
+			name:   "OK: deleted",
+			table:  "auriga-cache",
+			delete: true,
+		},
+		{
+			name:    "NG: table not found",
+			table:   "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := aws.NewLocalDynamoDB()
+			db.CreateTable("auriga-cache", "id")
+			now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+			s := NewDynamoDBStore(db, tt.table, time.Hour)
+			s.now = func() time.Time { return now }
+
+			err := s.Set(ctx, "user:U01", []byte(`{"ID":"U01"}`))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.delete {
+				if err := s.Delete(ctx, "user:U01"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			now = now.Add(tt.after)
+			got, ok, err := s.Get(ctx, "user:U01")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("Get() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && string(got) != `{"ID":"U01"}` {
+				t.Errorf("Get() = %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// lruStore keeps the values in memory. The least recently used values are evicted when it is full.
+type lruStore struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRUStore builds a store holding up to size values for ttl
+func NewLRUStore(size int, ttl time.Duration) *lruStore {
+	if size < 1 {
+		size = DefaultSize
+	}
+	return &lruStore{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (s *lruStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := e.Value.(*lruEntry)
+	if !s.now().Before(entry.expiresAt) {
+		s.order.Remove(e)
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	s.order.MoveToFront(e)
+	return entry.value, true, nil
+}
+
+func (s *lruStore) Set(ctx context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		s.order.Remove(e)
+	}
+	s.entries[key] = s.order.PushFront(&lruEntry{key: key, value: value, expiresAt: s.now().Add(s.ttl)})
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+func (s *lruStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		s.order.Remove(e)
+		delete(s.entries, key)
+	}
+	return nil
+}
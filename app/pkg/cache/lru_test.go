@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_lruStore(t *testing.T) {
+	type op struct {
+		set    string
+		get    string
+		delete string
+		after  time.Duration
+		wantOK bool
+	}
+	tests := []struct {
+		name string
+		size int
+		ops  []op
+	}{
+		{
+			name: "OK: the value is kept until ttl",
+			size: 10,
+			ops: []op{
+				{set: "U01"},
+				{get: "U01", after: time.Minute, wantOK: true},
+				{get: "U01", after: time.Hour, wantOK: false},
+			},
+		},
+		{
+			name: "OK: the least recently used value is evicted",
+			size: 2,
+			ops: []op{
+				{set: "U01"},
+				{set: "U02"},
+				{get: "U01", wantOK: true},
+				{set: "U03"},
+				{get: "U02", wantOK: false},
+				{get: "U01", wantOK: true},
+			},
+		},
+		{
+			name: "OK: the value is deleted",
+			size: 10,
+			ops: []op{
+				{set: "U01"},
+				{delete: "U01"},
+				{get: "U01", wantOK: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+			s := NewLRUStore(tt.size, time.Hour)
+			s.now = func() time.Time { return now }
+			for _, o := range tt.ops {
+				now = now.Add(o.after)
+				switch {
+				case o.set != "":
+					if err := s.Set(ctx, o.set, []byte("value of "+o.set)); err != nil {
+						t.Fatal(err)
+					}
+				case o.delete != "":
+					if err := s.Delete(ctx, o.delete); err != nil {
+						t.Fatal(err)
+					}
+				default:
+					got, ok, err := s.Get(ctx, o.get)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if ok != o.wantOK {
+						t.Errorf("Get(%s) ok = %v, want %v", o.get, ok, o.wantOK)
+					}
+					if ok && string(got) != "value of "+o.get {
+						t.Errorf("Get(%s) = %s", o.get, got)
+					}
+				}
+			}
+		})
+	}
+}
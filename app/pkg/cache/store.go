@@ -0,0 +1,38 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cache keeps the values looked up from the slow APIs for a while.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DefaultSize = 5000
+	// DefaultTTL keeps the values fresh enough, since the changes are also invalidated by the events
+	DefaultTTL = 24 * time.Hour
+)
+
+type Store interface {
+	// Get returns the value of the key. ok is false if the key is not found or expired.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set stores the value of the key for the TTL of the store
+	Set(ctx context.Context, key string, value []byte) error
+	// Delete removes the key. It is not an error if the key is not found.
+	Delete(ctx context.Context, key string) error
+}
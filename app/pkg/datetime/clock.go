@@ -0,0 +1,236 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datetime
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// clockPattern matches a time of day: "15:00", "15時", "15時30分", "15時半", "午後3時", "3pm", "3:30pm"
+	clockPattern = `(?:(?:午前|午後)?\s*\d{1,2}時(?:\d{1,2}分|半)?|\b\d{1,2}(?::\d{2})?\s*(?i:am|pm)\b|\b\d{1,2}:\d{2}\b)`
+	// durationPattern matches a length of time: "1時間", "1時間半", "1h", "1.5h", "45m", "90 minutes"
+	durationPattern = `(?:\d+(?:\.\d+)?\s*時間(?:\d{1,2}分|半)?|\b\d+(?:\.\d+)?\s*(?i:hours|hour|hrs|hr|h)(?:\s*\d+\s*(?i:minutes|minute|mins|min|m))?\b|\b\d+\s*(?i:minutes|minute|mins|min|m)\b)`
+	// minutesPattern matches minutes in Japanese: "30分".
+	// It is read as a duration only after "から" or "for", or before "間", since "15時30分" is a time of day.
+	minutesPattern = `\d+\s*分`
+	// rangeSeparator joins the start and the end of a time range
+	rangeSeparator = `(?:-|–|~|〜|～|から|(?i:to|until))`
+)
+
+var (
+	regTimeRange        = regexp.MustCompile(`(?:\b(?i:from)\s+)?(` + clockPattern + `)\s*` + rangeSeparator + `\s*(` + clockPattern + `)(?:まで)?`)
+	regTimeRangeAMPM    = regexp.MustCompile(`(?:\b(?i:from)\s+)?\b(\d{1,2}(?::\d{2})?)\s*` + rangeSeparator + `\s*(\d{1,2}(?::\d{2})?)\s*(?i:(am|pm))\b`)
+	regTimeWithDuration = regexp.MustCompile(`(?:\b(?i:at)\s+)?(` + clockPattern + `)\s*(?:(?:から|(?i:for)|,)?\s*(` + durationPattern + `)(?:間)?|(?:から|(?i:for))\s*(` + minutesPattern + `)(?:間)?)`)
+	regTime             = regexp.MustCompile(`(?:\b(?i:at)\s+)?(` + clockPattern + `)(?:から|~|〜|～)?`)
+	regDuration         = regexp.MustCompile(`(?:\b(?i:for)\s+)?(` + durationPattern + `)(?:間)?|(?:から|\b(?i:for))\s*(` + minutesPattern + `)(?:間)?|(` + minutesPattern + `)間`)
+
+	regClockJP    = regexp.MustCompile(`^(午前|午後)?\s*(\d{1,2})時(?:(\d{1,2})分|(半))?$`)
+	regClockAMPM  = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(?i:(am|pm))$`)
+	regClockColon = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+
+	regDurationJP      = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*時間(?:(\d{1,2})分|(半))?$`)
+	regDurationJPMin   = regexp.MustCompile(`^(\d+)\s*分$`)
+	regDurationHour    = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(?i:hours|hour|hrs|hr|h)(?:\s*(\d+)\s*(?i:minutes|minute|mins|min|m))?$`)
+	regDurationMinutes = regexp.MustCompile(`^(\d+)\s*(?i:minutes|minute|mins|min|m)$`)
+)
+
+// clock is a time of day
+type clock struct {
+	hour, minute int
+}
+
+func (c clock) duration() time.Duration {
+	return time.Duration(c.hour)*time.Hour + time.Duration(c.minute)*time.Minute
+}
+
+// followedByHour rejects "1時" of "1時間", which is a duration and not a time of day
+func followedByHour(rest string) bool {
+	return strings.HasPrefix(rest, "間")
+}
+
+// timeMatchers returns the time matchers in the order they are applied.
+// Ranges are tried before single times so that "15時から16時" is read as one range.
+func (p *parser) timeMatchers() []matcher {
+	return []matcher{
+		{re: regTimeRangeAMPM, fn: p.timeRangeAMPM},
+		{re: regTimeRange, reject: followedByHour, fn: p.timeRange},
+		{re: regTimeWithDuration, fn: p.timeWithDuration},
+		{re: regTime, reject: followedByHour, fn: p.singleTime},
+		{re: regDuration, fn: p.standaloneDuration},
+	}
+}
+
+func (p *parser) timeRange(m []string) error {
+	start, err := parseClock(m[1])
+	if err != nil {
+		return err
+	}
+	end, err := parseClock(m[2])
+	if err != nil {
+		return err
+	}
+	return p.addTimeRange(m[0], start, end)
+}
+
+// timeRangeAMPM reads a range whose am/pm is written only at the end (e.g. "3-4pm")
+func (p *parser) timeRangeAMPM(m []string) error {
+	end, err := parseClock(m[2] + m[3])
+	if err != nil {
+		return err
+	}
+	start, err := parseClock(m[1] + m[3])
+	if err != nil {
+		return err
+	}
+	if start.duration() >= end.duration() {
+		// "11-1pm" starts in the morning
+		if c, err := parseClock(m[1] + otherMeridiem(m[3])); err == nil && c.duration() < end.duration() {
+			start = c
+		}
+	}
+	return p.addTimeRange(m[0], start, end)
+}
+
+func (p *parser) addTimeRange(text string, start, end clock) error {
+	length := end.duration() - start.duration()
+	if length <= 0 {
+		// the range goes over midnight (e.g. 23:00-1:00)
+		length += 24 * time.Hour
+	}
+	p.times = append(p.times, foundTime{text: strings.TrimSpace(text), start: start, length: length})
+	return nil
+}
+
+func (p *parser) timeWithDuration(m []string) error {
+	start, err := parseClock(m[1])
+	if err != nil {
+		return err
+	}
+	length, err := parseDuration(firstGroup(m[2:]))
+	if err != nil {
+		return err
+	}
+	p.times = append(p.times, foundTime{text: strings.TrimSpace(m[0]), start: start, length: length})
+	return nil
+}
+
+func (p *parser) singleTime(m []string) error {
+	start, err := parseClock(m[1])
+	if err != nil {
+		return err
+	}
+	p.times = append(p.times, foundTime{text: strings.TrimSpace(m[0]), start: start})
+	return nil
+}
+
+func (p *parser) standaloneDuration(m []string) error {
+	length, err := parseDuration(firstGroup(m[1:]))
+	if err != nil {
+		return err
+	}
+	p.durations = append(p.durations, foundDuration{text: strings.TrimSpace(m[0]), length: length})
+	return nil
+}
+
+// firstGroup returns the group matched in one of the alternatives
+func firstGroup(groups []string) string {
+	for _, g := range groups {
+		if g != "" {
+			return g
+		}
+	}
+	return ""
+}
+
+func otherMeridiem(s string) string {
+	if strings.EqualFold(s, "pm") {
+		return "am"
+	}
+	return "pm"
+}
+
+func parseClock(s string) (clock, error) {
+	s = strings.TrimSpace(s)
+	var c clock
+	if m := regClockJP.FindStringSubmatch(s); m != nil {
+		c.hour, _ = strconv.Atoi(m[2])
+		c.minute, _ = strconv.Atoi(m[3])
+		if m[4] != "" {
+			c.minute = 30
+		}
+		if m[1] == "午後" && c.hour < 12 {
+			c.hour += 12
+		}
+	} else if m := regClockAMPM.FindStringSubmatch(s); m != nil {
+		c.hour, _ = strconv.Atoi(m[1])
+		c.minute, _ = strconv.Atoi(m[2])
+		if c.hour < 1 || c.hour > 12 {
+			return c, newParseError(ErrInvalid, "no such time: %s", s)
+		}
+		c.hour %= 12
+		if strings.ToLower(m[3]) == "pm" {
+			c.hour += 12
+		}
+	} else if m := regClockColon.FindStringSubmatch(s); m != nil {
+		c.hour, _ = strconv.Atoi(m[1])
+		c.minute, _ = strconv.Atoi(m[2])
+	}
+	if c.hour > 23 || c.minute > 59 {
+		return c, newParseError(ErrInvalid, "no such time: %s", s)
+	}
+	return c, nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var d time.Duration
+	if m := regDurationJP.FindStringSubmatch(s); m != nil {
+		d = hours(m[1])
+		if m[2] != "" {
+			minutes, _ := strconv.Atoi(m[2])
+			d += time.Duration(minutes) * time.Minute
+		}
+		if m[3] != "" {
+			d += 30 * time.Minute
+		}
+	} else if m := regDurationJPMin.FindStringSubmatch(s); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		d = time.Duration(minutes) * time.Minute
+	} else if m := regDurationHour.FindStringSubmatch(s); m != nil {
+		d = hours(m[1])
+		if m[2] != "" {
+			minutes, _ := strconv.Atoi(m[2])
+			d += time.Duration(minutes) * time.Minute
+		}
+	} else if m := regDurationMinutes.FindStringSubmatch(s); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		d = time.Duration(minutes) * time.Minute
+	}
+	if d <= 0 {
+		return 0, newParseError(ErrInvalid, "invalid duration: %s", s)
+	}
+	return d, nil
+}
+
+func hours(s string) time.Duration {
+	h, _ := strconv.ParseFloat(s, 64)
+	return time.Duration(h * float64(time.Hour))
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datetime
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// weekdayAnnotation matches an optional weekday following a date like "11/5(火)"
+const weekdayAnnotation = `(?:\s*[(（]([月火水木金土日])(?:曜日?)?[)）])?`
+
+var (
+	regISODate    = regexp.MustCompile(`(\d{4})[-/](\d{1,2})[-/](\d{1,2})` + weekdayAnnotation)
+	regJPFullDate = regexp.MustCompile(`(\d{4})年\s*(\d{1,2})月\s*(\d{1,2})日` + weekdayAnnotation)
+	regJPMonthDay = regexp.MustCompile(`(\d{1,2})月\s*(\d{1,2})日` + weekdayAnnotation)
+	regMonthDay   = regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})\b` + weekdayAnnotation)
+	regJPRelative = regexp.MustCompile(`(今日|本日|明後日|あさって|明日|あした)`)
+	regJPWeekday  = regexp.MustCompile(`(今週|再来週|来週|次)?の?\s*([月火水木金土日])曜日?`)
+	regENRelative = regexp.MustCompile(`\b(?i:(day after tomorrow|today|tomorrow))\b`)
+	regENWeekday  = regexp.MustCompile(`\b(?i:(?:(this|next)\s+)?(monday|tuesday|wednesday|thursday|friday|saturday|sunday|mon|tues|tue|wed|thurs|thu|fri|sat|sun))\b`)
+	regENMonthDay = regexp.MustCompile(`\b(?i:` + monthNamePattern + `)\.?\s+(\d{1,2})(?:st|nd|rd|th)?\b`)
+	regENDayMonth = regexp.MustCompile(`\b(\d{1,2})(?:st|nd|rd|th)?\s+(?i:` + monthNamePattern + `)\b`)
+	// regFollowingTime matches the time following a weekday, such as " 15時" of "月曜 15時"
+	regFollowingTime = regexp.MustCompile(`^[\s,、]*(?:の\s*)?(?:(?i:at|from)\s+)?` + clockPattern)
+	monthNamePattern = `(january|february|march|april|may|june|july|august|september|october|november|december|jan|feb|mar|apr|jun|jul|aug|sept|sep|oct|nov|dec)`
+)
+
+var jpWeekdays = map[string]time.Weekday{
+	"日": time.Sunday, "月": time.Monday, "火": time.Tuesday, "水": time.Wednesday,
+	"木": time.Thursday, "金": time.Friday, "土": time.Saturday,
+}
+
+var enWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// weekOffset is the number of weeks from this week
+type weekOffset int
+
+const (
+	upcoming weekOffset = -1 // the nearest day including today
+	nextOne  weekOffset = -2 // the nearest day excluding today
+	thisWeek weekOffset = 0
+	nextWeek weekOffset = 1
+)
+
+// dateMatchers returns the date matchers in the order they are applied.
+// More specific patterns come first so that "2026/11/5" is not read as "11/5".
+func (p *parser) dateMatchers() []matcher {
+	return []matcher{
+		{re: regISODate, fn: p.yearMonthDay},
+		{re: regJPFullDate, fn: p.yearMonthDay},
+		{re: regJPMonthDay, fn: p.monthDay},
+		{re: regMonthDay, fn: p.monthDay},
+		{re: regJPRelative, fn: p.jpRelative},
+		{re: regJPWeekday, reject: notFollowedByTime, fn: p.jpWeekday},
+		{re: regENRelative, fn: p.enRelative},
+		{re: regENWeekday, reject: notFollowedByTime, fn: p.enWeekday},
+		{re: regENMonthDay, fn: func(m []string) error { return p.monthNameDay(m[0], m[1], m[2]) }},
+		{re: regENDayMonth, fn: func(m []string) error { return p.monthNameDay(m[0], m[2], m[1]) }},
+	}
+}
+
+// notFollowedByTime rejects the weekday in the title like "月曜の振り返り".
+// A weekday is read as the date only when the time follows it, as in "来週月曜 15時".
+func notFollowedByTime(rest string) bool {
+	return !regFollowingTime.MatchString(rest)
+}
+
+func (p *parser) yearMonthDay(m []string) error {
+	y, _ := strconv.Atoi(m[1])
+	mo, _ := strconv.Atoi(m[2])
+	d, _ := strconv.Atoi(m[3])
+	date, err := p.date(m[0], y, mo, d)
+	if err != nil {
+		return err
+	}
+	return p.addDate(m[0], date, m[4])
+}
+
+// monthDay reads a date without year, which is the nearest one from today
+func (p *parser) monthDay(m []string) error {
+	mo, _ := strconv.Atoi(m[1])
+	d, _ := strconv.Atoi(m[2])
+	return p.addMonthDay(m[0], mo, d, m[3])
+}
+
+func (p *parser) addMonthDay(text string, month, day int, annotation string) error {
+	date, err := p.date(text, p.today.Year(), month, day)
+	if err != nil {
+		return err
+	}
+	if date.Before(p.today) {
+		if date, err = p.date(text, p.today.Year()+1, month, day); err != nil {
+			return err
+		}
+	}
+	return p.addDate(text, date, annotation)
+}
+
+func (p *parser) monthNameDay(text, month, day string) error {
+	month = strings.ToLower(month)
+	for i := time.January; i <= time.December; i++ {
+		if strings.HasPrefix(strings.ToLower(i.String()), month[:3]) {
+			d, _ := strconv.Atoi(day)
+			return p.addMonthDay(text, int(i), d, "")
+		}
+	}
+	return newParseError(ErrInvalid, "unknown month: %s", text)
+}
+
+func (p *parser) jpRelative(m []string) error {
+	days := map[string]int{"今日": 0, "本日": 0, "明日": 1, "あした": 1, "明後日": 2, "あさって": 2}[m[1]]
+	return p.addDate(m[0], p.today.AddDate(0, 0, days), "")
+}
+
+func (p *parser) enRelative(m []string) error {
+	days := map[string]int{"today": 0, "tomorrow": 1, "day after tomorrow": 2}[strings.ToLower(m[1])]
+	return p.addDate(m[0], p.today.AddDate(0, 0, days), "")
+}
+
+func (p *parser) jpWeekday(m []string) error {
+	offset := map[string]weekOffset{"": upcoming, "次": nextOne, "今週": thisWeek, "来週": nextWeek, "再来週": nextWeek + 1}[m[1]]
+	return p.addDate(m[0], p.weekday(jpWeekdays[m[2]], offset), "")
+}
+
+func (p *parser) enWeekday(m []string) error {
+	offset := map[string]weekOffset{"": upcoming, "this": thisWeek, "next": nextWeek}[strings.ToLower(m[1])]
+	return p.addDate(m[0], p.weekday(enWeekdays[strings.ToLower(m[2])[:3]], offset), "")
+}
+
+// weekday resolves the weekday relative to today. Weeks start on Monday.
+func (p *parser) weekday(w time.Weekday, offset weekOffset) time.Time {
+	ahead := (int(w) - int(p.today.Weekday()) + 7) % 7
+	switch offset {
+	case upcoming:
+		return p.today.AddDate(0, 0, ahead)
+	case nextOne:
+		if ahead == 0 {
+			ahead = 7
+		}
+		return p.today.AddDate(0, 0, ahead)
+	}
+	monday := p.today.AddDate(0, 0, -((int(p.today.Weekday()) + 6) % 7))
+	return monday.AddDate(0, 0, (int(w)+6)%7+7*int(offset))
+}
+
+// date builds a date, rejecting the overflowed one like 2/30
+func (p *parser) date(text string, y, m, d int) (time.Time, error) {
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, p.location)
+	if date.Year() != y || int(date.Month()) != m || date.Day() != d {
+		return time.Time{}, newParseError(ErrInvalid, "no such date: %s", text)
+	}
+	return date, nil
+}
+
+// addDate records the date, checking the weekday written beside it (e.g. "11/5(火)")
+func (p *parser) addDate(text string, date time.Time, annotation string) error {
+	if annotation != "" && date.Weekday() != jpWeekdays[annotation] {
+		return newParseError(ErrAmbiguous, "%s is %s, not %s曜日", strings.TrimSpace(text), date.Weekday(), annotation)
+	}
+	p.dates = append(p.dates, foundDate{text: strings.TrimSpace(text), date: date})
+	return nil
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package datetime parses the date and time of a meeting written in Japanese or English,
+// such as "明日15時から1時間", "来週火曜 10:00-11:30", "11/5 14時" and "tomorrow 3pm for 45m".
+package datetime
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// DefaultDuration is the length of the meeting when only the start time is written
+	DefaultDuration = time.Hour
+)
+
+// Result is the date and time read from the text
+type Result struct {
+	Start time.Time
+	End   time.Time
+	// Rest is the text other than the date and time (e.g. the title of the meeting)
+	Rest string
+}
+
+type Parser struct {
+	defaultDuration time.Duration
+}
+
+type Option func(p *Parser)
+
+// DefaultDurationOption sets the length of the meeting used when the end time is not written
+func DefaultDurationOption(d time.Duration) Option {
+	return func(p *Parser) {
+		p.defaultDuration = d
+	}
+}
+
+func NewParser(options ...Option) *Parser {
+	p := &Parser{
+		defaultDuration: DefaultDuration,
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	return p
+}
+
+// Parse reads the date and time from text.
+// Relative expressions like "明日" or "next tuesday" are resolved from now, in the location of now.
+func (p *Parser) Parse(text string, now time.Time) (*Result, error) {
+	s := &parser{
+		work:     normalize(text),
+		location: now.Location(),
+		now:      now,
+		today:    time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+	}
+	for _, m := range append(s.dateMatchers(), s.timeMatchers()...) {
+		if err := s.scan(m); err != nil {
+			return nil, err
+		}
+	}
+	return s.result(p.defaultDuration)
+}
+
+// Parse reads the date and time from text with the default options
+func Parse(text string, now time.Time) (*Result, error) {
+	return NewParser().Parse(text, now)
+}
+
+// matcher reads a part of the text matched by re
+type matcher struct {
+	re *regexp.Regexp
+	// reject skips the match by looking at the text following it
+	reject func(rest string) bool
+	fn     func(m []string) error
+}
+
+type foundDate struct {
+	text string
+	date time.Time
+}
+
+type foundTime struct {
+	text   string
+	start  clock
+	length time.Duration // zero if the end is not written
+}
+
+type foundDuration struct {
+	text   string
+	length time.Duration
+}
+
+// parser holds the state of a single Parse call
+type parser struct {
+	// work is the text to be parsed. Matched parts are blanked out so that they are read only once.
+	work     string
+	location *time.Location
+	now      time.Time
+	today    time.Time
+
+	dates     []foundDate
+	times     []foundTime
+	durations []foundDuration
+}
+
+func (p *parser) scan(m matcher) error {
+	for _, loc := range m.re.FindAllStringSubmatchIndex(p.work, -1) {
+		if m.reject != nil && m.reject(p.work[loc[1]:]) {
+			continue
+		}
+		groups := make([]string, len(loc)/2)
+		for i := range groups {
+			if loc[2*i] >= 0 {
+				groups[i] = p.work[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		if err := m.fn(groups); err != nil {
+			return err
+		}
+		p.work = p.work[:loc[0]] + strings.Repeat(" ", loc[1]-loc[0]) + p.work[loc[1]:]
+	}
+	return nil
+}
+
+func (p *parser) result(defaultDuration time.Duration) (*Result, error) {
+	if len(p.dates) == 0 && len(p.times) == 0 {
+		return nil, newParseError(ErrNotFound, "no date and time")
+	}
+	date, err := p.resolvedDate()
+	if err != nil {
+		return nil, err
+	}
+	if len(p.times) == 0 {
+		return nil, newParseError(ErrAmbiguous, "time is missing for %s", p.dates[0].text)
+	}
+	if len(p.times) > 1 {
+		return nil, newParseError(ErrAmbiguous, "multiple times: %s", joinTexts(p.timeTexts()))
+	}
+	t := p.times[0]
+	length := t.length
+	if len(p.durations) > 1 || (len(p.durations) == 1 && length > 0) {
+		return nil, newParseError(ErrAmbiguous, "multiple durations: %s", joinTexts(p.durationTexts()))
+	}
+	if len(p.durations) == 1 {
+		length = p.durations[0].length
+	}
+	if length == 0 {
+		length = defaultDuration
+	}
+	if date.IsZero() {
+		// only the time is written: today if it is still to come
+		date = p.today
+	}
+	start := time.Date(date.Year(), date.Month(), date.Day(), t.start.hour, t.start.minute, 0, 0, p.location)
+	if len(p.dates) == 0 && start.Before(p.now) {
+		return nil, newParseError(ErrAmbiguous, "date is missing for %s", t.text)
+	}
+	return &Result{
+		Start: start,
+		End:   start.Add(length),
+		Rest:  rest(p.work),
+	}, nil
+}
+
+// resolvedDate returns the date written in the text, or zero time if there is none
+func (p *parser) resolvedDate() (time.Time, error) {
+	if len(p.dates) == 0 {
+		return time.Time{}, nil
+	}
+	date := p.dates[0].date
+	for _, d := range p.dates[1:] {
+		if !d.date.Equal(date) {
+			texts := make([]string, 0, len(p.dates))
+			for _, d := range p.dates {
+				texts = append(texts, d.text)
+			}
+			return time.Time{}, newParseError(ErrAmbiguous, "multiple dates: %s", joinTexts(texts))
+		}
+	}
+	return date, nil
+}
+
+func (p *parser) timeTexts() []string {
+	texts := make([]string, 0, len(p.times))
+	for _, t := range p.times {
+		texts = append(texts, t.text)
+	}
+	return texts
+}
+
+func (p *parser) durationTexts() []string {
+	texts := make([]string, 0, len(p.durations)+1)
+	for _, t := range p.times {
+		if t.length > 0 {
+			texts = append(texts, t.text)
+		}
+	}
+	for _, d := range p.durations {
+		texts = append(texts, d.text)
+	}
+	return texts
+}
+
+func joinTexts(texts []string) string {
+	return `"` + strings.Join(texts, `", "`) + `"`
+}
+
+// normalize converts full-width digits and symbols into ASCII
+func normalize(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case '０' <= r && r <= '９':
+			return r - '０' + '0'
+		case r == '：':
+			return ':'
+		case r == '／':
+			return '/'
+		case r == '－' || r == '−':
+			return '-'
+		case r == '　':
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// rest returns the text which is not read as the date and time
+func rest(work string) string {
+	s := strings.Join(strings.Fields(work), " ")
+	return strings.TrimFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",、。・-", r)
+	})
+}
@@ -0,0 +1,258 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datetime
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	// Wednesday
+	now := time.Date(2026, 10, 21, 9, 0, 0, 0, jst)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, jst)
+	}
+	tests := []struct {
+		name      string
+		text      string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantRest  string
+		wantErr   error
+	}{
+		{
+			name:      "OK: 明日15時から1時間",
+			text:      "明日15時から1時間 定例",
+			wantStart: at(10, 22, 15, 0),
+			wantEnd:   at(10, 22, 16, 0),
+			wantRest:  "定例",
+		},
+		{
+			name:      "OK: 来週火曜 10:00-11:30",
+			text:      "来週火曜 10:00-11:30",
+			wantStart: at(10, 27, 10, 0),
+			wantEnd:   at(10, 27, 11, 30),
+		},
+		{
+			name:      "OK: 11/5 14時 (default duration)",
+			text:      "11/5 14時 Sprint review",
+			wantStart: at(11, 5, 14, 0),
+			wantEnd:   at(11, 5, 15, 0),
+			wantRest:  "Sprint review",
+		},
+		{
+			name:      "OK: tomorrow 3pm for 45m",
+			text:      "tomorrow 3pm for 45m Sprint review",
+			wantStart: at(10, 22, 15, 0),
+			wantEnd:   at(10, 22, 15, 45),
+			wantRest:  "Sprint review",
+		},
+		{
+			name:      "OK: ISO date and time range",
+			text:      "2026-11-02 15:00-16:00 Sprint review",
+			wantStart: at(11, 2, 15, 0),
+			wantEnd:   at(11, 2, 16, 0),
+			wantRest:  "Sprint review",
+		},
+		{
+			name:      "OK: 月日 with weekday and 時分 range",
+			text:      "11月5日(木) 午後1時30分〜午後3時 振り返り",
+			wantStart: at(11, 5, 13, 30),
+			wantEnd:   at(11, 5, 15, 0),
+			wantRest:  "振り返り",
+		},
+		{
+			name:      "OK: 時半 and 時間半",
+			text:      "明後日 10時半から1時間半",
+			wantStart: at(10, 23, 10, 30),
+			wantEnd:   at(10, 23, 12, 0),
+		},
+		{
+			name:      "OK: duration written apart from the time",
+			text:      "金曜 14:00 設計レビュー 30分間",
+			wantStart: at(10, 23, 14, 0),
+			wantEnd:   at(10, 23, 14, 30),
+			wantRest:  "設計レビュー",
+		},
+		{
+			name:      "OK: today's weekday is today",
+			text:      "水曜 18時",
+			wantStart: at(10, 21, 18, 0),
+			wantEnd:   at(10, 21, 19, 0),
+		},
+		{
+			name:      "OK: 次の水曜 is next week",
+			text:      "次の水曜 18時",
+			wantStart: at(10, 28, 18, 0),
+			wantEnd:   at(10, 28, 19, 0),
+		},
+		{
+			name:      "OK: next monday",
+			text:      "Kickoff next monday from 9:30am to 11am",
+			wantStart: at(10, 26, 9, 30),
+			wantEnd:   at(10, 26, 11, 0),
+			wantRest:  "Kickoff",
+		},
+		{
+			name:      "OK: month name",
+			text:      "Nov 5th at 2pm for 1.5h",
+			wantStart: at(11, 5, 14, 0),
+			wantEnd:   at(11, 5, 15, 30),
+		},
+		{
+			name:      "OK: past month/day is next year",
+			text:      "1/15 10:00",
+			wantStart: time.Date(2027, 1, 15, 10, 0, 0, 0, jst),
+			wantEnd:   time.Date(2027, 1, 15, 11, 0, 0, 0, jst),
+		},
+		{
+			name:      "OK: full-width digits",
+			text:      "１１／５ １４：００－１５：００",
+			wantStart: at(11, 5, 14, 0),
+			wantEnd:   at(11, 5, 15, 0),
+		},
+		{
+			name:      "OK: only time which is still to come is today",
+			text:      "17時から30分",
+			wantStart: at(10, 21, 17, 0),
+			wantEnd:   at(10, 21, 17, 30),
+		},
+		{
+			name:      "OK: range over midnight",
+			text:      "明日 23:00-1:00",
+			wantStart: at(10, 22, 23, 0),
+			wantEnd:   at(10, 23, 1, 0),
+		},
+		{
+			name:      "OK: minutes of the time are not a duration",
+			text:      "明日15時30分 定例",
+			wantStart: at(10, 22, 15, 30),
+			wantEnd:   at(10, 22, 16, 30),
+			wantRest:  "定例",
+		},
+		{
+			name:      "OK: minutes after から are a duration",
+			text:      "明日15時30分から30分 定例",
+			wantStart: at(10, 22, 15, 30),
+			wantEnd:   at(10, 22, 16, 0),
+			wantRest:  "定例",
+		},
+		{
+			name:      "OK: pm applies to both ends of the range",
+			text:      "tomorrow 3-4pm Sprint review",
+			wantStart: at(10, 22, 15, 0),
+			wantEnd:   at(10, 22, 16, 0),
+			wantRest:  "Sprint review",
+		},
+		{
+			name:      "OK: range from the morning to the afternoon",
+			text:      "tomorrow 11:30-1pm Lunch",
+			wantStart: at(10, 22, 11, 30),
+			wantEnd:   at(10, 22, 13, 0),
+			wantRest:  "Lunch",
+		},
+		{
+			name:      "OK: weekday in the title",
+			text:      "明日15時 月曜の振り返り",
+			wantStart: at(10, 22, 15, 0),
+			wantEnd:   at(10, 22, 16, 0),
+			wantRest:  "月曜の振り返り",
+		},
+		{
+			name:      "OK: weekday in the title before the date",
+			text:      "Monday retro tomorrow 3pm",
+			wantStart: at(10, 22, 15, 0),
+			wantEnd:   at(10, 22, 16, 0),
+			wantRest:  "Monday retro",
+		},
+		{
+			name:    "NG: no date and time",
+			text:    "Sprint review",
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "NG: date without time",
+			text:    "明日 Sprint review",
+			wantErr: ErrAmbiguous,
+		},
+		{
+			name:    "NG: passed time without date",
+			text:    "8時",
+			wantErr: ErrAmbiguous,
+		},
+		{
+			name:    "NG: multiple dates",
+			text:    "明日か明後日 15時",
+			wantErr: ErrAmbiguous,
+		},
+		{
+			name:    "NG: multiple times",
+			text:    "明日 10時 15時",
+			wantErr: ErrAmbiguous,
+		},
+		{
+			name:    "NG: range and duration",
+			text:    "明日 10:00-11:00 1時間",
+			wantErr: ErrAmbiguous,
+		},
+		{
+			name:    "NG: weekday does not match the date",
+			text:    "11/5(火) 14時",
+			wantErr: ErrAmbiguous,
+		},
+		{
+			name:    "NG: no such date",
+			text:    "2/30 14時",
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "NG: no such time",
+			text:    "明日 25:00",
+			wantErr: ErrInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !got.Start.Equal(tt.wantStart) || !got.End.Equal(tt.wantEnd) || got.Rest != tt.wantRest {
+				t.Errorf("Parse() = %v - %v %q, want %v - %v %q", got.Start, got.End, got.Rest, tt.wantStart, tt.wantEnd, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestParser_DefaultDurationOption(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2026, 10, 21, 9, 0, 0, 0, jst)
+	got, err := NewParser(DefaultDurationOption(30*time.Minute)).Parse("明日10時", now)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if want := time.Date(2026, 10, 22, 10, 30, 0, 0, jst); !got.End.Equal(want) {
+		t.Errorf("Parse() End = %v, want %v", got.End, want)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datetime
+
+import (
+	"fmt"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+var (
+	// ErrNotFound indicates that the text has no date and time
+	ErrNotFound = errors.New("datetime not found")
+	// ErrAmbiguous indicates that the date and time cannot be determined uniquely
+	ErrAmbiguous = errors.New("ambiguous datetime")
+	// ErrInvalid indicates that the date or time does not exist (e.g. 2/30, 25:00)
+	ErrInvalid = errors.New("invalid datetime")
+)
+
+// ParseError describes why the text cannot be parsed.
+// It matches ErrNotFound, ErrAmbiguous or ErrInvalid with errors.Is.
+type ParseError struct {
+	Reason string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Err.Error(), e.Reason)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func newParseError(err error, format string, args ...interface{}) *ParseError {
+	return &ParseError{
+		Reason: fmt.Sprintf(format, args...),
+		Err:    err,
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedupe
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/aws"
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+const (
+	// dynamoDBKey is the partition key of the table
+	dynamoDBKey = "id"
+	// dynamoDBExpiresAt is the attribute for the TTL of DynamoDB, in Unix time
+	dynamoDBExpiresAt = "expires_at"
+)
+
+// dynamoDBStore keeps the keys in a DynamoDB table, so that they are shared by the Lambda instances.
+// Enable the TTL of the table on expires_at to clean up the expired keys.
+type dynamoDBStore struct {
+	client    aws.DynamoDBClient
+	tableName string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+func NewDynamoDBStore(client aws.DynamoDBClient, tableName string, ttl time.Duration) *dynamoDBStore {
+	return &dynamoDBStore{
+		client:    client,
+		tableName: tableName,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// Claim puts the key unless it exists. The expired keys are overwritten since the TTL of DynamoDB may delete them late.
+func (s *dynamoDBStore) Claim(ctx context.Context, key string) (bool, error) {
+	now := s.now()
+	err := s.client.PutItem(ctx, &aws.PutItemInput{
+		TableName: s.tableName,
+		Item: aws.Item{
+			dynamoDBKey:       {S: key},
+			dynamoDBExpiresAt: {N: strconv.FormatInt(now.Add(s.ttl).Unix(), 10)},
+		},
+		ConditionExpression:      "attribute_not_exists(#id) OR #expires_at < :now",
+		ExpressionAttributeNames: map[string]string{"#id": dynamoDBKey, "#expires_at": dynamoDBExpiresAt},
+		ExpressionAttributeValues: aws.Item{
+			":now": {N: strconv.FormatInt(now.Unix(), 10)},
+		},
+	})
+	if errors.Is(err, aws.ErrConditionalCheckFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedupe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/aws"
+)
+
+func Test_dynamoDBStore_Claim(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   string
+		after   time.Duration
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:  "OK: already claimed",
+			table: "auriga-dedupe",
+			after: time.Minute,
+			want:  false,
+		},
+		{
+			name:  "OK: claimed again after expiration",
+			table: "auriga-dedupe",
+			after: 2 * time.Hour,
+			want:  true,
+		},
+		{
+			name:    "NG: table not found",
+			table:   "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := aws.NewLocalDynamoDB()
+			db.CreateTable("auriga-dedupe", "id")
+			now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+			s := NewDynamoDBStore(db, tt.table, time.Hour)
+			s.now = func() time.Time { return now }
+
+			first, err := s.Claim(context.Background(), "event:Ev01")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Claim() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !first {
+				t.Fatalf("Claim() = false for the first time")
+			}
+			now = now.Add(tt.after)
+			got, err := s.Claim(context.Background(), "event:Ev01")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Claim() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
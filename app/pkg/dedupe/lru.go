@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedupe
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// lruStore keeps the keys in memory. The least recently claimed keys are evicted when it is full.
+type lruStore struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// NewLRUStore builds a store holding up to size keys for ttl
+func NewLRUStore(size int, ttl time.Duration) *lruStore {
+	if size < 1 {
+		size = DefaultSize
+	}
+	return &lruStore{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (s *lruStore) Claim(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if e, ok := s.entries[key]; ok {
+		if now.Before(e.Value.(*lruEntry).expiresAt) {
+			return false, nil
+		}
+		s.order.Remove(e)
+		delete(s.entries, key)
+	}
+	s.entries[key] = s.order.PushFront(&lruEntry{key: key, expiresAt: now.Add(s.ttl)})
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry).key)
+	}
+	return true, nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedupe
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_lruStore_Claim(t *testing.T) {
+	type claim struct {
+		key   string
+		after time.Duration
+		want  bool
+	}
+	tests := []struct {
+		name   string
+		size   int
+		claims []claim
+	}{
+		{
+			name: "OK: the same key is claimed once",
+			size: 10,
+			claims: []claim{
+				{key: "event:Ev01", want: true},
+				{key: "event:Ev02", want: true},
+				{key: "event:Ev01", after: time.Minute, want: false},
+			},
+		},
+		{
+			name: "OK: the key expires after ttl",
+			size: 10,
+			claims: []claim{
+				{key: "event:Ev01", want: true},
+				{key: "event:Ev01", after: time.Hour, want: true},
+				{key: "event:Ev01", after: time.Minute, want: false},
+			},
+		},
+		{
+			name: "OK: the oldest key is evicted",
+			size: 2,
+			claims: []claim{
+				{key: "event:Ev01", want: true},
+				{key: "event:Ev02", want: true},
+				{key: "event:Ev03", want: true},
+				{key: "event:Ev01", want: true},
+				{key: "event:Ev03", want: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+			s := NewLRUStore(tt.size, time.Hour)
+			s.now = func() time.Time { return now }
+			for _, c := range tt.claims {
+				now = now.Add(c.after)
+				got, err := s.Claim(context.Background(), c.key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != c.want {
+					t.Errorf("Claim(%s) = %v, want %v", c.key, got, c.want)
+				}
+			}
+		})
+	}
+}
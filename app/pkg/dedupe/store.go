@@ -0,0 +1,34 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dedupe remembers the requests already handled, so that the ones retried or replayed by Slack are dropped.
+package dedupe
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DefaultSize = 1000
+	// DefaultTTL covers the retries of Slack, which are sent within a few minutes
+	DefaultTTL = time.Hour
+)
+
+type Store interface {
+	// Claim records the key and returns true if it is the first time, false if the key is already recorded
+	Claim(ctx context.Context, key string) (bool, error)
+}
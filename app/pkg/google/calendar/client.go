@@ -0,0 +1,156 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+const (
+	defaultBaseURL = "https://www.googleapis.com/calendar/v3/"
+)
+
+// Client is a minimal Google Calendar API v3 client
+type Client interface {
+	// InsertEvent creates an event and sends invitations to the attendees
+	InsertEvent(ctx context.Context, calendarID string, event *Event) (*Event, error)
+	// GetEvent gets the event
+	GetEvent(ctx context.Context, calendarID, eventID string) (*Event, error)
+	// PatchAttendees replaces the attendees of the event, and sends the invitations and the cancellations to the changed ones
+	PatchAttendees(ctx context.Context, calendarID, eventID string, attendees []*Attendee) (*Event, error)
+}
+
+type client struct {
+	baseURL     string
+	httpClient  *http.Client
+	tokenSource TokenSource
+}
+
+type Option func(c *client)
+
+// BaseURLOption overrides the API endpoint (e.g. a local stand-in for tests)
+func BaseURLOption(baseURL string) Option {
+	return func(c *client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+	}
+}
+
+// HTTPClientOption overrides the http.Client used for API requests
+func HTTPClientOption(httpClient *http.Client) Option {
+	return func(c *client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient builds a Calendar API client
+func NewClient(tokenSource TokenSource, options ...Option) *client {
+	c := &client{
+		baseURL:     defaultBaseURL,
+		httpClient:  http.DefaultClient,
+		tokenSource: tokenSource,
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+func (c *client) InsertEvent(ctx context.Context, calendarID string, event *Event) (*Event, error) {
+	query := url.Values{}
+	query.Set("sendUpdates", "all")
+	var created Event
+	path := fmt.Sprintf("calendars/%s/events", url.PathEscape(calendarID))
+	if err := c.do(ctx, http.MethodPost, path, query, event, &created); err != nil {
+		return nil, errors.Wrap(err, "failed to insert event")
+	}
+	return &created, nil
+}
+
+func (c *client) GetEvent(ctx context.Context, calendarID, eventID string) (*Event, error) {
+	var event Event
+	path := fmt.Sprintf("calendars/%s/events/%s", url.PathEscape(calendarID), url.PathEscape(eventID))
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &event); err != nil {
+		return nil, errors.Wrap(err, "failed to get event")
+	}
+	return &event, nil
+}
+
+func (c *client) PatchAttendees(ctx context.Context, calendarID, eventID string, attendees []*Attendee) (*Event, error) {
+	query := url.Values{}
+	query.Set("sendUpdates", "all")
+	// attendees is not omitted even if empty, to remove all of them
+	patch := struct {
+		Attendees []*Attendee `json:"attendees"`
+	}{Attendees: append([]*Attendee{}, attendees...)}
+	var patched Event
+	path := fmt.Sprintf("calendars/%s/events/%s", url.PathEscape(calendarID), url.PathEscape(eventID))
+	if err := c.do(ctx, http.MethodPatch, path, query, patch, &patched); err != nil {
+		return nil, errors.Wrap(err, "failed to patch attendees")
+	}
+	return &patched, nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return err
+	}
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return newAPIError(res)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
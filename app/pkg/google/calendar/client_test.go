@@ -0,0 +1,209 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+func Test_client_InsertEvent(t *testing.T) {
+	event := &Event{
+		Summary: "Sprint review",
+		Start:   &EventTime{DateTime: "2026-11-02T15:00:00+09:00", TimeZone: "Asia/Tokyo"},
+		End:     &EventTime{DateTime: "2026-11-02T16:00:00+09:00", TimeZone: "Asia/Tokyo"},
+		Attendees: []*Attendee{
+			{Email: "user01@example.com"},
+			{Email: "user02@example.com"},
+		},
+	}
+	tests := []struct {
+		name       string
+		calendarID string
+		handler    http.HandlerFunc
+		want       *Event
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "OK",
+			calendarID: "team@example.com",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.EscapedPath() != "/calendars/team@example.com/events" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+				}
+				if got := r.URL.Query().Get("sendUpdates"); got != "all" {
+					t.Errorf("sendUpdates = %v, want all", got)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer sample_token" {
+					t.Errorf("Authorization = %v", got)
+				}
+				var got Event
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(&got, event) {
+					t.Errorf("request body = %+v, want %+v", got, event)
+				}
+				got.ID = "event01"
+				got.HTMLLink = "https://calendar.google.com/event?eid=event01"
+				_ = json.NewEncoder(w).Encode(got)
+			},
+			want: &Event{
+				ID:        "event01",
+				Summary:   event.Summary,
+				Start:     event.Start,
+				End:       event.End,
+				Attendees: event.Attendees,
+				HTMLLink:  "https://calendar.google.com/event?eid=event01",
+			},
+		},
+		{
+			name:       "NG: api error",
+			calendarID: "primary",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":{"code":403,"message":"Service accounts cannot invite attendees"}}`))
+			},
+			wantStatus: http.StatusForbidden,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+			c := NewClient(StaticTokenSource("sample_token"), BaseURLOption(server.URL))
+			got, err := c.InsertEvent(context.Background(), tt.calendarID, event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InsertEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Errorf("InsertEvent() error = %v, want status %v", err, tt.wantStatus)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InsertEvent() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_client_GetEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    *Event
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.EscapedPath() != "/calendars/primary/events/event01" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+				}
+				_, _ = w.Write([]byte(`{"id":"event01","summary":"Sprint review","attendees":[{"email":"user01@example.com","optional":true,"responseStatus":"accepted"}]}`))
+			},
+			want: &Event{
+				ID:        "event01",
+				Summary:   "Sprint review",
+				Attendees: []*Attendee{{Email: "user01@example.com", Optional: true, ResponseStatus: "accepted"}},
+			},
+		},
+		{
+			name: "NG: api error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+			c := NewClient(StaticTokenSource("sample_token"), BaseURLOption(server.URL))
+			got, err := c.GetEvent(context.Background(), "primary", "event01")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetEvent() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_client_PatchAttendees(t *testing.T) {
+	tests := []struct {
+		name      string
+		attendees []*Attendee
+		wantBody  string
+		wantErr   bool
+	}{
+		{
+			name:      "OK",
+			attendees: []*Attendee{{Email: "user01@example.com", ResponseStatus: "accepted"}, {Email: "user02@example.com"}},
+			wantBody:  `{"attendees":[{"email":"user01@example.com","responseStatus":"accepted"},{"email":"user02@example.com"}]}`,
+		},
+		{
+			name:     "OK: remove all the attendees",
+			wantBody: `{"attendees":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch || r.URL.EscapedPath() != "/calendars/primary/events/event01" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+				}
+				if got := r.URL.Query().Get("sendUpdates"); got != "all" {
+					t.Errorf("sendUpdates = %v, want all", got)
+				}
+				var body json.RawMessage
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				if string(body) != tt.wantBody {
+					t.Errorf("request body = %s, want %s", body, tt.wantBody)
+				}
+				_, _ = w.Write([]byte(`{"id":"event01"}`))
+			}))
+			defer server.Close()
+			c := NewClient(StaticTokenSource("sample_token"), BaseURLOption(server.URL))
+			got, err := c.PatchAttendees(context.Background(), "primary", "event01", tt.attendees)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PatchAttendees() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.ID != "event01" {
+				t.Errorf("PatchAttendees() got = %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calendar
+
+// Event is the subset of the Calendar API event resource used by Auriga
+// See: https://developers.google.com/calendar/api/v3/reference/events
+type Event struct {
+	ID          string      `json:"id,omitempty"`
+	Summary     string      `json:"summary,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Start       *EventTime  `json:"start,omitempty"`
+	End         *EventTime  `json:"end,omitempty"`
+	Attendees   []*Attendee `json:"attendees,omitempty"`
+	HTMLLink    string      `json:"htmlLink,omitempty"`
+}
+
+type EventTime struct {
+	// DateTime is RFC3339 formatted
+	DateTime string `json:"dateTime,omitempty"`
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Attendee keeps the fields written by the organizer, which are lost unless they are patched back
+type Attendee struct {
+	Email          string `json:"email"`
+	DisplayName    string `json:"displayName,omitempty"`
+	Optional       bool   `json:"optional,omitempty"`
+	Comment        string `json:"comment,omitempty"`
+	ResponseStatus string `json:"responseStatus,omitempty"`
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calendar
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// TemplateBaseURL is the page of Google Calendar to create an event prefilled with the parameters,
+	// which needs no credentials since the user creates the event
+	TemplateBaseURL = "https://calendar.google.com/calendar/render"
+	// DefaultMaxTemplateURLLength keeps the links working in the browsers and Slack
+	DefaultMaxTemplateURLLength = 2000
+
+	templateDateFormat = "20060102T150405Z"
+)
+
+// Template is the event to prefill in the page
+type Template struct {
+	Title   string
+	Details string
+	Start   time.Time
+	End     time.Time
+	Guests  []string
+}
+
+// TemplateURLs returns the links to create the event. If the guests make a link longer than maxLength,
+// they are split into several links with the same title, dates and details. A link exceeds maxLength
+// only if a single guest does not fit in it.
+func TemplateURLs(t *Template, maxLength int) []string {
+	base := TemplateBaseURL + "?action=TEMPLATE" +
+		"&text=" + url.QueryEscape(t.Title) +
+		"&dates=" + t.Start.UTC().Format(templateDateFormat) + "/" + t.End.UTC().Format(templateDateFormat)
+	if t.Details != "" {
+		base += "&details=" + url.QueryEscape(t.Details)
+	}
+	if len(t.Guests) == 0 {
+		return []string{base}
+	}
+	var urls []string
+	var b strings.Builder
+	b.WriteString(base)
+	guests := 0
+	for _, guest := range t.Guests {
+		param := "&add=" + url.QueryEscape(guest)
+		if guests > 0 && b.Len()+len(param) > maxLength {
+			urls = append(urls, b.String())
+			b.Reset()
+			b.WriteString(base)
+			guests = 0
+		}
+		b.WriteString(param)
+		guests++
+	}
+	return append(urls, b.String())
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calendar
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_TemplateURLs(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	base := "https://calendar.google.com/calendar/render?action=TEMPLATE&text=Sprint+review+%26+retro&dates=20261105T050000Z/20261105T060000Z" +
+		"&details=https%3A%2F%2Fexample.slack.com%2Farchives%2FC01%2Fp1"
+	template := &Template{
+		Title:   "Sprint review & retro",
+		Details: "https://example.slack.com/archives/C01/p1",
+		Start:   time.Date(2026, 11, 5, 14, 0, 0, 0, jst),
+		End:     time.Date(2026, 11, 5, 15, 0, 0, 0, jst),
+	}
+	tests := []struct {
+		name      string
+		guests    []string
+		maxLength int
+		want      []string
+	}{
+		{
+			name:      "OK: no guests",
+			maxLength: DefaultMaxTemplateURLLength,
+			want:      []string{base},
+		},
+		{
+			name:      "OK: in a link",
+			guests:    []string{"user01@example.com", "user+02@example.com"},
+			maxLength: DefaultMaxTemplateURLLength,
+			want:      []string{base + "&add=user01%40example.com&add=user%2B02%40example.com"},
+		},
+		{
+			name:      "OK: split into links",
+			guests:    []string{"user01@example.com", "user02@example.com", "user03@example.com"},
+			maxLength: len(base) + 2*len("&add=user01%40example.com"),
+			want: []string{
+				base + "&add=user01%40example.com&add=user02%40example.com",
+				base + "&add=user03%40example.com",
+			},
+		},
+		{
+			name:      "OK: a guest longer than the limit",
+			guests:    []string{"user01@example.com", "user02@example.com"},
+			maxLength: len(base),
+			want: []string{
+				base + "&add=user01%40example.com",
+				base + "&add=user02%40example.com",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template.Guests = tt.guests
+			got := TemplateURLs(template, tt.maxLength)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TemplateURLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
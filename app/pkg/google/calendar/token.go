@@ -0,0 +1,204 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calendar
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+const (
+	// ScopeCalendarEvents is the OAuth scope required to create and update events
+	ScopeCalendarEvents = "https://www.googleapis.com/auth/calendar.events"
+
+	defaultTokenURI = "https://oauth2.googleapis.com/token"
+
+	// tokenExpiryDelta refreshes the token a little before it actually expires
+	tokenExpiryDelta = time.Minute
+)
+
+// TokenSource returns an OAuth2 access token for the Calendar API
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+type staticTokenSource string
+
+// StaticTokenSource returns a TokenSource that always returns the given token
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+func (s staticTokenSource) Token(_ context.Context) (string, error) {
+	return string(s), nil
+}
+
+// serviceAccountKey is the subset of the service account JSON key file used for the JWT flow
+type serviceAccountKey struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+}
+
+type serviceAccountTokenSource struct {
+	key        *serviceAccountKey
+	privateKey *rsa.PrivateKey
+	subject    string
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewServiceAccountTokenSource builds a TokenSource from a service account JSON key file.
+// subject is the user impersonated with domain-wide delegation.
+// A service account cannot invite attendees by itself, so subject should be set in most cases.
+func NewServiceAccountTokenSource(credentialsFile, subject string) (*serviceAccountTokenSource, error) {
+	b, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read credentials file")
+	}
+	return newServiceAccountTokenSource(b, subject, http.DefaultClient)
+}
+
+func newServiceAccountTokenSource(credentials []byte, subject string, httpClient *http.Client) (*serviceAccountTokenSource, error) {
+	var key serviceAccountKey
+	if err := json.Unmarshal(credentials, &key); err != nil {
+		return nil, errors.Wrap(err, "failed to parse credentials")
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = defaultTokenURI
+	}
+	privateKey, err := parsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &serviceAccountTokenSource{
+		key:        &key,
+		privateKey: privateKey,
+		subject:    subject,
+		httpClient: httpClient,
+	}, nil
+}
+
+func parsePrivateKey(s string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private key")
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA")
+	}
+	return key, nil
+}
+
+func (s *serviceAccountTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Now().Add(tokenExpiryDelta).Before(s.expiry) {
+		return s.token, nil
+	}
+
+	assertion, err := s.assertion(time.Now())
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.key.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to request token")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to request token: status %d", res.StatusCode)
+	}
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", errors.Wrap(err, "failed to decode token response")
+	}
+	s.token = body.AccessToken
+	s.expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+// assertion builds the RS256 signed JWT exchanged for an access token
+func (s *serviceAccountTokenSource) assertion(now time.Time) (string, error) {
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	if s.key.PrivateKeyID != "" {
+		header["kid"] = s.key.PrivateKeyID
+	}
+	claims := map[string]interface{}{
+		"iss":   s.key.ClientEmail,
+		"scope": ScopeCalendarEvents,
+		"aud":   s.key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+	if s.subject != "" {
+		claims["sub"] = s.subject
+	}
+	h, err := json.Marshal(header)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal jwt header")
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal jwt claims")
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign jwt")
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
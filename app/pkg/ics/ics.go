@@ -0,0 +1,174 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ics writes the events in the iCalendar format (RFC 5545),
+// so that they can be imported to Outlook, Apple Calendar and the others.
+package ics
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultProdID is the identifier of the product which writes the calendar
+	DefaultProdID = "-//Money Forward, Inc.//Auriga//EN"
+
+	// maxLineOctets is the length of a content line, excluding the line break, above which it is folded
+	maxLineOctets = 75
+	// utcFormat is the format of DATE-TIME in UTC
+	utcFormat = "20060102T150405Z"
+)
+
+// Calendar is an iCalendar object
+type Calendar struct {
+	// ProdID is DefaultProdID if empty
+	ProdID string
+	// Method is e.g. "REQUEST" to send the events as invitations, or omitted if empty
+	Method string
+	Events []*Event
+}
+
+// Event is a VEVENT component
+type Event struct {
+	// UID identifies the event globally, e.g. "1667283600.123456-C01@auriga"
+	UID string
+	// Stamp is when the event is written, which is DTSTAMP
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	URL         string
+	// Organizer is omitted if nil
+	Organizer *Person
+	Attendees []*Person
+}
+
+// Person is the organizer or an attendee of the event
+type Person struct {
+	Email string
+	// Name is the common name (CN), or omitted if empty
+	Name string
+}
+
+// String returns the calendar in the iCalendar format, whose lines end with CRLF
+func (c *Calendar) String() string {
+	w := &writer{}
+	w.line("BEGIN", nil, "VCALENDAR")
+	w.line("VERSION", nil, "2.0")
+	prodID := c.ProdID
+	if prodID == "" {
+		prodID = DefaultProdID
+	}
+	w.line("PRODID", nil, escapeText(prodID))
+	w.line("CALSCALE", nil, "GREGORIAN")
+	if c.Method != "" {
+		w.line("METHOD", nil, c.Method)
+	}
+	for _, e := range c.Events {
+		e.write(w)
+	}
+	w.line("END", nil, "VCALENDAR")
+	return w.b.String()
+}
+
+func (e *Event) write(w *writer) {
+	w.line("BEGIN", nil, "VEVENT")
+	w.line("UID", nil, escapeText(e.UID))
+	w.line("DTSTAMP", nil, e.Stamp.UTC().Format(utcFormat))
+	w.line("DTSTART", nil, e.Start.UTC().Format(utcFormat))
+	w.line("DTEND", nil, e.End.UTC().Format(utcFormat))
+	w.line("SUMMARY", nil, escapeText(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION", nil, escapeText(e.Description))
+	}
+	if e.URL != "" {
+		w.line("URL", nil, e.URL)
+	}
+	if e.Organizer != nil {
+		w.line("ORGANIZER", e.Organizer.params(), "mailto:"+e.Organizer.Email)
+	}
+	for _, a := range e.Attendees {
+		params := append(a.params(), "ROLE=REQ-PARTICIPANT", "PARTSTAT=NEEDS-ACTION", "RSVP=TRUE")
+		w.line("ATTENDEE", params, "mailto:"+a.Email)
+	}
+	w.line("END", nil, "VEVENT")
+}
+
+func (p *Person) params() []string {
+	if p.Name == "" {
+		return nil
+	}
+	return []string{"CN=" + paramValue(p.Name)}
+}
+
+type writer struct {
+	b strings.Builder
+}
+
+// line writes a content line "NAME;PARAM=VALUE:value", folded by maxLineOctets
+func (w *writer) line(name string, params []string, value string) {
+	l := name
+	for _, p := range params {
+		l += ";" + p
+	}
+	w.b.WriteString(fold(l + ":" + value))
+}
+
+// fold splits the line into the lines of up to maxLineOctets octets, each of which follows a space but the first,
+// without splitting the UTF-8 sequences of the characters
+func fold(line string) string {
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		b.WriteString(line[:i])
+		b.WriteString("\r\n ")
+		line = line[i:]
+		// the space at the beginning is counted
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeText escapes the value of TEXT
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// paramValue quotes the value of the parameter if it contains ":", ";" or ",".
+// The double quotes and the control characters, which cannot be written in the value, are dropped.
+func paramValue(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '"' || (r < 0x20 && r != '\t') || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+	if strings.ContainsAny(s, ":;,") {
+		return `"` + s + `"`
+	}
+	return s
+}
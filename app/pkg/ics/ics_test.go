@@ -0,0 +1,201 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_fold(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{
+			name: "OK: short line",
+			line: "SUMMARY:Sprint review",
+			want: "SUMMARY:Sprint review\r\n",
+		},
+		{
+			name: "OK: 75 octets",
+			line: strings.Repeat("a", 75),
+			want: strings.Repeat("a", 75) + "\r\n",
+		},
+		{
+			name: "OK: folded into 75 octets including the leading space",
+			line: strings.Repeat("a", 75) + strings.Repeat("b", 74) + "c",
+			want: strings.Repeat("a", 75) + "\r\n " + strings.Repeat("b", 74) + "\r\n c\r\n",
+		},
+		{
+			name: "OK: multibyte characters are not split",
+			// "あ" is 3 octets, so the 25th one does not fit after "SUMMARY:"
+			line: "SUMMARY:" + strings.Repeat("あ", 25),
+			want: "SUMMARY:" + strings.Repeat("あ", 22) + "\r\n " + strings.Repeat("あ", 3) + "\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fold(tt.line)
+			if got != tt.want {
+				t.Errorf("fold() = %q, want %q", got, tt.want)
+			}
+			for _, l := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+				if len(l) > maxLineOctets {
+					t.Errorf("fold() has a line of %d octets: %q", len(l), l)
+				}
+			}
+		})
+	}
+}
+
+func Test_escapeText(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "OK: plain text",
+			s:    "Sprint review",
+			want: "Sprint review",
+		},
+		{
+			name: "OK: special characters",
+			s:    `Review; retro, and C:\tmp`,
+			want: `Review\; retro\, and C:\\tmp`,
+		},
+		{
+			name: "OK: line breaks",
+			s:    "line1\nline2\r\nline3",
+			want: `line1\nline2\nline3`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeText(tt.s); got != tt.want {
+				t.Errorf("escapeText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_paramValue(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "OK: plain value",
+			s:    "Taro Yamada",
+			want: "Taro Yamada",
+		},
+		{
+			name: "OK: quoted",
+			s:    "Yamada, Taro",
+			want: `"Yamada, Taro"`,
+		},
+		{
+			name: "OK: double quotes and control characters are dropped",
+			s:    "\"Taro\"\n",
+			want: "Taro",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paramValue(tt.s); got != tt.want {
+				t.Errorf("paramValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Calendar_String(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	tests := []struct {
+		name     string
+		calendar *Calendar
+		want     []string
+	}{
+		{
+			name: "OK: event with the organizer and the attendees",
+			calendar: &Calendar{
+				Method: "REQUEST",
+				Events: []*Event{{
+					UID:         "1667283600.123456-C01@auriga",
+					Stamp:       time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC),
+					Start:       time.Date(2022, 11, 5, 14, 0, 0, 0, jst),
+					End:         time.Date(2022, 11, 5, 15, 0, 0, 0, jst),
+					Summary:     "Review, retro",
+					Description: "https://example.slack.com/archives/C01/p1667283600123456",
+					URL:         "https://example.slack.com/archives/C01/p1667283600123456",
+					Organizer:   &Person{Email: "user01@example.com", Name: "user01"},
+					Attendees: []*Person{
+						{Email: "user02@example.com", Name: "Yamada, Taro"},
+						{Email: "user03@example.com"},
+					},
+				}},
+			},
+			want: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//Money Forward\\, Inc.//Auriga//EN",
+				"CALSCALE:GREGORIAN",
+				"METHOD:REQUEST",
+				"BEGIN:VEVENT",
+				"UID:1667283600.123456-C01@auriga",
+				"DTSTAMP:20221101T150000Z",
+				"DTSTART:20221105T050000Z",
+				"DTEND:20221105T060000Z",
+				"SUMMARY:Review\\, retro",
+				"DESCRIPTION:https://example.slack.com/archives/C01/p1667283600123456",
+				"URL:https://example.slack.com/archives/C01/p1667283600123456",
+				"ORGANIZER;CN=user01:mailto:user01@example.com",
+				"ATTENDEE;CN=\"Yamada, Taro\";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=",
+				" TRUE:mailto:user02@example.com",
+				"ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:user03",
+				" @example.com",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			},
+		},
+		{
+			name: "OK: no events",
+			calendar: &Calendar{
+				ProdID: "-//Example//Test//EN",
+			},
+			want: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//Example//Test//EN",
+				"CALSCALE:GREGORIAN",
+				"END:VCALENDAR",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := strings.Join(tt.want, "\r\n") + "\r\n"
+			if got := tt.calendar.String(); got != want {
+				t.Errorf("String() = %q, want %q", got, want)
+			}
+		})
+	}
+}
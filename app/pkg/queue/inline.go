@@ -0,0 +1,43 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queue
+
+import (
+	"context"
+)
+
+// inline processes the job in Enqueue, which is the behavior without a queue
+type inline struct {
+	handler Handler
+}
+
+func NewInline() *inline {
+	return &inline{}
+}
+
+func (q *inline) Start(handler Handler) {
+	q.handler = handler
+}
+
+func (q *inline) Enqueue(ctx context.Context, job *Job) error {
+	q.handler(ctx, job)
+	return nil
+}
+
+func (q *inline) Shutdown(ctx context.Context) error {
+	return nil
+}
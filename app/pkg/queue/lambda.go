@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+// envelopeKey marks the payload of the invocation as a job, not a request from API Gateway
+const envelopeKey = "auriga_job"
+
+// Invoker invokes a Lambda function asynchronously
+type Invoker interface {
+	InvokeAsync(ctx context.Context, functionName string, payload []byte) error
+}
+
+// lambdaQueue hands off the job by invoking the Lambda function itself asynchronously.
+// The invoked function receives the job with ParseEnvelope and processes it.
+type lambdaQueue struct {
+	invoker      Invoker
+	functionName string
+}
+
+func NewLambdaQueue(invoker Invoker, functionName string) *lambdaQueue {
+	return &lambdaQueue{
+		invoker:      invoker,
+		functionName: functionName,
+	}
+}
+
+// Start does nothing since the job is processed by another invocation
+func (q *lambdaQueue) Start(handler Handler) {
+}
+
+func (q *lambdaQueue) Enqueue(ctx context.Context, job *Job) error {
+	payload, err := json.Marshal(map[string]*Job{envelopeKey: job})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal job")
+	}
+	return q.invoker.InvokeAsync(ctx, q.functionName, payload)
+}
+
+func (q *lambdaQueue) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// ParseEnvelope returns the job if the payload of the invocation is enqueued by lambdaQueue
+func ParseEnvelope(payload []byte) (*Job, bool) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &envelope); err != nil || envelope[envelopeKey] == nil {
+		return nil, false
+	}
+	var job Job
+	if err := json.Unmarshal(envelope[envelopeKey], &job); err != nil {
+		return nil, false
+	}
+	return &job, true
+}
+
+// LocalInvoker is a local stand-in for Lambda, which delivers the payload to Receive in another goroutine
+type LocalInvoker struct {
+	Receive func(ctx context.Context, payload []byte)
+}
+
+func (i *LocalInvoker) InvokeAsync(ctx context.Context, functionName string, payload []byte) error {
+	go i.Receive(context.Background(), payload)
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queue
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_lambdaQueue(t *testing.T) {
+	received := make(chan *Job, 1)
+	invoker := &LocalInvoker{
+		Receive: func(ctx context.Context, payload []byte) {
+			job, ok := ParseEnvelope(payload)
+			if !ok {
+				t.Errorf("ParseEnvelope(%s) is not a job", payload)
+			}
+			received <- job
+		},
+	}
+	q := NewLambdaQueue(invoker, "auriga-cmd")
+	want := &Job{Kind: "event", Body: `{"type":"event_callback"}`}
+	if err := q.Enqueue(context.Background(), want); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-received:
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("received = %+v, want %+v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the job is not received")
+	}
+}
+
+func TestParseEnvelope(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *Job
+		wantOK  bool
+	}{
+		{
+			name:    "OK",
+			payload: `{"auriga_job":{"kind":"command","body":"{}"}}`,
+			want:    &Job{Kind: "command", Body: "{}"},
+			wantOK:  true,
+		},
+		{
+			name:    "NG: request from API Gateway",
+			payload: `{"resource":"/callback","httpMethod":"POST","body":"{}"}`,
+		},
+		{
+			name:    "NG: not an object",
+			payload: `"sample"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseEnvelope([]byte(tt.payload))
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEnvelope() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
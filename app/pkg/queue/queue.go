@@ -0,0 +1,51 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package queue hands off the requests from Slack to be processed in the background,
+// so that the listener can acknowledge them within 3 seconds.
+package queue
+
+import (
+	"context"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+var (
+	// ErrFull is returned when the queue has no room for the job
+	ErrFull = errors.New("queue is full")
+	// ErrClosed is returned when the queue is shutting down
+	ErrClosed = errors.New("queue is closed")
+)
+
+// Job is a request from Slack to be processed
+type Job struct {
+	// Kind tells how to read Body (e.g. an event or a slash command)
+	Kind string `json:"kind"`
+	Body string `json:"body"`
+}
+
+// Handler processes a job
+type Handler func(ctx context.Context, job *Job)
+
+type Queue interface {
+	// Start begins to process the jobs with handler
+	Start(handler Handler)
+	// Enqueue hands off the job. It returns ErrFull if there is no room for it.
+	Enqueue(ctx context.Context, job *Job) error
+	// Shutdown stops accepting jobs and waits for the ones in progress until ctx is done
+	Shutdown(ctx context.Context) error
+}
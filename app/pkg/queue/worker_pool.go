@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queue
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultConcurrency = 4
+	DefaultCapacity    = 100
+	// DefaultEnqueueWait is how long Enqueue waits for room, well within the 3 seconds Slack waits for the response
+	DefaultEnqueueWait = time.Second
+)
+
+// workerPool processes the jobs in the background with a bounded number of goroutines
+type workerPool struct {
+	concurrency int
+	jobs        chan *Job
+	// wait is how long Enqueue waits for room when the queue is full
+	wait time.Duration
+	// mu guards closed, so that no job is sent to the closed channel
+	mu     sync.RWMutex
+	closed bool
+	// closing releases Enqueue waiting for room on Shutdown
+	closing   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewWorkerPool builds a queue processing up to concurrency jobs at once and holding up to capacity waiting jobs
+func NewWorkerPool(concurrency, capacity int) *workerPool {
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+	if capacity < 0 {
+		capacity = DefaultCapacity
+	}
+	return &workerPool{
+		concurrency: concurrency,
+		jobs:        make(chan *Job, capacity),
+		wait:        DefaultEnqueueWait,
+		closing:     make(chan struct{}),
+	}
+}
+
+func (q *workerPool) Start(handler Handler) {
+	for i := 0; i < q.concurrency; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for job := range q.jobs {
+				q.run(handler, job)
+			}
+		}()
+	}
+}
+
+// run processes the job, keeping the worker alive even if the handler panics
+func (q *workerPool) run(handler Handler, job *Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Recovered from panic in %s job: %v", job.Kind, r)
+		}
+	}()
+	handler(context.Background(), job)
+}
+
+// Enqueue waits for room up to the wait of the queue or until ctx is done, and returns ErrFull then
+func (q *workerPool) Enqueue(ctx context.Context, job *Job) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrClosed
+	}
+	timer := time.NewTimer(q.wait)
+	defer timer.Stop()
+	select {
+	case q.jobs <- job:
+		return nil
+	case <-q.closing:
+		return ErrClosed
+	case <-ctx.Done():
+		return ErrFull
+	case <-timer.C:
+		return ErrFull
+	}
+}
+
+func (q *workerPool) Shutdown(ctx context.Context) error {
+	q.closeOnce.Do(func() { close(q.closing) })
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
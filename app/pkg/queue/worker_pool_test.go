@@ -0,0 +1,165 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+func Test_workerPool(t *testing.T) {
+	const concurrency = 2
+	q := NewWorkerPool(concurrency, 10)
+	var running, maxRunning int32
+	var mu sync.Mutex
+	var done []string
+	q.Start(func(ctx context.Context, job *Job) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		mu.Lock()
+		done = append(done, job.Body)
+		mu.Unlock()
+	})
+	for i := 0; i < 6; i++ {
+		if err := q.Enqueue(context.Background(), &Job{Kind: "sample", Body: "job"}); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+	if err := q.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if len(done) != 6 {
+		t.Errorf("done = %d jobs, want 6", len(done))
+	}
+	if maxRunning > concurrency {
+		t.Errorf("max running = %d, want <= %d", maxRunning, concurrency)
+	}
+	if err := q.Enqueue(context.Background(), &Job{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Enqueue() after Shutdown error = %v, want %v", err, ErrClosed)
+	}
+}
+
+func Test_workerPool_Enqueue_full(t *testing.T) {
+	q := NewWorkerPool(1, 1)
+	q.wait = 10 * time.Millisecond
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	q.Start(func(ctx context.Context, job *Job) {
+		started <- struct{}{}
+		<-release
+	})
+	defer func() {
+		close(release)
+		_ = q.Shutdown(context.Background())
+	}()
+	if err := q.Enqueue(context.Background(), &Job{}); err != nil {
+		t.Fatal(err)
+	}
+	<-started // the worker is busy
+	if err := q.Enqueue(context.Background(), &Job{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Enqueue(context.Background(), &Job{}); !errors.Is(err, ErrFull) {
+		t.Errorf("Enqueue() error = %v, want %v", err, ErrFull)
+	}
+}
+
+func Test_workerPool_Enqueue_wait(t *testing.T) {
+	q := NewWorkerPool(1, 1)
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	q.Start(func(ctx context.Context, job *Job) {
+		started <- struct{}{}
+		<-release
+	})
+	defer func() {
+		_ = q.Shutdown(context.Background())
+	}()
+	if err := q.Enqueue(context.Background(), &Job{}); err != nil {
+		t.Fatal(err)
+	}
+	<-started // the worker is busy
+	if err := q.Enqueue(context.Background(), &Job{}); err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(10*time.Millisecond, func() { close(release) })
+	// the queue has room again once the worker takes the second job
+	if err := q.Enqueue(context.Background(), &Job{}); err != nil {
+		t.Errorf("Enqueue() error = %v, want nil", err)
+	}
+}
+
+func Test_workerPool_Enqueue_shutdown(t *testing.T) {
+	q := NewWorkerPool(1, 1)
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	q.Start(func(ctx context.Context, job *Job) {
+		started <- struct{}{}
+		<-release
+	})
+	_ = q.Enqueue(context.Background(), &Job{})
+	<-started
+	_ = q.Enqueue(context.Background(), &Job{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- q.Enqueue(context.Background(), &Job{})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	done := make(chan error, 1)
+	go func() {
+		done <- q.Shutdown(context.Background())
+	}()
+	// Shutdown releases the job waiting for room
+	if err := <-errCh; !errors.Is(err, ErrClosed) {
+		t.Errorf("Enqueue() error = %v, want %v", err, ErrClosed)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_workerPool_recover(t *testing.T) {
+	q := NewWorkerPool(1, 2)
+	var handled int32
+	q.Start(func(ctx context.Context, job *Job) {
+		if job.Body == "panic" {
+			panic("sample panic")
+		}
+		atomic.AddInt32(&handled, 1)
+	})
+	_ = q.Enqueue(context.Background(), &Job{Body: "panic"})
+	_ = q.Enqueue(context.Background(), &Job{Body: "ok"})
+	if err := q.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if handled != 1 {
+		t.Errorf("handled = %d, want 1", handled)
+	}
+}
@@ -26,17 +26,37 @@ import (
 type Client interface {
 	PostMessage(ctx context.Context, channelID, message, ts string) error
 	PostEphemeral(ctx context.Context, channelID, userID, ts, message string) error
+	PostBlocks(ctx context.Context, channelID, ts, text string, blocks []slack.Block) error
 	GetConversationReplies(ctx context.Context, channelID, ts string) ([]slack.Message, error)
+	// GetThreadReplies gets all the messages of the thread of ts including the parent, following the pages
+	GetThreadReplies(ctx context.Context, channelID, ts string) ([]slack.Message, error)
 	GetUsersInfo(ctx context.Context, userID ...string) (*[]slack.User, error)
+	// GetUserGroupMembers gets the IDs of the users in the user group
+	GetUserGroupMembers(ctx context.Context, userGroupID string) ([]string, error)
+	// GetChannelMembers gets the IDs of the users in the channel, following the pages
+	GetChannelMembers(ctx context.Context, channelID string) ([]string, error)
 	GetReaction(ctx context.Context, channelID, ts string, full bool) ([]slack.ItemReaction, error)
+	GetPermalink(ctx context.Context, channelID, ts string) (string, error)
+	PostResponse(ctx context.Context, responseURL, message string, inChannel bool) error
+	ReplaceResponse(ctx context.Context, responseURL, text string, blocks []slack.Block) error
+	OpenView(ctx context.Context, triggerID string, view slack.ModalViewRequest) error
+	UpdateView(ctx context.Context, viewID, hash string, view slack.ModalViewRequest) error
+	UploadFile(ctx context.Context, channelID, ts, filename, content, comment string) error
 
 	GetClient() *slack.Client
 	GetAppUserID() string
+	// GetTeamID returns the ID of the workspace the app is installed in
+	GetTeamID() string
+	// GetEnterpriseID returns the ID of the Enterprise Grid organization of the workspace, or "" if it is not in one
+	GetEnterpriseID() string
 }
 
 type client struct {
 	*slack.Client
-	appUserID string
+	appUserID    string
+	teamID       string
+	enterpriseID string
+	retrier      *retrier
 }
 
 type Option = slack.Option
@@ -53,18 +73,24 @@ func NewClient(botToken string, options ...Option) (*client, error) {
 		return nil, errors.Wrap(err, "failed to authenticate test")
 	}
 	return &client{
-		Client:    c,
-		appUserID: at.UserID,
+		Client:       c,
+		appUserID:    at.UserID,
+		teamID:       at.TeamID,
+		enterpriseID: at.EnterpriseID,
+		retrier:      newRetrier(),
 	}, nil
 }
 
 func (c *client) PostMessage(ctx context.Context, channelID, message, ts string) error {
-	_, _, err := c.PostMessageContext(
-		ctx,
-		channelID,
-		slack.MsgOptionTS(ts),
-		slack.MsgOptionText(message, false),
-	)
+	err := c.retrier.do(ctx, "chat.postMessage", func() error {
+		_, _, err := c.PostMessageContext(
+			ctx,
+			channelID,
+			slack.MsgOptionTS(ts),
+			slack.MsgOptionText(message, false),
+		)
+		return err
+	})
 
 	if err != nil {
 		return errors.Wrap(err, "failed to post message")
@@ -74,13 +100,36 @@ func (c *client) PostMessage(ctx context.Context, channelID, message, ts string)
 }
 
 func (c *client) PostEphemeral(ctx context.Context, channelID, userID, ts, message string) error {
-	_, err := c.PostEphemeralContext(
-		ctx,
-		channelID,
-		userID,
-		slack.MsgOptionTS(ts),
-		slack.MsgOptionText(message, false),
-	)
+	err := c.retrier.do(ctx, "chat.postEphemeral", func() error {
+		_, err := c.PostEphemeralContext(
+			ctx,
+			channelID,
+			userID,
+			slack.MsgOptionTS(ts),
+			slack.MsgOptionText(message, false),
+		)
+		return err
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "failed to post message")
+	}
+
+	return nil
+}
+
+// PostBlocks posts a Block Kit message. text is shown in the notifications.
+func (c *client) PostBlocks(ctx context.Context, channelID, ts, text string, blocks []slack.Block) error {
+	err := c.retrier.do(ctx, "chat.postMessage", func() error {
+		_, _, err := c.PostMessageContext(
+			ctx,
+			channelID,
+			slack.MsgOptionTS(ts),
+			slack.MsgOptionText(text, false),
+			slack.MsgOptionBlocks(blocks...),
+		)
+		return err
+	})
 
 	if err != nil {
 		return errors.Wrap(err, "failed to post message")
@@ -94,7 +143,12 @@ func (c *client) GetConversationReplies(ctx context.Context, channelID, ts strin
 		ChannelID: channelID,
 		Timestamp: ts,
 	}
-	msgs, _, _, err := c.GetConversationRepliesContext(ctx, params)
+	var msgs []slack.Message
+	err := c.retrier.do(ctx, "conversations.replies", func() error {
+		var err error
+		msgs, _, _, err = c.GetConversationRepliesContext(ctx, params)
+		return err
+	})
 	if err != nil {
 		if err.Error() == ErrThreadNotFound.Error() {
 			return nil, ErrThreadNotFound
@@ -106,8 +160,48 @@ func (c *client) GetConversationReplies(ctx context.Context, channelID, ts strin
 	return msgs, nil
 }
 
+// threadRepliesPageSize is the number of the messages of a page of conversations.replies, which Slack recommends up to 200
+const threadRepliesPageSize = 200
+
+func (c *client) GetThreadReplies(ctx context.Context, channelID, ts string) ([]slack.Message, error) {
+	params := &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: ts,
+		Limit:     threadRepliesPageSize,
+	}
+	var all []slack.Message
+	for {
+		var (
+			msgs    []slack.Message
+			hasMore bool
+			cursor  string
+		)
+		err := c.retrier.do(ctx, "conversations.replies", func() error {
+			var err error
+			msgs, hasMore, cursor, err = c.GetConversationRepliesContext(ctx, params)
+			return err
+		})
+		if err != nil {
+			if err.Error() == ErrThreadNotFound.Error() {
+				return nil, ErrThreadNotFound
+			}
+			return nil, errors.Wrap(err, "failed to get conversation replies")
+		}
+		all = append(all, msgs...)
+		if !hasMore || cursor == "" {
+			return all, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
 func (c *client) GetUsersInfo(ctx context.Context, userID ...string) (*[]slack.User, error) {
-	users, err := c.GetUsersInfoContext(ctx, userID...)
+	var users *[]slack.User
+	err := c.retrier.do(ctx, "users.info", func() error {
+		var err error
+		users, err = c.GetUsersInfoContext(ctx, userID...)
+		return err
+	})
 	if err != nil {
 		if err.Error() == ErrUserNotFound.Error() {
 			return nil, ErrUserNotFound
@@ -119,13 +213,168 @@ func (c *client) GetUsersInfo(ctx context.Context, userID ...string) (*[]slack.U
 	return users, nil
 }
 
+func (c *client) GetUserGroupMembers(ctx context.Context, userGroupID string) ([]string, error) {
+	var members []string
+	err := c.retrier.do(ctx, "usergroups.users.list", func() error {
+		var err error
+		members, err = c.GetUserGroupMembersContext(ctx, userGroupID)
+		return err
+	})
+	if err != nil {
+		if err.Error() == ErrUserGroupNotFound.Error() {
+			return nil, ErrUserGroupNotFound
+		}
+		return nil, errors.Wrap(err, "failed to get user group members")
+	}
+
+	return members, nil
+}
+
+// channelMembersPageSize is the number of the members of a page of conversations.members
+const channelMembersPageSize = 1000
+
+func (c *client) GetChannelMembers(ctx context.Context, channelID string) ([]string, error) {
+	params := &slack.GetUsersInConversationParameters{
+		ChannelID: channelID,
+		Limit:     channelMembersPageSize,
+	}
+	var all []string
+	for {
+		var (
+			members []string
+			cursor  string
+		)
+		err := c.retrier.do(ctx, "conversations.members", func() error {
+			var err error
+			members, cursor, err = c.GetUsersInConversationContext(ctx, params)
+			return err
+		})
+		if err != nil {
+			if err.Error() == ErrChannelNotFound.Error() {
+				return nil, ErrChannelNotFound
+			}
+			return nil, errors.Wrap(err, "failed to get channel members")
+		}
+		all = append(all, members...)
+		if cursor == "" {
+			return all, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
 func (c *client) GetReaction(ctx context.Context, channelID, ts string, full bool) ([]slack.ItemReaction, error) {
-	return c.Client.GetReactionsContext(ctx, slack.ItemRef{
-		Channel:   channelID,
-		Timestamp: ts,
-	}, slack.GetReactionsParameters{
-		Full: full,
+	var reactions []slack.ItemReaction
+	err := c.retrier.do(ctx, "reactions.get", func() error {
+		var err error
+		reactions, err = c.Client.GetReactionsContext(ctx, slack.ItemRef{
+			Channel:   channelID,
+			Timestamp: ts,
+		}, slack.GetReactionsParameters{
+			Full: full,
+		})
+		return err
+	})
+	return reactions, err
+}
+
+func (c *client) GetPermalink(ctx context.Context, channelID, ts string) (string, error) {
+	var permalink string
+	err := c.retrier.do(ctx, "chat.getPermalink", func() error {
+		var err error
+		permalink, err = c.GetPermalinkContext(ctx, &slack.PermalinkParameters{
+			Channel: channelID,
+			Ts:      ts,
+		})
+		return err
 	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get permalink")
+	}
+
+	return permalink, nil
+}
+
+// PostResponse sends a message to the response_url of a slash command or an interaction.
+// The message is visible only to the user unless inChannel is true.
+func (c *client) PostResponse(ctx context.Context, responseURL, message string, inChannel bool) error {
+	responseType := slack.ResponseTypeEphemeral
+	if inChannel {
+		responseType = slack.ResponseTypeInChannel
+	}
+	err := c.retrier.do(ctx, "", func() error {
+		return slack.PostWebhookContext(ctx, responseURL, &slack.WebhookMessage{
+			Text:         message,
+			ResponseType: responseType,
+		})
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to post response")
+	}
+
+	return nil
+}
+
+// ReplaceResponse replaces the message the interaction was triggered on with a Block Kit message
+func (c *client) ReplaceResponse(ctx context.Context, responseURL, text string, blocks []slack.Block) error {
+	err := c.retrier.do(ctx, "", func() error {
+		return slack.PostWebhookContext(ctx, responseURL, &slack.WebhookMessage{
+			Text:            text,
+			Blocks:          &slack.Blocks{BlockSet: blocks},
+			ReplaceOriginal: true,
+		})
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to replace response")
+	}
+
+	return nil
+}
+
+func (c *client) OpenView(ctx context.Context, triggerID string, view slack.ModalViewRequest) error {
+	err := c.retrier.do(ctx, "views.open", func() error {
+		_, err := c.OpenViewContext(ctx, triggerID, view)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to open view")
+	}
+
+	return nil
+}
+
+// UpdateView replaces the view. hash prevents the view from being overwritten by an outdated one.
+func (c *client) UpdateView(ctx context.Context, viewID, hash string, view slack.ModalViewRequest) error {
+	err := c.retrier.do(ctx, "views.update", func() error {
+		_, err := c.UpdateViewContext(ctx, view, "", hash, viewID)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update view")
+	}
+
+	return nil
+}
+
+// UploadFile shares a file with the content in the thread of ts.
+// The file is shared in the direct message from Auriga if channelID is a user ID.
+func (c *client) UploadFile(ctx context.Context, channelID, ts, filename, content, comment string) error {
+	err := c.retrier.do(ctx, "files.upload", func() error {
+		_, err := c.UploadFileContext(ctx, slack.FileUploadParameters{
+			Content:         content,
+			Filename:        filename,
+			Title:           filename,
+			InitialComment:  comment,
+			Channels:        []string{channelID},
+			ThreadTimestamp: ts,
+		})
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to upload file")
+	}
+
+	return nil
 }
 
 func (c *client) GetClient() *slack.Client {
@@ -135,3 +384,11 @@ func (c *client) GetClient() *slack.Client {
 func (c *client) GetAppUserID() string {
 	return c.appUserID
 }
+
+func (c *client) GetTeamID() string {
+	return c.teamID
+}
+
+func (c *client) GetEnterpriseID() string {
+	return c.enterpriseID
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestClient_GetThreadReplies(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []fakeResponse
+		wantTexts []string
+		wantErr   bool
+	}{
+		{
+			name: "OK: a page",
+			responses: []fakeResponse{
+				{statusCode: http.StatusOK, body: `{"ok":true,"messages":[{"text":"parent"},{"text":"reply"}],"has_more":false}`},
+			},
+			wantTexts: []string{"parent", "reply"},
+		},
+		{
+			name: "OK: pages",
+			responses: []fakeResponse{
+				{statusCode: http.StatusOK, body: `{"ok":true,"messages":[{"text":"parent"},{"text":"reply01"}],"has_more":true,"response_metadata":{"next_cursor":"next"}}`},
+				{statusCode: http.StatusOK, body: `{"ok":true,"messages":[{"text":"reply02"}],"has_more":false}`},
+			},
+			wantTexts: []string{"parent", "reply01", "reply02"},
+		},
+		{
+			name: "NG: thread not found",
+			responses: []fakeResponse{
+				{statusCode: http.StatusOK, body: `{"ok":false,"error":"thread_not_found"}`},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeSlackAPI{responses: tt.responses}
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			c, err := NewClient("xoxb-test", slack.OptionAPIURL(server.URL+"/"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			msgs, err := c.GetThreadReplies(context.Background(), "C0123456789", "1667283600.123456")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetThreadReplies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(msgs) != len(tt.wantTexts) {
+				t.Fatalf("GetThreadReplies() got %d messages, want %d", len(msgs), len(tt.wantTexts))
+			}
+			for i, msg := range msgs {
+				if msg.Text != tt.wantTexts[i] {
+					t.Errorf("GetThreadReplies() text[%d] = %v, want %v", i, msg.Text, tt.wantTexts[i])
+				}
+			}
+			if api.calls != len(tt.responses) {
+				t.Errorf("GetThreadReplies() calls = %v, want %v", api.calls, len(tt.responses))
+			}
+		})
+	}
+}
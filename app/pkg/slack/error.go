@@ -25,4 +25,7 @@ func (e APIError) Error() string {
 const (
 	ErrThreadNotFound APIError = "thread_not_found"
 	ErrUserNotFound   APIError = "user_not_found"
+	// ErrChannelNotFound is returned for the channels Auriga cannot see, such as the private ones it has not joined
+	ErrChannelNotFound   APIError = "channel_not_found"
+	ErrUserGroupNotFound APIError = "no_such_subteam"
 )
@@ -19,11 +19,25 @@ package slack
 import (
 	"context"
 
+	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 )
 
 type HandlerFactory interface {
 	MentionEventHandler() MentionEventHandler
+	SlashCommandHandler() SlashCommandHandler
+	InteractionHandler() InteractionHandler
+	UserChangeEventHandler() UserChangeEventHandler
+	ReactionEventHandler() ReactionEventHandler
 }
 
 type MentionEventHandler func(ctx context.Context, event *slackevents.AppMentionEvent)
+
+type SlashCommandHandler func(ctx context.Context, command *slack.SlashCommand)
+
+type InteractionHandler func(ctx context.Context, callback *slack.InteractionCallback)
+
+type UserChangeEventHandler func(ctx context.Context, event *slack.UserChangeEvent)
+
+// ReactionEventHandler handles reaction_added and reaction_removed on the item by the user
+type ReactionEventHandler func(ctx context.Context, userID, reaction string, item slackevents.Item)
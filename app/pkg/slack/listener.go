@@ -19,6 +19,7 @@ package slack
 import (
 	"context"
 
+	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 )
 
@@ -27,3 +28,7 @@ type Listener interface {
 }
 
 type EventHandlerFunc func(context.Context, slackevents.EventsAPIInnerEvent)
+
+type SlashCommandHandlerFunc func(context.Context, slack.SlashCommand)
+
+type InteractionHandlerFunc func(context.Context, slack.InteractionCallback)
@@ -0,0 +1,265 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/dedupe"
+	"github.com/moneyforward/auriga/app/pkg/errors"
+	"github.com/moneyforward/auriga/app/pkg/queue"
+	pkgslack "github.com/moneyforward/auriga/app/pkg/slack"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// kinds of the jobs handed off to the queue
+const (
+	jobKindEvent        = "event"
+	jobKindSlashCommand = "slash_command"
+	jobKindInteraction  = "interaction"
+)
+
+const (
+	// DefaultJobTimeout bounds a job, so that the retries of the Web API give up in time
+	DefaultJobTimeout = 2 * time.Minute
+	// jobDeadlineMargin ends the job before the deadline of the invocation (e.g. the timeout of Lambda)
+	jobDeadlineMargin = 5 * time.Second
+)
+
+// dispatcher verifies the requests from Slack and routes them to the handlers through the queue.
+// It is shared by all the listeners.
+type dispatcher struct {
+	eventHandlerFunc        pkgslack.EventHandlerFunc
+	slashCommandHandlerFunc pkgslack.SlashCommandHandlerFunc
+	interactionHandlerFunc  pkgslack.InteractionHandlerFunc
+	signingSecretKey        string
+	queue                   queue.Queue
+	dedupeStore             dedupe.Store
+	jobTimeout              time.Duration
+}
+
+// Option configures the listener
+type Option func(*dispatcher)
+
+// QueueOption sets the queue to process the requests in the background.
+// The requests are processed synchronously by default.
+func QueueOption(q queue.Queue) Option {
+	return func(d *dispatcher) {
+		d.queue = q
+	}
+}
+
+// JobTimeoutOption sets how long a job may run. DefaultJobTimeout is used by default.
+// On AWS Lambda the job ends before the timeout of the function even if it is longer.
+func JobTimeoutOption(timeout time.Duration) Option {
+	return func(d *dispatcher) {
+		d.jobTimeout = timeout
+	}
+}
+
+// DedupeOption sets the store of the handled events, which drops the events retried or replayed by Slack.
+// The events are remembered in memory by default.
+func DedupeOption(store dedupe.Store) Option {
+	return func(d *dispatcher) {
+		d.dedupeStore = store
+	}
+}
+
+func newDispatcher(eventHandlerFunc pkgslack.EventHandlerFunc, slashCommandHandlerFunc pkgslack.SlashCommandHandlerFunc, interactionHandlerFunc pkgslack.InteractionHandlerFunc, signingSecretKey string, opts []Option) *dispatcher {
+	d := &dispatcher{
+		eventHandlerFunc:        eventHandlerFunc,
+		slashCommandHandlerFunc: slashCommandHandlerFunc,
+		interactionHandlerFunc:  interactionHandlerFunc,
+		signingSecretKey:        signingSecretKey,
+		queue:                   queue.NewInline(),
+		dedupeStore:             dedupe.NewLRUStore(dedupe.DefaultSize, dedupe.DefaultTTL),
+		jobTimeout:              DefaultJobTimeout,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.queue.Start(d.run)
+	return d
+}
+
+// response is returned to Slack
+type response struct {
+	statusCode  int
+	contentType string
+	body        string
+}
+
+var responseOK = &response{statusCode: http.StatusOK}
+
+// dispatch handles the request. The response is returned even if err is not nil.
+func (d *dispatcher) dispatch(ctx context.Context, header http.Header, body string) (*response, error) {
+	if err := verify(header, body, d.signingSecretKey); err != nil {
+		return &response{statusCode: http.StatusBadRequest}, errors.Wrap(err, "verification failed")
+	}
+
+	form, _ := url.ParseQuery(body)
+	if payload := form.Get("payload"); payload != "" {
+		if !json.Valid([]byte(payload)) {
+			return &response{statusCode: http.StatusBadRequest}, errors.New("parse failed: invalid payload")
+		}
+		// an empty body closes the modal on view_submission
+		return d.enqueue(ctx, &queue.Job{Kind: jobKindInteraction, Body: payload})
+	}
+	if form.Get("command") != "" {
+		command, err := parseSlashCommand(body)
+		if err != nil {
+			return &response{statusCode: http.StatusBadRequest}, errors.Wrap(err, "parse failed")
+		}
+		b, err := json.Marshal(command)
+		if err != nil {
+			return &response{statusCode: http.StatusInternalServerError}, errors.Wrap(err, "marshal failed")
+		}
+		// an empty body leaves the response to the handler
+		return d.enqueue(ctx, &queue.Job{Kind: jobKindSlashCommand, Body: string(b)})
+	}
+
+	event, err := slackevents.ParseEvent(json.RawMessage(body), slackevents.OptionNoVerifyToken())
+	if err != nil {
+		return &response{statusCode: http.StatusInternalServerError}, errors.Wrap(err, "parse failed")
+	}
+
+	switch data := event.Data.(type) {
+	case *slackevents.EventsAPIURLVerificationEvent:
+		return &response{statusCode: http.StatusOK, contentType: "text/plain", body: data.Challenge}, nil
+	case *slackevents.EventsAPICallbackEvent:
+		if retryNum := header.Get("X-Slack-Retry-Num"); retryNum != "" {
+			// the duplicates are dropped in run, after the original is handled
+			log.Printf("Retry %s of %s: %s", retryNum, data.EventID, header.Get("X-Slack-Retry-Reason"))
+		}
+		return d.enqueue(ctx, &queue.Job{Kind: jobKindEvent, Body: body})
+	}
+
+	return responseOK, nil
+}
+
+// enqueue hands off the job. Slack retries the request if the queue is full.
+func (d *dispatcher) enqueue(ctx context.Context, job *queue.Job) (*response, error) {
+	if err := d.queue.Enqueue(ctx, job); err != nil {
+		return &response{statusCode: http.StatusServiceUnavailable}, errors.Wrap(err, "enqueue failed")
+	}
+	return responseOK, nil
+}
+
+// run processes the job with the handlers
+func (d *dispatcher) run(ctx context.Context, job *queue.Job) {
+	ctx, cancel := d.jobContext(ctx)
+	defer cancel()
+	switch job.Kind {
+	case jobKindEvent:
+		event, err := slackevents.ParseEvent(json.RawMessage(job.Body), slackevents.OptionNoVerifyToken())
+		if err != nil {
+			log.Printf("Failed to parse the event: %v", err)
+			return
+		}
+		if event.Type != slackevents.CallbackEvent || d.isDuplicate(ctx, job.Body) {
+			return
+		}
+		d.eventHandlerFunc(ctx, event.InnerEvent)
+	case jobKindSlashCommand:
+		var command slack.SlashCommand
+		if err := json.Unmarshal([]byte(job.Body), &command); err != nil {
+			log.Printf("Failed to parse the slash command: %v", err)
+			return
+		}
+		d.slashCommandHandlerFunc(ctx, command)
+	case jobKindInteraction:
+		var callback slack.InteractionCallback
+		if err := json.Unmarshal([]byte(job.Body), &callback); err != nil {
+			log.Printf("Failed to parse the interaction: %v", err)
+			return
+		}
+		d.interactionHandlerFunc(ctx, callback)
+	default:
+		log.Printf("Skipped the job: %s", job.Kind)
+	}
+}
+
+// jobContext bounds the job by the timeout, and ends it jobDeadlineMargin before the deadline of ctx if it has one
+func (d *dispatcher) jobContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := d.jobTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline) - jobDeadlineMargin; remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// isDuplicate returns true if the event or the message is already handled.
+// Slack resends the event with the same event_id on retries and reconnections of Socket Mode,
+// and client_msg_id identifies the message even if it is delivered as another event.
+func (d *dispatcher) isDuplicate(ctx context.Context, body string) bool {
+	var ids struct {
+		EventID string `json:"event_id"`
+		Event   struct {
+			ClientMsgID string `json:"client_msg_id"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal([]byte(body), &ids); err != nil {
+		return false
+	}
+	for _, key := range []string{"event:" + ids.EventID, "message:" + ids.Event.ClientMsgID} {
+		if strings.HasSuffix(key, ":") {
+			continue
+		}
+		first, err := d.dedupeStore.Claim(ctx, key)
+		if err != nil {
+			// handle the event rather than dropping it
+			log.Printf("Failed to claim %s: %v", key, err)
+			continue
+		}
+		if !first {
+			log.Printf("Dropped duplicate %s", key)
+			return true
+		}
+	}
+	return false
+}
+
+// verify returns the result of slack signing secret verification.
+func verify(header http.Header, body, signingSecretKey string) error {
+	sv, err := slack.NewSecretsVerifier(header, signingSecretKey)
+	if err != nil {
+		return err
+	}
+	if _, err := sv.Write([]byte(body)); err != nil {
+		return err
+	}
+	return sv.Ensure()
+}
+
+func parseSlashCommand(body string) (slack.SlashCommand, error) {
+	r, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if err != nil {
+		return slack.SlashCommand{}, err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return slack.SlashCommandParse(r)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package listener
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	pkgslack "github.com/moneyforward/auriga/app/pkg/slack"
+)
+
+const (
+	// maxRequestBodySize is large enough for the payloads of Slack
+	maxRequestBodySize = 1 << 20
+
+	shutdownTimeout = 10 * time.Second
+)
+
+// httpListener receives the requests from Slack with net/http, for running outside AWS Lambda
+type httpListener struct {
+	addr       string
+	dispatcher *dispatcher
+}
+
+// NewHTTPListener builds a listener serving on addr (e.g. ":8080").
+// Every path is accepted, so the request URLs of the events, the slash command and the interactivity can be the same.
+func NewHTTPListener(addr string, eventHandlerFunc pkgslack.EventHandlerFunc, slashCommandHandlerFunc pkgslack.SlashCommandHandlerFunc, interactionHandlerFunc pkgslack.InteractionHandlerFunc, signingSecretKey string, opts ...Option) *httpListener {
+	return &httpListener{
+		addr:       addr,
+		dispatcher: newDispatcher(eventHandlerFunc, slashCommandHandlerFunc, interactionHandlerFunc, signingSecretKey, opts),
+	}
+}
+
+// Listen serves until ctx is done
+func (l *httpListener) Listen(ctx context.Context) {
+	server := &http.Server{
+		Addr:              l.addr,
+		Handler:           l,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Failed to shutdown: %v", err)
+		}
+	}()
+	log.Printf("listening on %s", l.addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("Failed to serve: %v", err)
+	}
+}
+
+func (l *httpListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// not r.Context(), since Slack may close the connection before the handlers finish
+	res, err := l.dispatcher.dispatch(context.Background(), r.Header, string(body))
+	if err != nil {
+		log.Println(err)
+	}
+
+	if res.contentType != "" {
+		w.Header().Set("Content-Type", res.contentType)
+	}
+	w.WriteHeader(res.statusCode)
+	if res.body != "" {
+		_, _ = io.WriteString(w, res.body)
+	}
+}
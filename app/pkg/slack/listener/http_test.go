@@ -0,0 +1,236 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package listener
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+
+	"github.com/moneyforward/auriga/app/pkg/queue"
+)
+
+const sampleSigningSecret = "sample_signing_secret"
+
+// signedRequest builds a request signed in the same way as Slack
+func signedRequest(t *testing.T, url, body, secret string) *http.Request {
+	t.Helper()
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte("v0:" + ts + ":" + body))
+	r, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("X-Slack-Request-Timestamp", ts)
+	r.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return r
+}
+
+func Test_httpListener_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		secret     string
+		wantStatus int
+		wantBody   string
+		wantCalled string
+	}{
+		{
+			name:       "OK: url_verification",
+			body:       `{"token":"sample","challenge":"sample_challenge","type":"url_verification"}`,
+			secret:     sampleSigningSecret,
+			wantStatus: http.StatusOK,
+			wantBody:   "sample_challenge",
+		},
+		{
+			name: "OK: app_mention",
+			body: `{"type":"event_callback","event_id":"Ev01","event":{"type":"app_mention","user":"U01","text":"<@U00> :sanka:",` +
+				`"ts":"1667283600.000200","thread_ts":"1667283600.000100","channel":"C01"}}`,
+			secret:     sampleSigningSecret,
+			wantStatus: http.StatusOK,
+			wantCalled: "event",
+		},
+		{
+			name:       "OK: slash command",
+			body:       url.Values{"command": {"/auriga"}, "text": {"help"}, "response_url": {"https://hooks.slack.com/commands/sample"}}.Encode(),
+			secret:     sampleSigningSecret,
+			wantStatus: http.StatusOK,
+			wantCalled: "command",
+		},
+		{
+			name:       "OK: interaction",
+			body:       url.Values{"payload": {`{"type":"message_action","callback_id":"collect_attendees","trigger_id":"sample"}`}}.Encode(),
+			secret:     sampleSigningSecret,
+			wantStatus: http.StatusOK,
+			wantCalled: "interaction",
+		},
+		{
+			name:       "NG: invalid signature",
+			body:       `{"token":"sample","challenge":"sample_challenge","type":"url_verification"}`,
+			secret:     "wrong_secret",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called string
+			l := NewHTTPListener(":0",
+				func(ctx context.Context, event slackevents.EventsAPIInnerEvent) {
+					if ev, ok := event.Data.(*slackevents.AppMentionEvent); !ok || ev.Channel != "C01" {
+						t.Errorf("unexpected event: %+v", event.Data)
+					}
+					called = "event"
+				},
+				func(ctx context.Context, command slack.SlashCommand) {
+					if command.Command != "/auriga" || command.Text != "help" {
+						t.Errorf("unexpected command: %+v", command)
+					}
+					called = "command"
+				},
+				func(ctx context.Context, callback slack.InteractionCallback) {
+					if callback.Type != slack.InteractionTypeMessageAction || callback.TriggerID != "sample" {
+						t.Errorf("unexpected callback: %+v", callback)
+					}
+					called = "interaction"
+				},
+				sampleSigningSecret,
+			)
+			server := httptest.NewServer(l)
+			defer server.Close()
+
+			res, err := server.Client().Do(signedRequest(t, server.URL+"/slack/events", tt.body, tt.secret))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			body, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("status = %v, want %v", res.StatusCode, tt.wantStatus)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("called = %q, want %q", called, tt.wantCalled)
+			}
+		})
+	}
+}
+
+// fullQueue rejects every job
+type fullQueue struct{}
+
+func (q *fullQueue) Start(handler queue.Handler) {}
+
+func (q *fullQueue) Enqueue(ctx context.Context, job *queue.Job) error {
+	return queue.ErrFull
+}
+
+func (q *fullQueue) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func Test_httpListener_ServeHTTP_queue(t *testing.T) {
+	body := url.Values{"command": {"/auriga"}, "text": {"help"}}.Encode()
+	tests := []struct {
+		name       string
+		queue      func() queue.Queue
+		wantStatus int
+		wantCalled bool
+	}{
+		{
+			name:       "OK: processed in the background",
+			queue:      func() queue.Queue { return queue.NewWorkerPool(1, 1) },
+			wantStatus: http.StatusOK,
+			wantCalled: true,
+		},
+		{
+			name:       "NG: queue is full",
+			queue:      func() queue.Queue { return &fullQueue{} },
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.queue()
+			called := make(chan struct{}, 1)
+			release := make(chan struct{})
+			l := NewHTTPListener(":0", nil,
+				func(ctx context.Context, command slack.SlashCommand) {
+					// the response must not wait for the handler
+					<-release
+					called <- struct{}{}
+				},
+				nil, sampleSigningSecret, QueueOption(q),
+			)
+			w := httptest.NewRecorder()
+			l.ServeHTTP(w, signedRequest(t, "/", body, sampleSigningSecret))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			close(release)
+			if err := q.Shutdown(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got := len(called) > 0; got != tt.wantCalled {
+				t.Errorf("called = %v, want %v", got, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func Test_httpListener_ServeHTTP_retry(t *testing.T) {
+	body := `{"type":"event_callback","event_id":"Ev01","event":{"type":"app_mention","user":"U01","text":"<@U00> :sanka:",` +
+		`"client_msg_id":"sample-msg","ts":"1667283600.000200","channel":"C01"}}`
+	// the same message delivered as another event
+	replayed := strings.Replace(body, "Ev01", "Ev02", 1)
+	var called int
+	l := NewHTTPListener(":0",
+		func(ctx context.Context, event slackevents.EventsAPIInnerEvent) {
+			called++
+		},
+		nil, nil, sampleSigningSecret,
+	)
+	for i, b := range []string{body, body, replayed} {
+		r := signedRequest(t, "/", b, sampleSigningSecret)
+		if i > 0 {
+			r.Header.Set("X-Slack-Retry-Num", strconv.Itoa(i))
+			r.Header.Set("X-Slack-Retry-Reason", "http_timeout")
+		}
+		w := httptest.NewRecorder()
+		l.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %v, want %v", w.Code, http.StatusOK)
+		}
+	}
+	if called != 1 {
+		t.Errorf("called = %d, want 1", called)
+	}
+}
@@ -22,75 +22,66 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/moneyforward/auriga/app/pkg/queue"
 	pkgslack "github.com/moneyforward/auriga/app/pkg/slack"
 
-	"github.com/slack-go/slack"
-	"github.com/slack-go/slack/slackevents"
-
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 )
 
 type lambdaListener struct {
-	eventHandlerFunc pkgslack.EventHandlerFunc
-	signingSecretKey string
+	dispatcher *dispatcher
 }
 
-type handleEventRequest func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+type handleEventRequest func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// handleInvocation is called with the context of the invocation, whose deadline is the timeout of the function
+type handleInvocation func(ctx context.Context, payload json.RawMessage) (events.APIGatewayProxyResponse, error)
 
-func NewLambdaListener(eventHandlerFunc pkgslack.EventHandlerFunc, signingSecretKey string) *lambdaListener {
+func NewLambdaListener(eventHandlerFunc pkgslack.EventHandlerFunc, slashCommandHandlerFunc pkgslack.SlashCommandHandlerFunc, interactionHandlerFunc pkgslack.InteractionHandlerFunc, signingSecretKey string, opts ...Option) *lambdaListener {
 	l := &lambdaListener{
-		eventHandlerFunc: eventHandlerFunc,
-		signingSecretKey: signingSecretKey,
+		dispatcher: newDispatcher(eventHandlerFunc, slashCommandHandlerFunc, interactionHandlerFunc, signingSecretKey, opts),
 	}
 
 	return l
 }
 
 func (l *lambdaListener) Listen(ctx context.Context) {
-	lambda.Start(l.newHandleEventRequest(ctx))
+	lambda.Start(l.newHandleInvocation())
 }
 
-func (l *lambdaListener) newHandleEventRequest(ctx context.Context) handleEventRequest {
-	return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-		if res, err := l.verify(request, l.signingSecretKey); err != nil {
-			fmt.Println("verification failed")
-			return res, err
+// newHandleInvocation handles both the requests from API Gateway and the jobs enqueued by queue.NewLambdaQueue
+func (l *lambdaListener) newHandleInvocation() handleInvocation {
+	handleEventRequest := l.newHandleEventRequest()
+	return func(ctx context.Context, payload json.RawMessage) (events.APIGatewayProxyResponse, error) {
+		if job, ok := queue.ParseEnvelope(payload); ok {
+			l.dispatcher.run(ctx, job)
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
 		}
-
-		event, err := slackevents.ParseEvent(json.RawMessage(request.Body), slackevents.OptionNoVerifyToken())
-		if err != nil {
-			fmt.Println("parse failed")
-			return events.APIGatewayProxyResponse{StatusCode: 500}, err
+		var request events.APIGatewayProxyRequest
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest}, err
 		}
-
-		switch event.Data.(type) {
-		case *slackevents.EventsAPICallbackEvent:
-			l.eventHandlerFunc(ctx, event.InnerEvent)
-		}
-
-		return events.APIGatewayProxyResponse{Body: request.Body, StatusCode: 200}, nil
+		return handleEventRequest(ctx, request)
 	}
 }
 
-// verify returns the result of slack signing secret verification.
-func (l *lambdaListener) verify(request events.APIGatewayProxyRequest, sc string) (events.APIGatewayProxyResponse, error) {
-	body := request.Body
-	header := http.Header{}
-	for k, v := range request.Headers {
-		header.Set(k, v)
-	}
+func (l *lambdaListener) newHandleEventRequest() handleEventRequest {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		header := http.Header{}
+		for k, v := range request.Headers {
+			header.Set(k, v)
+		}
 
-	sv, err := slack.NewSecretsVerifier(header, sc)
-	if err != nil {
-		return events.APIGatewayProxyResponse{StatusCode: 500}, err
-	}
+		res, err := l.dispatcher.dispatch(ctx, header, request.Body)
+		if err != nil {
+			fmt.Println(err)
+		}
 
-	if _, err := sv.Write([]byte(body)); err != nil {
-		return events.APIGatewayProxyResponse{StatusCode: 500}, err
-	}
-	if err := sv.Ensure(); err != nil {
-		return events.APIGatewayProxyResponse{StatusCode: 400}, err
+		var resHeader map[string]string
+		if res.contentType != "" {
+			resHeader = map[string]string{"Content-Type": res.contentType}
+		}
+		return events.APIGatewayProxyResponse{StatusCode: res.statusCode, Headers: resHeader, Body: res.body}, err
 	}
-	return events.APIGatewayProxyResponse{StatusCode: 200}, nil
 }
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/slack-go/slack"
+)
+
+func Test_lambdaListener_newHandleEventRequest(t *testing.T) {
+	body := `{"token":"sample","challenge":"sample_challenge","type":"url_verification"}`
+	tests := []struct {
+		name       string
+		secret     string
+		wantStatus int
+		wantBody   string
+		wantErr    bool
+	}{
+		{
+			name:       "OK: url_verification",
+			secret:     sampleSigningSecret,
+			wantStatus: http.StatusOK,
+			wantBody:   "sample_challenge",
+		},
+		{
+			name:       "NG: invalid signature",
+			secret:     "wrong_secret",
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := signedRequest(t, "/", body, tt.secret)
+			headers := map[string]string{}
+			for k := range r.Header {
+				headers[k] = r.Header.Get(k)
+			}
+			l := NewLambdaListener(nil, nil, nil, sampleSigningSecret)
+			got, err := l.newHandleEventRequest()(context.Background(), events.APIGatewayProxyRequest{Headers: headers, Body: body})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("handleEventRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.StatusCode != tt.wantStatus || got.Body != tt.wantBody {
+				t.Errorf("handleEventRequest() got = %+v, want status %v and body %q", got, tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_lambdaListener_newHandleInvocation(t *testing.T) {
+	var called string
+	l := NewLambdaListener(nil,
+		func(ctx context.Context, command slack.SlashCommand) {
+			called = command.Text
+		},
+		nil, sampleSigningSecret,
+	)
+	payload := `{"auriga_job":{"kind":"slash_command","body":"{\"command\":\"/auriga\",\"text\":\"help\"}"}}`
+	got, err := l.newHandleInvocation()(context.Background(), json.RawMessage(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.StatusCode != http.StatusOK {
+		t.Errorf("handleInvocation() got = %+v", got)
+	}
+	if called != "help" {
+		t.Errorf("called with %q, want %q", called, "help")
+	}
+}
+
+func Test_lambdaListener_newHandleInvocation_deadline(t *testing.T) {
+	tests := []struct {
+		name       string
+		jobTimeout time.Duration
+		timeout    time.Duration
+		want       time.Duration
+	}{
+		{
+			name:       "OK: the timeout of the job",
+			jobTimeout: 10 * time.Second,
+			timeout:    time.Minute,
+			want:       10 * time.Second,
+		},
+		{
+			name:       "OK: before the timeout of the function",
+			jobTimeout: DefaultJobTimeout,
+			timeout:    30 * time.Second,
+			want:       30*time.Second - jobDeadlineMargin,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got time.Duration
+			l := NewLambdaListener(nil,
+				func(ctx context.Context, command slack.SlashCommand) {
+					if deadline, ok := ctx.Deadline(); ok {
+						got = time.Until(deadline)
+					}
+				},
+				nil, sampleSigningSecret, JobTimeoutOption(tt.jobTimeout),
+			)
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			payload := `{"auriga_job":{"kind":"slash_command","body":"{\"command\":\"/auriga\"}"}}`
+			if _, err := l.newHandleInvocation()(ctx, json.RawMessage(payload)); err != nil {
+				t.Fatal(err)
+			}
+			if got > tt.want || got < tt.want-time.Second {
+				t.Errorf("job deadline in %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
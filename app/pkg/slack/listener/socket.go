@@ -18,24 +18,33 @@ package listener
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
 
-	"github.com/moneyforward/auriga/app/pkg/slack"
-
-	"github.com/slack-go/slack/slackevents"
+	"github.com/moneyforward/auriga/app/pkg/queue"
+	pkgslack "github.com/moneyforward/auriga/app/pkg/slack"
 
+	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/socketmode"
 )
 
+// busyMessage tells the user to try again when the queue has no room for the request
+const busyMessage = "Auriga is busy now :neko_namida: Please try again in a moment."
+
+// postWebhook posts to the response_url, which is replaced in the tests
+var postWebhook = slack.PostWebhookContext
+
 type socketListener struct {
-	socketClient     slack.SocketClient
-	eventHandlerFunc slack.EventHandlerFunc
+	socketClient pkgslack.SocketClient
+	dispatcher   *dispatcher
 }
 
-func NewSocketListener(socketClient slack.SocketClient, eventHandlerFunc slack.EventHandlerFunc) *socketListener {
+func NewSocketListener(socketClient pkgslack.SocketClient, eventHandlerFunc pkgslack.EventHandlerFunc, slashCommandHandlerFunc pkgslack.SlashCommandHandlerFunc, interactionHandlerFunc pkgslack.InteractionHandlerFunc, opts ...Option) *socketListener {
 	return &socketListener{
-		socketClient:     socketClient,
-		eventHandlerFunc: eventHandlerFunc,
+		socketClient: socketClient,
+		// the requests via Socket Mode are not signed
+		dispatcher: newDispatcher(eventHandlerFunc, slashCommandHandlerFunc, interactionHandlerFunc, "", opts),
 	}
 }
 
@@ -46,17 +55,47 @@ func (l *socketListener) Listen(ctx context.Context) {
 
 func (l *socketListener) listen(ctx context.Context) {
 	for ev := range l.socketClient.Events() {
+		var kind string
 		switch ev.Type {
 		case socketmode.EventTypeEventsAPI:
-			l.socketClient.Ack(*ev.Request)
-			payload := ev.Data.(slackevents.EventsAPIEvent)
-			switch payload.Type {
-			case slackevents.CallbackEvent:
-				l.eventHandlerFunc(ctx, payload.InnerEvent)
-			}
+			kind = jobKindEvent
+		case socketmode.EventTypeSlashCommand:
+			kind = jobKindSlashCommand
+		case socketmode.EventTypeInteractive:
+			kind = jobKindInteraction
 		default:
 			l.socketClient.Debugf("Skipped: %v", ev.Type)
+			continue
+		}
+		job := &queue.Job{Kind: kind, Body: string(ev.Request.Payload)}
+		if err := l.dispatcher.queue.Enqueue(ctx, job); err != nil {
+			log.Printf("Dropped %s: %v", kind, err)
+			l.reject(ctx, ev.Request, job)
+			continue
+		}
+		// acknowledged only when the job is handed off, so that Slack sends the events again otherwise
+		l.socketClient.Ack(*ev.Request)
+	}
+}
+
+// reject tells the user that the request is dropped.
+// The events are not acknowledged so that Slack sends them again,
+// and Slack shows the failure of the interactions which have no response_url such as the submission of the modal.
+func (l *socketListener) reject(ctx context.Context, req *socketmode.Request, job *queue.Job) {
+	switch job.Kind {
+	case jobKindSlashCommand:
+		// the payload of the acknowledgement is the response shown only to the user
+		l.socketClient.Ack(*req, map[string]interface{}{"text": busyMessage})
+	case jobKindInteraction:
+		var callback slack.InteractionCallback
+		if err := json.Unmarshal([]byte(job.Body), &callback); err != nil || callback.ResponseURL == "" {
+			return
+		}
+		if err := postWebhook(ctx, callback.ResponseURL, &slack.WebhookMessage{Text: busyMessage, ResponseType: slack.ResponseTypeEphemeral}); err != nil {
+			log.Printf("Failed to reply to the dropped %s: %v", job.Kind, err)
+			return
 		}
+		l.socketClient.Ack(*req)
 	}
 }
 
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+
+	"github.com/moneyforward/auriga/app/pkg/queue"
+)
+
+// fakeSocketClient delivers the events and records the acknowledgements
+type fakeSocketClient struct {
+	events chan socketmode.Event
+	acks   map[string][]interface{}
+}
+
+func (c *fakeSocketClient) Events() chan socketmode.Event {
+	return c.events
+}
+
+func (c *fakeSocketClient) Debugf(format string, v ...interface{}) {}
+
+func (c *fakeSocketClient) Run() error {
+	return nil
+}
+
+func (c *fakeSocketClient) Ack(req socketmode.Request, payload ...interface{}) {
+	c.acks[req.EnvelopeID] = payload
+}
+
+func Test_socketListener_listen(t *testing.T) {
+	tests := []struct {
+		name        string
+		queue       queue.Queue
+		eventType   socketmode.EventType
+		payload     string
+		wantAcked   bool
+		wantPayload []interface{}
+		wantPosted  string
+	}{
+		{
+			name:      "OK: acknowledged after handed off",
+			queue:     queue.NewInline(),
+			eventType: socketmode.EventTypeSlashCommand,
+			payload:   `{"command":"/auriga"}`,
+			wantAcked: true,
+		},
+		{
+			name:        "NG: the slash command is answered with the busy message",
+			queue:       &fullQueue{},
+			eventType:   socketmode.EventTypeSlashCommand,
+			payload:     `{"command":"/auriga"}`,
+			wantAcked:   true,
+			wantPayload: []interface{}{map[string]interface{}{"text": busyMessage}},
+		},
+		{
+			name:       "NG: the busy message is posted to the response_url of the interaction",
+			queue:      &fullQueue{},
+			eventType:  socketmode.EventTypeInteractive,
+			payload:    `{"type":"block_actions","response_url":"https://hooks.slack.com/actions/sample"}`,
+			wantAcked:  true,
+			wantPosted: "https://hooks.slack.com/actions/sample",
+		},
+		{
+			name:      "NG: the submission of the modal is not acknowledged",
+			queue:     &fullQueue{},
+			eventType: socketmode.EventTypeInteractive,
+			payload:   `{"type":"view_submission"}`,
+		},
+		{
+			name:      "NG: the event is not acknowledged so that Slack sends it again",
+			queue:     &fullQueue{},
+			eventType: socketmode.EventTypeEventsAPI,
+			payload:   `{"type":"event_callback","event_id":"Ev01"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted string
+			postWebhook = func(ctx context.Context, url string, msg *slack.WebhookMessage) error {
+				posted = url
+				return nil
+			}
+			defer func() { postWebhook = slack.PostWebhookContext }()
+			client := &fakeSocketClient{events: make(chan socketmode.Event, 1), acks: map[string][]interface{}{}}
+			l := NewSocketListener(client,
+				func(ctx context.Context, event slackevents.EventsAPIInnerEvent) {},
+				func(ctx context.Context, command slack.SlashCommand) {},
+				func(ctx context.Context, callback slack.InteractionCallback) {},
+				QueueOption(tt.queue),
+			)
+			client.events <- socketmode.Event{
+				Type:    tt.eventType,
+				Request: &socketmode.Request{EnvelopeID: "envelope01", Payload: json.RawMessage(tt.payload)},
+			}
+			close(client.events)
+			l.listen(context.Background())
+			payload, acked := client.acks["envelope01"]
+			if acked != tt.wantAcked {
+				t.Errorf("acked = %v, want %v", acked, tt.wantAcked)
+			}
+			if len(payload) > 0 || len(tt.wantPayload) > 0 {
+				if !reflect.DeepEqual(payload, tt.wantPayload) {
+					t.Errorf("payload = %v, want %v", payload, tt.wantPayload)
+				}
+			}
+			if posted != tt.wantPosted {
+				t.Errorf("posted = %q, want %q", posted, tt.wantPosted)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import "regexp"
+
+var (
+	// regUserGroupMention matches the mention of a user group like "<!subteam^S0123456789|@design-team>"
+	regUserGroupMention = regexp.MustCompile(`^<!subteam\^([A-Z0-9]+)(?:\|([^>]*))?>$`)
+	// regChannelMention matches the mention of a channel like "<#C0123456789|project-x>"
+	regChannelMention = regexp.MustCompile(`^<#([A-Z0-9]+)(?:\|([^>]*))?>$`)
+)
+
+// ParseUserGroupMention extracts the ID and the handle of the user group from its mention escaped by Slack.
+// The handle is empty if the mention does not have it.
+func ParseUserGroupMention(word string) (userGroupID, handle string, ok bool) {
+	m := regUserGroupMention.FindStringSubmatch(word)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// ParseChannelMention extracts the ID and the name of the channel from its mention escaped by Slack.
+// The name is empty if the mention does not have it.
+func ParseChannelMention(word string) (channelID, name string, ok bool) {
+	m := regChannelMention.FindStringSubmatch(word)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import "testing"
+
+func TestParseUserGroupMention(t *testing.T) {
+	tests := []struct {
+		name            string
+		word            string
+		wantUserGroupID string
+		wantHandle      string
+		wantOK          bool
+	}{
+		{
+			name:            "OK",
+			word:            "<!subteam^S0123456789|@design-team>",
+			wantUserGroupID: "S0123456789",
+			wantHandle:      "@design-team",
+			wantOK:          true,
+		},
+		{
+			name:            "OK: without the handle",
+			word:            "<!subteam^S0123456789>",
+			wantUserGroupID: "S0123456789",
+			wantOK:          true,
+		},
+		{
+			name: "NG: channel",
+			word: "<#C0123456789|project-x>",
+		},
+		{
+			name: "NG: not escaped",
+			word: "@design-team",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUserGroupID, gotHandle, gotOK := ParseUserGroupMention(tt.word)
+			if gotUserGroupID != tt.wantUserGroupID || gotHandle != tt.wantHandle || gotOK != tt.wantOK {
+				t.Errorf("ParseUserGroupMention() = %v, %v, %v, want %v, %v, %v",
+					gotUserGroupID, gotHandle, gotOK, tt.wantUserGroupID, tt.wantHandle, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseChannelMention(t *testing.T) {
+	tests := []struct {
+		name          string
+		word          string
+		wantChannelID string
+		wantName      string
+		wantOK        bool
+	}{
+		{
+			name:          "OK",
+			word:          "<#C0123456789|project-x>",
+			wantChannelID: "C0123456789",
+			wantName:      "project-x",
+			wantOK:        true,
+		},
+		{
+			name:          "OK: without the name",
+			word:          "<#G0123456789|>",
+			wantChannelID: "G0123456789",
+			wantOK:        true,
+		},
+		{
+			name: "NG: user group",
+			word: "<!subteam^S0123456789|@design-team>",
+		},
+		{
+			name: "NG: not escaped",
+			word: "#project-x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotChannelID, gotName, gotOK := ParseChannelMention(tt.word)
+			if gotChannelID != tt.wantChannelID || gotName != tt.wantName || gotOK != tt.wantOK {
+				t.Errorf("ParseChannelMention() = %v, %v, %v, want %v, %v, %v",
+					gotChannelID, gotName, gotOK, tt.wantChannelID, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
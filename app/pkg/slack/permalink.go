@@ -0,0 +1,55 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+// ErrInvalidPermalink is returned when the text is not a permalink of a Slack message
+var ErrInvalidPermalink = errors.New("invalid_permalink")
+
+// regPermalink matches a message permalink like "https://example.slack.com/archives/C0123456789/p1667283600123456"
+var regPermalink = regexp.MustCompile(`^https://[^/]+/archives/([A-Z0-9]+)/p(\d{10})(\d{6})(?:\?(.*))?$`)
+
+// ParsePermalink extracts the channel ID and the timestamp of the message from its permalink.
+// The link may be enclosed in angle brackets and its "&" escaped as "&amp;" as Slack escapes it
+// (e.g. "<https://...>" or "<https://...|text>").
+// The permalink of a reply ("...?thread_ts=...") gives the timestamp of the parent message of the thread,
+// since Auriga collects the reactions on the message which starts the thread as the mention in the thread does.
+func ParsePermalink(permalink string) (channelID, ts string, err error) {
+	link := strings.TrimSpace(permalink)
+	if strings.HasPrefix(link, "<") && strings.HasSuffix(link, ">") {
+		link = link[1 : len(link)-1]
+		if i := strings.Index(link, "|"); i >= 0 {
+			link = link[:i]
+		}
+	}
+	m := regPermalink.FindStringSubmatch(html.UnescapeString(link))
+	if m == nil {
+		return "", "", ErrInvalidPermalink
+	}
+	if query, err := url.ParseQuery(m[4]); err == nil && query.Get("thread_ts") != "" {
+		return m[1], query.Get("thread_ts"), nil
+	}
+	return m[1], m[2] + "." + m[3], nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import (
+	"testing"
+)
+
+func TestParsePermalink(t *testing.T) {
+	tests := []struct {
+		name          string
+		permalink     string
+		wantChannelID string
+		wantTs        string
+		wantErr       bool
+	}{
+		{
+			name:          "OK",
+			permalink:     "https://example.slack.com/archives/C0123456789/p1667283600123456",
+			wantChannelID: "C0123456789",
+			wantTs:        "1667283600.123456",
+		},
+		{
+			name:          "OK: reply in a thread is the parent message",
+			permalink:     "https://example.slack.com/archives/C0123456789/p1667283600123456?thread_ts=1667283000.000100&cid=C0123456789",
+			wantChannelID: "C0123456789",
+			wantTs:        "1667283000.000100",
+		},
+		{
+			name:          "OK: escaped by Slack",
+			permalink:     "<https://example.slack.com/archives/C0123456789/p1667283600123456|link>",
+			wantChannelID: "C0123456789",
+			wantTs:        "1667283600.123456",
+		},
+		{
+			name:          "OK: reply escaped by Slack is the parent message",
+			permalink:     "<https://example.slack.com/archives/C0123456789/p1667283600123456?thread_ts=1667283000.000100&amp;cid=C0123456789>",
+			wantChannelID: "C0123456789",
+			wantTs:        "1667283000.000100",
+		},
+		{
+			name:          "OK: reply pasted in the text escaped by Slack",
+			permalink:     "https://example.slack.com/archives/C0123456789/p1667283600123456?thread_ts=1667283000.000100&amp;cid=C0123456789",
+			wantChannelID: "C0123456789",
+			wantTs:        "1667283000.000100",
+		},
+		{
+			name:      "NG: not a message",
+			permalink: "https://example.slack.com/archives/C0123456789",
+			wantErr:   true,
+		},
+		{
+			name:      "NG: not a link",
+			permalink: ":sanka:",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotChannelID, gotTs, err := ParsePermalink(tt.permalink)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePermalink() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotChannelID != tt.wantChannelID || gotTs != tt.wantTs {
+				t.Errorf("ParsePermalink() got = %v, %v, want %v, %v", gotChannelID, gotTs, tt.wantChannelID, tt.wantTs)
+			}
+		})
+	}
+}
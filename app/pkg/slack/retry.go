@@ -0,0 +1,223 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// defaultMaxAttempts is the number of the calls including the first one
+	defaultMaxAttempts = 5
+	defaultBaseDelay   = 500 * time.Millisecond
+	defaultMaxDelay    = 30 * time.Second
+)
+
+// tier is the rate limit tier of the Web API methods in calls per minute.
+// See https://api.slack.com/docs/rate-limits
+type tier int
+
+const (
+	tier2 tier = 20
+	tier3 tier = 50
+	tier4 tier = 100
+	// tierPostMessage is the limit of chat.postMessage, which allows about a message per second per channel
+	tierPostMessage tier = 60
+)
+
+var methodTiers = map[string]tier{
+	"chat.postMessage":      tierPostMessage,
+	"chat.postEphemeral":    tier4,
+	"chat.getPermalink":     tier4,
+	"conversations.members": tier4,
+	"conversations.replies": tier3,
+	"files.upload":          tier2,
+	"reactions.get":         tier3,
+	"usergroups.users.list": tier2,
+	"users.info":            tier4,
+	"views.open":            tier4,
+	"views.update":          tier4,
+}
+
+// readMethods are the methods which only read, so that they are safe to call again after 5xx.
+// The others such as chat.postMessage may have succeeded before failing with 5xx,
+// so they are retried only when rate limited, which Slack does not process.
+var readMethods = map[string]bool{
+	"chat.getPermalink":     true,
+	"conversations.members": true,
+	"conversations.replies": true,
+	"reactions.get":         true,
+	"usergroups.users.list": true,
+	"users.info":            true,
+}
+
+// retrier calls the Web API within the budgets of the tiers,
+// and retries the calls rate limited, or the reads failed with 5xx, until maxAttempts or the deadline of the context.
+type retrier struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	now         func() time.Time
+	sleep       func(ctx context.Context, d time.Duration) error
+	// jitter randomizes the backoff to spread the retries of the concurrent calls
+	jitter func(d time.Duration) time.Duration
+
+	mu      sync.Mutex
+	budgets map[string]*budget
+}
+
+func newRetrier() *retrier {
+	return &retrier{
+		maxAttempts: defaultMaxAttempts,
+		baseDelay:   defaultBaseDelay,
+		maxDelay:    defaultMaxDelay,
+		now:         time.Now,
+		sleep:       sleepContext,
+		jitter: func(d time.Duration) time.Duration {
+			// between d/2 and d
+			return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+		},
+		budgets: map[string]*budget{},
+	}
+}
+
+// do calls the method. method is the name of the Web API method (e.g. users.info) to spend the budget of,
+// or "" for the calls without tiers such as response_url.
+func (r *retrier) do(ctx context.Context, method string, call func() error) error {
+	for attempt := 1; ; attempt++ {
+		if wait := r.reserve(method); wait > 0 {
+			if err := r.sleep(ctx, wait); err != nil {
+				return errors.Wrapf(err, "waited for the rate limit of %s", method)
+			}
+		}
+		err := call()
+		if err == nil || attempt >= r.maxAttempts {
+			return err
+		}
+		delay, ok := r.retryDelay(method, err, attempt)
+		if !ok {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && r.now().Add(delay).After(deadline) {
+			// the retry would be too late
+			return err
+		}
+		if sleepErr := r.sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying the call failed with err, and false if it should not be retried
+func (r *retrier) retryDelay(method string, err error, attempt int) (time.Duration, bool) {
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		r.pause(method, rateLimited.RetryAfter)
+		return rateLimited.RetryAfter, true
+	}
+	var statusCode interface{ HTTPStatusCode() int }
+	if readMethods[method] && errors.As(err, &statusCode) && statusCode.HTTPStatusCode() >= 500 {
+		delay := r.baseDelay << (attempt - 1)
+		if delay > r.maxDelay || delay <= 0 {
+			delay = r.maxDelay
+		}
+		return r.jitter(delay), true
+	}
+	return 0, false
+}
+
+// reserve spends the budget of the method and returns how long to wait for it
+func (r *retrier) reserve(method string) time.Duration {
+	t, ok := methodTiers[method]
+	if !ok {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.budgets[method]
+	if !ok {
+		b = newBudget(t)
+		r.budgets[method] = b
+	}
+	return b.reserve(r.now())
+}
+
+// pause stops the calls of the method for d, as Slack told with Retry-After
+func (r *retrier) pause(method string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.budgets[method]; ok {
+		b.pause(r.now().Add(d))
+	}
+}
+
+// budget allows the calls of a tier in any minute, with the generic cell rate algorithm
+type budget struct {
+	interval time.Duration
+	// tolerance allows the burst of the calls of a minute
+	tolerance time.Duration
+	// tat is the theoretical arrival time of the next call
+	tat         time.Time
+	pausedUntil time.Time
+}
+
+func newBudget(t tier) *budget {
+	interval := time.Minute / time.Duration(t)
+	return &budget{
+		interval:  interval,
+		tolerance: time.Minute - interval,
+	}
+}
+
+func (b *budget) reserve(now time.Time) time.Duration {
+	start := now
+	if start.Before(b.pausedUntil) {
+		start = b.pausedUntil
+	}
+	if b.tat.Before(start) {
+		b.tat = start
+	}
+	wait := b.tat.Sub(now) - b.tolerance
+	if wait < start.Sub(now) {
+		wait = start.Sub(now)
+	}
+	b.tat = b.tat.Add(b.interval)
+	return wait
+}
+
+func (b *budget) pause(until time.Time) {
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// fakeSlackAPI answers the Web API methods with the responses in order, except auth.test
+type fakeSlackAPI struct {
+	mu        sync.Mutex
+	responses []fakeResponse
+	calls     int
+}
+
+type fakeResponse struct {
+	statusCode int
+	retryAfter string
+	body       string
+}
+
+func (f *fakeSlackAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/auth.test" {
+		fmt.Fprint(w, `{"ok":true,"user_id":"UAURIGA","team_id":"T0123456789"}`)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	res := f.responses[f.calls]
+	f.calls++
+	if res.retryAfter != "" {
+		w.Header().Set("Retry-After", res.retryAfter)
+	}
+	w.WriteHeader(res.statusCode)
+	fmt.Fprint(w, res.body)
+}
+
+const usersInfoOK = `{"ok":true,"users":[{"id":"U0123456789","profile":{"email":"test@example.com"}}]}`
+
+func TestClient_retry(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []fakeResponse
+		// call calls the API, which is users.info if nil
+		call       func(ctx context.Context, c *client) error
+		timeout    time.Duration
+		wantCalls  int
+		wantSleeps []time.Duration
+		wantErr    bool
+	}{
+		{
+			name: "OK",
+			responses: []fakeResponse{
+				{statusCode: http.StatusOK, body: usersInfoOK},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "OK: retried after Retry-After",
+			responses: []fakeResponse{
+				{statusCode: http.StatusTooManyRequests, retryAfter: "3"},
+				{statusCode: http.StatusOK, body: usersInfoOK},
+			},
+			wantCalls:  2,
+			wantSleeps: []time.Duration{3 * time.Second},
+		},
+		{
+			name: "OK: retried 5xx with backoff",
+			responses: []fakeResponse{
+				{statusCode: http.StatusServiceUnavailable},
+				{statusCode: http.StatusBadGateway},
+				{statusCode: http.StatusOK, body: usersInfoOK},
+			},
+			wantCalls:  3,
+			wantSleeps: []time.Duration{defaultBaseDelay, 2 * defaultBaseDelay},
+		},
+		{
+			name: "OK: write retried after Retry-After",
+			responses: []fakeResponse{
+				{statusCode: http.StatusTooManyRequests, retryAfter: "1"},
+				{statusCode: http.StatusOK, body: `{"ok":true}`},
+			},
+			call: func(ctx context.Context, c *client) error {
+				return c.PostMessage(ctx, "C0123456789", "hello", "")
+			},
+			wantCalls:  2,
+			wantSleeps: []time.Duration{time.Second},
+		},
+		{
+			name: "NG: write not retried on 5xx",
+			responses: []fakeResponse{
+				{statusCode: http.StatusServiceUnavailable},
+			},
+			call: func(ctx context.Context, c *client) error {
+				return c.PostMessage(ctx, "C0123456789", "hello", "")
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "NG: gave up after the max attempts",
+			responses: []fakeResponse{
+				{statusCode: http.StatusInternalServerError},
+				{statusCode: http.StatusInternalServerError},
+				{statusCode: http.StatusInternalServerError},
+				{statusCode: http.StatusInternalServerError},
+				{statusCode: http.StatusInternalServerError},
+			},
+			wantCalls:  defaultMaxAttempts,
+			wantSleeps: []time.Duration{defaultBaseDelay, 2 * defaultBaseDelay, 4 * defaultBaseDelay, 8 * defaultBaseDelay},
+			wantErr:    true,
+		},
+		{
+			name: "NG: Retry-After beyond the deadline",
+			responses: []fakeResponse{
+				{statusCode: http.StatusTooManyRequests, retryAfter: "60"},
+			},
+			timeout:   time.Second,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "NG: 5xx retries stop at the deadline of the job",
+			responses: []fakeResponse{
+				{statusCode: http.StatusInternalServerError},
+				{statusCode: http.StatusInternalServerError},
+				{statusCode: http.StatusInternalServerError},
+			},
+			timeout:    2 * time.Second,
+			wantCalls:  3,
+			wantSleeps: []time.Duration{defaultBaseDelay, 2 * defaultBaseDelay},
+			wantErr:    true,
+		},
+		{
+			name: "NG: not retried on the errors of the method",
+			responses: []fakeResponse{
+				{statusCode: http.StatusOK, body: `{"ok":false,"error":"user_not_found"}`},
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeSlackAPI{responses: tt.responses}
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			c, err := NewClient("xoxb-test", slack.OptionAPIURL(server.URL+"/"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// sleep advances the clock instead of sleeping
+			now := time.Now()
+			var sleeps []time.Duration
+			c.retrier.now = func() time.Time { return now }
+			c.retrier.sleep = func(ctx context.Context, d time.Duration) error {
+				sleeps = append(sleeps, d)
+				now = now.Add(d)
+				return nil
+			}
+			c.retrier.jitter = func(d time.Duration) time.Duration { return d }
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			call := tt.call
+			if call == nil {
+				call = func(ctx context.Context, c *client) error {
+					_, err := c.GetUsersInfo(ctx, "U0123456789")
+					return err
+				}
+			}
+			err = call(ctx, c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("call error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if api.calls != tt.wantCalls {
+				t.Errorf("calls = %v, want %v", api.calls, tt.wantCalls)
+			}
+			if !reflect.DeepEqual(sleeps, tt.wantSleeps) {
+				t.Errorf("sleeps = %v, want %v", sleeps, tt.wantSleeps)
+			}
+		})
+	}
+}
+
+func TestRetrier_reserve(t *testing.T) {
+	now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+	r := newRetrier()
+	r.now = func() time.Time { return now }
+
+	// chat.postMessage allows 60 calls in a minute
+	for i := 0; i < int(tierPostMessage); i++ {
+		if got := r.reserve("chat.postMessage"); got != 0 {
+			t.Fatalf("reserve() call %d = %v, want 0", i, got)
+		}
+	}
+	if got := r.reserve("chat.postMessage"); got != time.Second {
+		t.Errorf("reserve() over the budget = %v, want %v", got, time.Second)
+	}
+	if got := r.reserve("users.info"); got != 0 {
+		t.Errorf("reserve() of another method = %v, want 0", got)
+	}
+	if got := r.reserve(""); got != 0 {
+		t.Errorf("reserve() without tier = %v, want 0", got)
+	}
+
+	r.pause("users.info", 30*time.Second)
+	if got := r.reserve("users.info"); got != 30*time.Second {
+		t.Errorf("reserve() while paused = %v, want %v", got, 30*time.Second)
+	}
+	now = now.Add(time.Minute)
+	if got := r.reserve("users.info"); got != 0 {
+		t.Errorf("reserve() after the pause = %v, want 0", got)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/aws"
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+const (
+	// dynamoDBKey is the partition key of the table
+	dynamoDBKey = "id"
+	// dynamoDBValue is the attribute of the value in JSON
+	dynamoDBValue = "value"
+	// dynamoDBVersion is the attribute of the version of the value, which is 1 if missing
+	dynamoDBVersion = "version"
+	// dynamoDBExpiresAt is the attribute for the TTL of DynamoDB, in Unix time
+	dynamoDBExpiresAt = "expires_at"
+)
+
+// dynamoDBStore keeps the records in a DynamoDB table, so that they are shared by the Lambda instances.
+// The table has the same keys as the ones of pkg/cache. Enable the TTL of the table on expires_at to clean up the expired records.
+type dynamoDBStore struct {
+	client    aws.DynamoDBClient
+	tableName string
+	now       func() time.Time
+}
+
+func NewDynamoDBStore(client aws.DynamoDBClient, tableName string) *dynamoDBStore {
+	return &dynamoDBStore{
+		client:    client,
+		tableName: tableName,
+		now:       time.Now,
+	}
+}
+
+// Get ignores the expired records since the TTL of DynamoDB may delete them late
+func (s *dynamoDBStore) Get(ctx context.Context, key string) (*Record, error) {
+	item, err := s.client.GetItem(ctx, s.tableName, aws.Item{dynamoDBKey: {S: key}})
+	if err != nil || item == nil {
+		return nil, err
+	}
+	record := &Record{Key: key, Value: json.RawMessage(item[dynamoDBValue].S), Version: 1}
+	if v, ok := item[dynamoDBVersion]; ok {
+		if record.Version, err = strconv.Atoi(v.N); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := item[dynamoDBExpiresAt]; ok {
+		expiresAt, err := strconv.ParseInt(v.N, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		record.ExpiresAt = time.Unix(expiresAt, 0).UTC()
+	}
+	if record.Expired(s.now()) {
+		return nil, nil
+	}
+	return record, nil
+}
+
+func (s *dynamoDBStore) Put(ctx context.Context, record *Record) error {
+	return s.client.PutItem(ctx, &aws.PutItemInput{TableName: s.tableName, Item: s.item(record)})
+}
+
+// Claim overwrites the expired record since the TTL of DynamoDB may delete it late
+func (s *dynamoDBStore) Claim(ctx context.Context, record *Record) (bool, error) {
+	err := s.client.PutItem(ctx, &aws.PutItemInput{
+		TableName:                s.tableName,
+		Item:                     s.item(record),
+		ConditionExpression:      "attribute_not_exists(#id) OR #expires_at < :now OR #value = :value",
+		ExpressionAttributeNames: map[string]string{"#id": dynamoDBKey, "#expires_at": dynamoDBExpiresAt, "#value": dynamoDBValue},
+		ExpressionAttributeValues: aws.Item{
+			":now":   {N: strconv.FormatInt(s.now().Unix(), 10)},
+			":value": {S: string(record.Value)},
+		},
+	})
+	if errors.Is(err, aws.ErrConditionalCheckFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *dynamoDBStore) item(record *Record) aws.Item {
+	item := aws.Item{
+		dynamoDBKey:     {S: record.Key},
+		dynamoDBValue:   {S: string(record.Value)},
+		dynamoDBVersion: {N: strconv.Itoa(record.Version)},
+	}
+	if !record.ExpiresAt.IsZero() {
+		item[dynamoDBExpiresAt] = aws.AttributeValue{N: strconv.FormatInt(record.ExpiresAt.Unix(), 10)}
+	}
+	return item
+}
+
+func (s *dynamoDBStore) Delete(ctx context.Context, key string) error {
+	return s.client.DeleteItem(ctx, &aws.DeleteItemInput{TableName: s.tableName, Key: aws.Item{dynamoDBKey: {S: key}}})
+}
+
+func (s *dynamoDBStore) DeleteIf(ctx context.Context, key string, value json.RawMessage) (bool, error) {
+	err := s.client.DeleteItem(ctx, &aws.DeleteItemInput{
+		TableName:                 s.tableName,
+		Key:                       aws.Item{dynamoDBKey: {S: key}},
+		ConditionExpression:       "#value = :value",
+		ExpressionAttributeNames:  map[string]string{"#value": dynamoDBValue},
+		ExpressionAttributeValues: aws.Item{":value": {S: string(value)}},
+	})
+	if errors.Is(err, aws.ErrConditionalCheckFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/aws"
+)
+
+func Test_dynamoDBStore(t *testing.T) {
+	now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		table   string
+		record  *Record
+		after   time.Duration
+		delete  bool
+		want    *Record
+		wantErr bool
+	}{
+		{
+			name:   "OK: kept",
+			table:  "auriga-state",
+			record: &Record{Key: "event_binding:C01:1667283600.123456", Value: json.RawMessage(`{"EventID":"event01"}`), Version: 2},
+			after:  365 * 24 * time.Hour,
+			want:   &Record{Key: "event_binding:C01:1667283600.123456", Value: json.RawMessage(`{"EventID":"event01"}`), Version: 2},
+		},
+		{
+			name:   "OK: not expired yet",
+			table:  "auriga-state",
+			record: &Record{Key: "key", Value: json.RawMessage(`{}`), Version: 1, ExpiresAt: now.Add(time.Hour)},
+			after:  time.Minute,
+			want:   &Record{Key: "key", Value: json.RawMessage(`{}`), Version: 1, ExpiresAt: now.Add(time.Hour)},
+		},
+		{
+			name:   "OK: expired",
+			table:  "auriga-state",
+			record: &Record{Key: "key", Value: json.RawMessage(`{}`), Version: 1, ExpiresAt: now.Add(time.Hour)},
+			after:  time.Hour,
+		},
+		{
+			name:   "OK: deleted",
+			table:  "auriga-state",
+			record: &Record{Key: "key", Value: json.RawMessage(`{}`), Version: 1},
+			delete: true,
+		},
+		{
+			name:    "NG: table not found",
+			table:   "unknown",
+			record:  &Record{Key: "key", Value: json.RawMessage(`{}`), Version: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := aws.NewLocalDynamoDB()
+			db.CreateTable("auriga-state", "id")
+			s := NewDynamoDBStore(db, tt.table)
+			s.now = func() time.Time { return now.Add(tt.after) }
+
+			err := s.Put(ctx, tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Put() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.delete {
+				if err := s.Delete(ctx, tt.record.Key); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := s.Get(ctx, tt.record.Key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dynamoDBStore_Get_withoutVersion(t *testing.T) {
+	// the items written before the migrations have no version
+	ctx := context.Background()
+	db := aws.NewLocalDynamoDB()
+	db.CreateTable("auriga-state", "id")
+	if err := db.PutItem(ctx, &aws.PutItemInput{
+		TableName: "auriga-state",
+		Item:      aws.Item{"id": {S: "key"}, "value": {S: `{}`}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewDynamoDBStore(db, "auriga-state").Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&Record{Key: "key", Value: json.RawMessage(`{}`), Version: 1}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_dynamoDBStore_Claim(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+	db := aws.NewLocalDynamoDB()
+	db.CreateTable("auriga-state", "id")
+	s := NewDynamoDBStore(db, "auriga-state")
+	lock := func(owner string) *Record {
+		return &Record{Key: "lock", Value: json.RawMessage(`{"owner":"` + owner + `"}`), Version: 1, ExpiresAt: now.Add(time.Minute)}
+	}
+	tests := []struct {
+		name   string
+		record *Record
+		after  time.Duration
+		want   bool
+	}{
+		{
+			name:   "OK: claimed",
+			record: lock("a"),
+			want:   true,
+		},
+		{
+			name:   "OK: claimed by the other",
+			record: lock("b"),
+		},
+		{
+			name:   "OK: renewed by the holder",
+			record: lock("a"),
+			want:   true,
+		},
+		{
+			name:   "OK: claimed by the other after it expires",
+			record: lock("b"),
+			after:  time.Minute + time.Second,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.now = func() time.Time { return now.Add(tt.after) }
+			got, err := s.Claim(ctx, tt.record)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Claim() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dynamoDBStore_DeleteIf(t *testing.T) {
+	ctx := context.Background()
+	db := aws.NewLocalDynamoDB()
+	db.CreateTable("auriga-state", "id")
+	s := NewDynamoDBStore(db, "auriga-state")
+	if err := s.Put(ctx, &Record{Key: "lock", Value: json.RawMessage(`{"owner":"a"}`), Version: 1}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  bool
+	}{
+		{
+			name:  "OK: not removed for the other",
+			key:   "lock",
+			value: `{"owner":"b"}`,
+		},
+		{
+			name:  "OK: removed for the holder",
+			key:   "lock",
+			value: `{"owner":"a"}`,
+			want:  true,
+		},
+		{
+			name:  "OK: not found",
+			key:   "lock",
+			value: `{"owner":"a"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.DeleteIf(ctx, tt.key, json.RawMessage(tt.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("DeleteIf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+// ErrLocked is returned when the state file is used by another process
+var ErrLocked = errors.New("the state file is used by another process")
+
+// fileRecord is a record written in the file
+type fileRecord struct {
+	Value   json.RawMessage `json:"value"`
+	Version int             `json:"version"`
+	// ExpiresAt is in Unix time, or zero to keep the record
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
+// fileStore keeps the records in memory and writes them to a JSON file, to run Auriga on a single host without a database.
+// The whole file is rewritten on every change, so it suits a modest number of records.
+// It is for a single process only: the records are read only when it is built, so the process holds
+// the exclusive lock of path+".lock" until it exits, and another process fails to build the store of the same file.
+type fileStore struct {
+	*memoryStore
+	path string
+	lock *os.File
+}
+
+// NewFileStore builds a store of the file at path, reading the records in it if it exists.
+// It returns ErrLocked if another process uses the file.
+func NewFileStore(path string) (*fileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create the directory of the state file")
+	}
+	lock, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open the lock file of the state file")
+	}
+	if err := lockFile(lock); err != nil {
+		lock.Close()
+		return nil, err
+	}
+	s := &fileStore{
+		memoryStore: NewMemoryStore(),
+		path:        path,
+		lock:        lock,
+	}
+	if err := s.load(); err != nil {
+		lock.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close releases the lock of the file, after which the store must not be used
+func (s *fileStore) Close() error {
+	return s.lock.Close()
+}
+
+// load reads the records in the file if it exists
+func (s *fileStore) load() error {
+	b, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to read the state file")
+	}
+	var records map[string]*fileRecord
+	if err := json.Unmarshal(b, &records); err != nil {
+		return errors.Wrap(err, "failed to parse the state file")
+	}
+	for key, r := range records {
+		record := &Record{Key: key, Value: r.Value, Version: r.Version}
+		if r.ExpiresAt != 0 {
+			record.ExpiresAt = time.Unix(r.ExpiresAt, 0).UTC()
+		}
+		s.records[key] = record
+	}
+	return nil
+}
+
+func (s *fileStore) Put(ctx context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(record)
+	return s.save()
+}
+
+func (s *fileStore) Claim(ctx context.Context, record *Record) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.claimable(record) {
+		return false, nil
+	}
+	s.put(record)
+	return true, s.save()
+}
+
+func (s *fileStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[key]; !ok {
+		return nil
+	}
+	delete(s.records, key)
+	return s.save()
+}
+
+func (s *fileStore) DeleteIf(ctx context.Context, key string, value json.RawMessage) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.deleteIf(key, value) {
+		return false, nil
+	}
+	return true, s.save()
+}
+
+// save rewrites the file.
+// The records are written to a temporary file which is synced to the disk and then renamed over the file,
+// so that a crash leaves either the old file or the new one, never a half-written one.
+func (s *fileStore) save() error {
+	records := make(map[string]*fileRecord, len(s.records))
+	for key, r := range s.records {
+		records[key] = &fileRecord{Value: r.Value, Version: r.Version}
+		if !r.ExpiresAt.IsZero() {
+			records[key].ExpiresAt = r.ExpiresAt.Unix()
+		}
+	}
+	b, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := writeFileSync(tmp, b); err != nil {
+		return errors.Wrap(err, "failed to write the state file")
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return errors.Wrap(err, "failed to replace the state file")
+	}
+	// the rename is durable only after the directory is synced
+	if err := syncDir(filepath.Dir(s.path)); err != nil {
+		return errors.Wrap(err, "failed to sync the directory of the state file")
+	}
+	return nil
+}
+
+// writeFileSync writes the file and flushes it to the disk before returning
+func writeFileSync(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
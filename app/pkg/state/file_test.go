@@ -0,0 +1,197 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_fileStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "state", "auriga.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return now }
+	kept := &Record{Key: "kept", Value: json.RawMessage(`{"a":1}`), Version: 2}
+	expiring := &Record{Key: "expiring", Value: json.RawMessage(`{"b":2}`), Version: 1, ExpiresAt: now.Add(time.Hour)}
+	deleted := &Record{Key: "deleted", Value: json.RawMessage(`{}`), Version: 1}
+	for _, r := range []*Record{kept, expiring, deleted} {
+		if err := s.Put(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Delete(ctx, "deleted"); err != nil {
+		t.Fatal(err)
+	}
+	lock := &Record{Key: "lock", Value: json.RawMessage(`{"owner":"a"}`), Version: 1}
+	if ok, err := s.Claim(ctx, lock); !ok || err != nil {
+		t.Fatalf("Claim() = %v, %v", ok, err)
+	}
+	if ok, err := s.DeleteIf(ctx, "lock", json.RawMessage(`{"owner":"a"}`)); !ok || err != nil {
+		t.Fatalf("DeleteIf() = %v, %v", ok, err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the records are read again from the file
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	tests := []struct {
+		name  string
+		key   string
+		after time.Duration
+		want  *Record
+	}{
+		{
+			name: "OK: kept",
+			key:  "kept",
+			want: kept,
+		},
+		{
+			name:  "OK: not expired yet",
+			key:   "expiring",
+			after: time.Minute,
+			want:  expiring,
+		},
+		{
+			name:  "OK: expired",
+			key:   "expiring",
+			after: time.Hour,
+		},
+		{
+			name: "OK: deleted",
+			key:  "deleted",
+		},
+		{
+			name: "OK: deleted by the holder",
+			key:  "lock",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reopened.now = func() time.Time { return now.Add(tt.after) }
+			got, err := reopened.Get(ctx, tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("the temporary file is left: %v", err)
+	}
+}
+
+func Test_fileStore_crash(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "auriga.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := &Record{Key: "saved", Value: json.RawMessage(`{"a":1}`), Version: 1}
+	if err := s.Put(ctx, saved); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// the process crashed while writing the next change
+	if err := os.WriteFile(path+".tmp", []byte(`{"saved":{"val`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() after the crash error = %v", err)
+	}
+	defer reopened.Close()
+	got, err := reopened.Get(ctx, "saved")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, saved) {
+		t.Errorf("Get() = %+v, want %+v", got, saved)
+	}
+	// the next change replaces the half-written temporary file
+	if err := reopened.Put(ctx, &Record{Key: "next", Value: json.RawMessage(`{}`), Version: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("the temporary file is left: %v", err)
+	}
+}
+
+func Test_NewFileStore(t *testing.T) {
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(broken, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	used := filepath.Join(dir, "used.json")
+	user, err := NewFileStore(used)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer user.Close()
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{
+			name: "OK: the file does not exist yet",
+			path: filepath.Join(dir, "new.json"),
+		},
+		{
+			name:    "NG: broken file",
+			path:    broken,
+			wantErr: true,
+		},
+		{
+			name:    "NG: used by another store",
+			path:    used,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewFileStore(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewFileStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				s.Close()
+			}
+		})
+	}
+}
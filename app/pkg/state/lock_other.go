@@ -0,0 +1,31 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+// lockFile fails as the file store is supported only where flock is, rather than sharing the file without the lock
+func lockFile(f *os.File) error {
+	return errors.New("the state file is not supported on " + runtime.GOOS)
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// memoryStore keeps the records in memory, which are lost on restart.
+// Unlike the LRU of pkg/cache, the records are never evicted before they expire.
+type memoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	records map[string]*Record
+}
+
+func NewMemoryStore() *memoryStore {
+	return &memoryStore{
+		now:     time.Now,
+		records: map[string]*Record{},
+	}
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[key]
+	if !ok || r.Expired(s.now()) {
+		return nil, nil
+	}
+	record := *r
+	return &record, nil
+}
+
+func (s *memoryStore) Put(ctx context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(record)
+	return nil
+}
+
+func (s *memoryStore) Claim(ctx context.Context, record *Record) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.claimable(record) {
+		return false, nil
+	}
+	s.put(record)
+	return true, nil
+}
+
+// claimable returns true if no unexpired record of the key exists, or it has the same value as record
+func (s *memoryStore) claimable(record *Record) bool {
+	r, ok := s.records[record.Key]
+	return !ok || r.Expired(s.now()) || bytes.Equal(r.Value, record.Value)
+}
+
+// put stores a copy of the record, dropping the expired ones
+func (s *memoryStore) put(record *Record) {
+	now := s.now()
+	for key, r := range s.records {
+		if r.Expired(now) {
+			delete(s.records, key)
+		}
+	}
+	r := *record
+	s.records[record.Key] = &r
+}
+
+func (s *memoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memoryStore) DeleteIf(ctx context.Context, key string, value json.RawMessage) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deleteIf(key, value), nil
+}
+
+// deleteIf removes the record of the key if it has the value
+func (s *memoryStore) deleteIf(key string, value json.RawMessage) bool {
+	r, ok := s.records[key]
+	if !ok || !bytes.Equal(r.Value, value) {
+		return false
+	}
+	delete(s.records, key)
+	return true
+}
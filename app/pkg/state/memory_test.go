@@ -0,0 +1,162 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_memoryStore(t *testing.T) {
+	now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		record *Record
+		after  time.Duration
+		want   *Record
+	}{
+		{
+			name:   "OK: kept",
+			record: &Record{Key: "key", Value: json.RawMessage(`{"a":1}`)},
+			after:  365 * 24 * time.Hour,
+			want:   &Record{Key: "key", Value: json.RawMessage(`{"a":1}`)},
+		},
+		{
+			name:   "OK: not expired yet",
+			record: &Record{Key: "key", Value: json.RawMessage(`{}`), ExpiresAt: now.Add(time.Hour)},
+			after:  time.Minute,
+			want:   &Record{Key: "key", Value: json.RawMessage(`{}`), ExpiresAt: now.Add(time.Hour)},
+		},
+		{
+			name:   "OK: expired",
+			record: &Record{Key: "key", Value: json.RawMessage(`{}`), ExpiresAt: now.Add(time.Hour)},
+			after:  time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := NewMemoryStore()
+			s.now = func() time.Time { return now }
+			if err := s.Put(ctx, tt.record); err != nil {
+				t.Fatal(err)
+			}
+			s.now = func() time.Time { return now.Add(tt.after) }
+			got, err := s.Get(ctx, tt.record.Key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_memoryStore_Claim(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 11, 1, 15, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	lock := func(owner string) *Record {
+		return &Record{Key: "lock", Value: json.RawMessage(`{"owner":"` + owner + `"}`), ExpiresAt: now.Add(time.Minute)}
+	}
+	tests := []struct {
+		name   string
+		record *Record
+		after  time.Duration
+		want   bool
+	}{
+		{
+			name:   "OK: claimed",
+			record: lock("a"),
+			want:   true,
+		},
+		{
+			name:   "OK: claimed by the other",
+			record: lock("b"),
+		},
+		{
+			name:   "OK: renewed by the holder",
+			record: lock("a"),
+			want:   true,
+		},
+		{
+			name:   "OK: claimed by the other after it expires",
+			record: lock("b"),
+			after:  time.Minute,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.now = func() time.Time { return now.Add(tt.after) }
+			got, err := s.Claim(ctx, tt.record)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Claim() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_memoryStore_DeleteIf(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	if err := s.Put(ctx, &Record{Key: "lock", Value: json.RawMessage(`{"owner":"a"}`), Version: 1}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  bool
+	}{
+		{
+			name:  "OK: not removed for the other",
+			key:   "lock",
+			value: `{"owner":"b"}`,
+		},
+		{
+			name:  "OK: removed for the holder",
+			key:   "lock",
+			value: `{"owner":"a"}`,
+			want:  true,
+		},
+		{
+			name:  "OK: not found",
+			key:   "lock",
+			value: `{"owner":"a"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.DeleteIf(ctx, tt.key, json.RawMessage(tt.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("DeleteIf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
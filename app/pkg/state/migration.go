@@ -0,0 +1,51 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"encoding/json"
+
+	"github.com/moneyforward/auriga/app/pkg/errors"
+)
+
+// ErrUnknownVersion is returned when the record is written by a newer version of Auriga
+var ErrUnknownVersion = errors.New("unknown version of the state")
+
+// Migrations upgrade the values of a schema step by step. Migrations[i] upgrades a value of version i+1 to i+2,
+// so the current version is len(Migrations)+1. Append a migration when changing the schema incompatibly.
+// The records are upgraded when they are read, rather than all at once, since they may be shared by running instances.
+type Migrations []func(value json.RawMessage) (json.RawMessage, error)
+
+// Version returns the current version of the schema
+func (m Migrations) Version() int {
+	return len(m) + 1
+}
+
+// Migrate upgrades the value of the version to the current one
+func (m Migrations) Migrate(value json.RawMessage, version int) (json.RawMessage, error) {
+	if version < 1 || version > m.Version() {
+		return nil, errors.Wrapf(ErrUnknownVersion, "version %d", version)
+	}
+	for _, migrate := range m[version-1:] {
+		var err error
+		if value, err = migrate(value); err != nil {
+			return nil, errors.Wrapf(err, "failed to migrate from version %d", version)
+		}
+		version++
+	}
+	return value, nil
+}
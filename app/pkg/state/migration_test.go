@@ -0,0 +1,90 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func Test_Migrations_Migrate(t *testing.T) {
+	migrations := Migrations{
+		// version 2 renames "name" to "title"
+		func(value json.RawMessage) (json.RawMessage, error) {
+			var v map[string]interface{}
+			if err := json.Unmarshal(value, &v); err != nil {
+				return nil, err
+			}
+			v["title"] = v["name"]
+			delete(v, "name")
+			return json.Marshal(v)
+		},
+		// version 3 adds "tags"
+		func(value json.RawMessage) (json.RawMessage, error) {
+			var v map[string]interface{}
+			if err := json.Unmarshal(value, &v); err != nil {
+				return nil, err
+			}
+			v["tags"] = []string{}
+			return json.Marshal(v)
+		},
+	}
+	tests := []struct {
+		name    string
+		value   string
+		version int
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "OK: from version 1",
+			value:   `{"name":"Sprint review"}`,
+			version: 1,
+			want:    `{"tags":[],"title":"Sprint review"}`,
+		},
+		{
+			name:    "OK: from version 2",
+			value:   `{"title":"Sprint review"}`,
+			version: 2,
+			want:    `{"tags":[],"title":"Sprint review"}`,
+		},
+		{
+			name:    "OK: current version",
+			value:   `{"tags":[],"title":"Sprint review"}`,
+			version: 3,
+			want:    `{"tags":[],"title":"Sprint review"}`,
+		},
+		{
+			name:    "NG: written by a newer version",
+			value:   `{}`,
+			version: 4,
+			wantErr: ErrUnknownVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migrations.Migrate(json.RawMessage(tt.value), tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Migrate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Migrate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
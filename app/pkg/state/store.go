@@ -0,0 +1,53 @@
+/*
+ * Copyright 2022 Money Forward, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package state keeps the state of Auriga which outlives the requests, such as the events created from the threads.
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Record is a value in JSON with the version of its schema, which the migrations upgrade
+type Record struct {
+	Key     string
+	Value   json.RawMessage
+	Version int
+	// ExpiresAt is when the record is deleted, or zero to keep it
+	ExpiresAt time.Time
+}
+
+// Expired returns true if the record has expired at now
+func (r *Record) Expired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt)
+}
+
+type Store interface {
+	// Get returns the record of the key, or nil if it is not found or expired
+	Get(ctx context.Context, key string) (*Record, error)
+	// Put stores the record, replacing the one of the same key
+	Put(ctx context.Context, record *Record) error
+	// Claim stores the record unless an unexpired record of the same key with another value exists, and reports whether it stored.
+	// Claiming again with the same value extends the expiry, so the value identifies the holder of a lock.
+	Claim(ctx context.Context, record *Record) (bool, error)
+	// Delete removes the record of the key. It is not an error if the key is not found.
+	Delete(ctx context.Context, key string) error
+	// DeleteIf removes the record of the key only if its value is still value, and reports whether it removed,
+	// so that the holder of a lock does not release the lock taken by the other after its lease.
+	DeleteIf(ctx context.Context, key string, value json.RawMessage) (bool, error)
+}